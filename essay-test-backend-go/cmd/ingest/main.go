@@ -0,0 +1,112 @@
+// Command ingest uploads a CSV or JSONL file of offline submissions to the batch ingestion API and prints the per-row report.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	"essay-test-backend/internal/application/dto"
+	"essay-test-backend/internal/presentation/middleware"
+)
+
+func main() {
+	api := flag.String("api", "http://localhost:5000", "APIサーバーのURL")
+	file := flag.String("file", "", "登録するCSVまたはJSONLファイル")
+	format := flag.String("format", "", "ファイル形式（csv, jsonl）。省略時は拡張子から判断")
+	user := flag.String("user", "", "登録する教員の利用者ID")
+	role := flag.String("role", middleware.RoleTeacher, "登録する教員のロール（teacher, admin）")
+	secret := flag.String("gateway-secret", os.Getenv("GATEWAY_SECRET"), "ゲートウェイとの共有シークレット。省略時は環境変数GATEWAY_SECRET")
+	flag.Parse()
+
+	if *file == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	// シークレットがないと利用者ヘッダーが信頼されず、未認証として拒否される
+	if *secret == "" {
+		log.Fatal("-gateway-secretまたはGATEWAY_SECRETを指定してください")
+	}
+
+	report, err := upload(*api, *file, *format, *user, *role, *secret)
+	if err != nil {
+		log.Fatal("一括登録に失敗: ", err)
+	}
+
+	for _, row := range report.Rows {
+		switch row.Status {
+		case "error":
+			fmt.Printf("%d行目: エラー %s\n", row.Line, row.Error)
+		case "duplicate":
+			fmt.Printf("%d行目: 登録済み（%s）\n", row.Line, row.SubmissionID)
+		}
+	}
+	fmt.Printf("合計%d件: 登録%d件、登録済み%d件、エラー%d件\n", report.Total, report.Created, report.Duplicates, report.Failed)
+
+	if report.Failed > 0 {
+		os.Exit(1)
+	}
+}
+
+func upload(api, path, format, user, role, secret string) (*dto.IngestReportResponse, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile("file", filepath.Base(path))
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.Copy(part, f); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	endpoint := api + "/api/v1/teacher/ingest/submissions"
+	if format != "" {
+		endpoint += "?format=" + url.QueryEscape(format)
+	}
+	req, err := http.NewRequest(http.MethodPost, endpoint, &body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req.Header.Set(middleware.HeaderUserID, user)
+	req.Header.Set(middleware.HeaderUserRole, role)
+	req.Header.Set(middleware.HeaderGatewaySecret, secret)
+
+	client := &http.Client{Timeout: 10 * time.Minute}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Success bool                     `json:"success"`
+		Data    dto.IngestReportResponse `json:"data"`
+		Error   string                   `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("応答を読み取れません（HTTP %d）: %w", resp.StatusCode, err)
+	}
+	if !result.Success {
+		return nil, fmt.Errorf("%s（HTTP %d）", result.Error, resp.StatusCode)
+	}
+	return &result.Data, nil
+}
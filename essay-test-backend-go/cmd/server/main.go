@@ -1,18 +1,29 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"log"
+	"net/http"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"essay-test-backend/internal/application/usecases"
 	"essay-test-backend/internal/infrastructure/database"
 	"essay-test-backend/internal/infrastructure/services"
 	"essay-test-backend/internal/presentation/handlers"
+	"essay-test-backend/internal/presentation/middleware"
 	"essay-test-backend/internal/presentation/routes"
 	"essay-test-backend/pkg/config"
 	"essay-test-backend/pkg/logger"
+	"essay-test-backend/pkg/metrics"
+	"essay-test-backend/pkg/ratelimit"
+	"essay-test-backend/pkg/tracing"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 	"go.uber.org/zap"
 )
 
@@ -30,18 +41,30 @@ func main() {
 	}
 	defer zapLogger.Sync()
 
+	// トレースの初期化
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing)
+	if err != nil {
+		zapLogger.Fatal("トレースの初期化に失敗", zap.Error(err))
+	}
+
 	zapLogger.Info("サーバー起動開始", 
 		zap.String("environment", cfg.Environment),
 		zap.String("port", cfg.Server.Port))
 
 	// データベース接続
-	db, err := database.NewMySQLConnection(cfg.Database)
+	db, err := database.NewMySQLConnection(cfg.Database, zapLogger)
 	if err != nil {
 		zapLogger.Fatal("データベース接続に失敗", zap.Error(err))
 	}
 
 	zapLogger.Info("データベース接続成功")
 
+	sqlDB, err := db.DB()
+	if err != nil {
+		zapLogger.Fatal("データベース接続の取得に失敗", zap.Error(err))
+	}
+	metrics.RegisterDBStats(sqlDB)
+
 	// マイグレーション実行
 	if err := database.Migrate(db); err != nil {
 		zapLogger.Fatal("マイグレーションに失敗", zap.Error(err))
@@ -60,21 +83,205 @@ func main() {
 	testRepo := database.NewMySQLEssayTestRepository(db)
 	submissionRepo := database.NewMySQLSubmissionRepository(db)
 	resultRepo := database.NewMySQLScoringResultRepository(db)
+	similarityRepo := database.NewMySQLSimilarityRepository(db)
+	reviewRepo := database.NewMySQLScoreReviewRepository(db)
+	ratingRepo := database.NewMySQLRatingRepository(db)
+	calibrationRepo := database.NewMySQLCalibrationRepository(db)
+	rescoreJobRepo := database.NewMySQLRescoreJobRepository(db)
+	exemplarRepo := database.NewMySQLModelAnswerRepository(db)
+	annotationRepo := database.NewMySQLAnnotationRepository(db)
+	classRepo := database.NewMySQLClassRepository(db)
+	analyticsRepo := database.NewMySQLAnalyticsRepository(db)
+	transcriptionRepo := database.NewMySQLTranscriptionRepository(db)
+	idempotencyRepo := database.NewMySQLIdempotencyRepository(db)
+	searchRepo := database.NewMySQLEssaySearchRepository(db)
 
 	// サービスの初期化
-	scoringService := services.NewFallbackScoringService(cfg, zapLogger)
+	baseScoringService := services.NewInstrumentedScoringService(services.NewFallbackScoringService(cfg, zapLogger), "fallback")
+	scoringService := services.NewCalibratedScoringService(baseScoringService, calibrationRepo, zapLogger)
+	reportRenderer, err := services.NewPDFReportRenderer(cfg.Report, zapLogger)
+	if err != nil {
+		zapLogger.Fatal("レポート用フォントを利用できません。REPORT_FONT_PATHを設定するかREPORT_ENABLED=falseで無効にしてください", zap.Error(err))
+	}
+	fileStorage := services.NewLocalFileStorage(cfg.Upload.Dir)
+	ocrService := services.NewOCRService(cfg.OCR, zapLogger)
 
 	// ユースケースの初期化
+	similarityUsecase := usecases.NewSimilarityUsecase(
+		testRepo,
+		submissionRepo,
+		similarityRepo,
+		cfg.Similarity,
+		zapLogger,
+	)
+	annotationUsecase := usecases.NewAnnotationUsecase(
+		submissionRepo,
+		annotationRepo,
+		zapLogger,
+	)
+	analyticsUsecase := usecases.NewAnalyticsUsecase(
+		testRepo,
+		submissionRepo,
+		resultRepo,
+		analyticsRepo,
+		zapLogger,
+	)
 	testUsecase := usecases.NewEssayTestUsecase(
 		testRepo, 
 		submissionRepo, 
 		resultRepo, 
+		exemplarRepo,
+		annotationRepo,
+		classRepo,
+		transcriptionRepo,
+		ratingRepo,
 		scoringService, 
+		cfg.Limits,
+		zapLogger,
+		similarityUsecase,
+		annotationUsecase,
+		analyticsUsecase,
+		services.NewMetricsListener(),
+	)
+	scoringQueue := usecases.NewScoringQueue(
+		testUsecase,
+		submissionRepo,
+		cfg.Ingest,
+		zapLogger,
+	)
+	ingestUsecase := usecases.NewIngestUsecase(
+		testRepo,
+		submissionRepo,
+		scoringQueue,
+		cfg.Ingest,
+		cfg.Limits,
+		zapLogger,
+	)
+	transcriptionUsecase := usecases.NewTranscriptionUsecase(
+		testRepo,
+		transcriptionRepo,
+		fileStorage,
+		ocrService,
+		cfg.Upload,
+		cfg.Limits,
+		zapLogger,
+	)
+	feedbackGenerator := services.NewFeedbackGenerator()
+	reviewUsecase := usecases.NewReviewUsecase(
+		submissionRepo,
+		resultRepo,
+		reviewRepo,
+		ratingRepo,
+		feedbackGenerator,
+		zapLogger,
+		analyticsUsecase,
+	)
+	ratingUsecase := usecases.NewRatingUsecase(
+		submissionRepo,
+		resultRepo,
+		ratingRepo,
+		reviewRepo,
+		feedbackGenerator,
+		zapLogger,
+		analyticsUsecase,
+	)
+	calibrationUsecase := usecases.NewCalibrationUsecase(
+		testRepo,
+		calibrationRepo,
+		baseScoringService,
+		zapLogger,
+	)
+	exemplarUsecase := usecases.NewExemplarUsecase(
+		testRepo,
+		submissionRepo,
+		exemplarRepo,
+		zapLogger,
+	)
+	classUsecase := usecases.NewClassUsecase(
+		testRepo,
+		submissionRepo,
+		resultRepo,
+		classRepo,
+		zapLogger,
+	)
+	exportUsecase := usecases.NewExportUsecase(
+		testRepo,
+		submissionRepo,
+		resultRepo,
+		classRepo,
+		zapLogger,
+	)
+	searchUsecase := usecases.NewSearchUsecase(
+		searchRepo,
+		testRepo,
+		classRepo,
+		zapLogger,
+	)
+	reportUsecase := usecases.NewReportUsecase(
+		testRepo,
+		submissionRepo,
+		resultRepo,
+		ratingRepo,
+		reportRenderer,
+		zapLogger,
+	)
+	rescoreUsecase := usecases.NewRescoreUsecase(
+		testRepo,
+		submissionRepo,
+		resultRepo,
+		rescoreJobRepo,
+		ratingRepo,
+		reviewRepo,
+		scoringService,
 		zapLogger,
+		analyticsUsecase,
 	)
 
+	idempotencyUsecase := usecases.NewIdempotencyUsecase(
+		idempotencyRepo,
+		zapLogger,
+	)
+	healthUsecase := usecases.NewHealthUsecase(
+		database.NewConnectionHealthChecker(db),
+		database.NewMigrationHealthChecker(db),
+		scoringService,
+		scoringQueue,
+		cfg.Health,
+		zapLogger,
+	)
+
+	// 一括登録された提出物のバックグラウンド採点
+	scoringQueue.Start(context.Background())
+	metrics.RegisterQueueDepth(scoringQueue.Len)
+
+	// 再送の受付期間を過ぎた記録の定期的な削除
+	pruneCtx, stopPruning := context.WithCancel(context.Background())
+	defer stopPruning()
+	idempotencyUsecase.StartPruning(pruneCtx)
+
+	// 前回の停止で中断された再採点ジョブの再開
+	if err := rescoreUsecase.ResumeJobs(context.Background()); err != nil {
+		zapLogger.Error("再採点ジョブの再開に失敗", zap.Error(err))
+	}
+
 	// ハンドラーの初期化
 	testHandler := handlers.NewEssayTestHandler(testUsecase, zapLogger)
+	similarityHandler := handlers.NewSimilarityHandler(similarityUsecase, zapLogger)
+	reviewHandler := handlers.NewReviewHandler(reviewUsecase, zapLogger)
+	ratingHandler := handlers.NewRatingHandler(ratingUsecase, zapLogger)
+	calibrationHandler := handlers.NewCalibrationHandler(calibrationUsecase, zapLogger)
+	rescoreHandler := handlers.NewRescoreHandler(rescoreUsecase, zapLogger)
+	exemplarHandler := handlers.NewExemplarHandler(exemplarUsecase, zapLogger)
+	annotationHandler := handlers.NewAnnotationHandler(annotationUsecase, zapLogger)
+	classHandler := handlers.NewClassHandler(classUsecase, zapLogger)
+	analyticsHandler := handlers.NewAnalyticsHandler(analyticsUsecase, zapLogger)
+	exportHandler := handlers.NewExportHandler(exportUsecase, zapLogger)
+	searchHandler := handlers.NewSearchHandler(searchUsecase, zapLogger)
+	reportHandler := handlers.NewReportHandler(reportUsecase, zapLogger)
+	ingestHandler := handlers.NewIngestHandler(ingestUsecase, zapLogger)
+	transcriptionHandler := handlers.NewTranscriptionHandler(transcriptionUsecase, zapLogger)
+	healthHandler := handlers.NewHealthHandler(healthUsecase, zapLogger)
+	idempotencyHandler := handlers.NewIdempotencyHandler(idempotencyUsecase, zapLogger)
 
 	// Ginエンジンの設定
 	if cfg.Environment == "production" {
@@ -82,27 +289,128 @@ func main() {
 	}
 
 	r := gin.New()
-	r.Use(gin.Logger())
+	// X-Forwarded-Forは設定したプロキシからのものだけを信頼する
+	if err := r.SetTrustedProxies(cfg.Server.TrustedProxies); err != nil {
+		zapLogger.Fatal("信頼するプロキシの設定が不正です", zap.Error(err))
+	}
+	// パニックも500としてアクセスログとメトリクスに残るよう、Recoveryはアクセスログの後に置く
+	r.Use(middleware.RequestID())
+	r.Use(middleware.Metrics())
+	r.Use(otelgin.Middleware(cfg.Tracing.ServiceName))
+	if cfg.Auth.GatewaySecret == "" {
+		zapLogger.Warn("GATEWAY_SECRETが未設定のため、利用者ヘッダーを信頼せずすべてのリクエストを未認証として扱います")
+	}
+	r.Use(middleware.Identity(cfg.Auth.GatewaySecret))
+	r.Use(middleware.AccessLog(zapLogger))
 	r.Use(gin.Recovery())
+	r.Use(middleware.BodyLimit(cfg.Limits.MaxBodyBytes, cfg.Limits.MaxUploadBytes))
 
 	// CORS設定
 	corsConfig := cors.DefaultConfig()
 	corsConfig.AllowOrigins = cfg.CORS.AllowedOrigins
 	corsConfig.AllowMethods = []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"}
-	corsConfig.AllowHeaders = []string{"Origin", "Content-Type", "Authorization"}
+	corsConfig.AllowHeaders = []string{"Origin", "Content-Type", "Authorization", middleware.HeaderRequestID, handlers.HeaderIdempotencyKey, "traceparent", "tracestate"}
+	corsConfig.ExposeHeaders = []string{middleware.HeaderRequestID, handlers.HeaderIdempotentReplay, "Retry-After"}
 	corsConfig.AllowCredentials = true
 	r.Use(cors.New(corsConfig))
 
 	zapLogger.Info("CORS設定完了", zap.Strings("allowed_origins", cfg.CORS.AllowedOrigins))
 
+	// 採点を伴うルートのレート制限
+	rateLimitStore := ratelimit.NewMemoryStore()
+	rateLimit := func(name string, policy, anonymous config.RateLimitPolicy) gin.HandlerFunc {
+		if !cfg.RateLimit.Enabled {
+			return func(c *gin.Context) { c.Next() }
+		}
+		return middleware.RateLimit(rateLimitStore, name,
+			ratelimit.Policy{PerMinute: policy.PerMinute, Burst: policy.Burst},
+			ratelimit.Policy{PerMinute: anonymous.PerMinute, Burst: anonymous.Burst})
+	}
+
+	// API仕様（ルートの登録後に生成する）
+	docsHandler := handlers.NewDocsHandler()
+
 	// ルートの設定
-	routes.SetupRoutes(r, testHandler)
+	routes.SetupRoutes(r, routes.Handlers{
+		EssayTest:  testHandler,
+		Similarity: similarityHandler,
+		Review:     reviewHandler,
+		Rating:     ratingHandler,
+		Calibration: calibrationHandler,
+		Rescore:     rescoreHandler,
+		Exemplar:    exemplarHandler,
+		Annotation:  annotationHandler,
+		Class:       classHandler,
+		Analytics:   analyticsHandler,
+		Export:      exportHandler,
+		Search:      searchHandler,
+		Report:      reportHandler,
+		Ingest:      ingestHandler,
+		Transcription: transcriptionHandler,
+		Health:        healthHandler,
+		Idempotency:   idempotencyHandler,
+		Docs:          docsHandler,
+	}, routes.RateLimits{
+		Submit:  rateLimit("submit", cfg.RateLimit.Submit, cfg.RateLimit.SubmitAnonymous),
+		Upload:  rateLimit("upload", cfg.RateLimit.Upload, cfg.RateLimit.Upload),
+		Scoring: rateLimit("scoring", cfg.RateLimit.Scoring, cfg.RateLimit.Scoring),
+	})
+
+	// 登録したルートからAPI仕様を生成する。記載漏れはテストで検出するため、ここでは警告に留める
+	spec := routes.OpenAPISpec(r)
+	if err := docsHandler.SetSpec(spec); err != nil {
+		zapLogger.Fatal("API仕様の生成に失敗", zap.Error(err))
+	}
+	if err := routes.VerifyOpenAPI(r, spec); err != nil {
+		zapLogger.Warn("API仕様に記載されていないルートがあります", zap.Error(err))
+	}
 
 	zapLogger.Info("ルート設定完了")
 
 	// サーバー起動
-	zapLogger.Info("サーバー起動", zap.String("port", cfg.Server.Port))
-	if err := r.Run(":" + cfg.Server.Port); err != nil {
-		zapLogger.Fatal("サーバー起動に失敗", zap.Error(err))
+	srv := &http.Server{
+		Addr:    ":" + cfg.Server.Port,
+		Handler: r,
+	}
+	go func() {
+		zapLogger.Info("サーバー起動", zap.String("port", cfg.Server.Port))
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			zapLogger.Fatal("サーバー起動に失敗", zap.Error(err))
+		}
+	}()
+
+	// 停止シグナルを待つ
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	<-ctx.Done()
+	stop()
+
+	zapLogger.Info("サーバー停止開始", zap.Duration("timeout", cfg.Server.ShutdownTimeout))
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+	defer cancel()
+
+	// 新しいリクエストの受付を止め、処理中のリクエスト（提出の採点を含む）の完了を待つ。
+	// 期限内に終わらなかった提出は採点待ちのまま残り、次回の起動時に採点キューが採点する
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		zapLogger.Warn("処理中のリクエストが期限内に完了しませんでした", zap.Error(err))
+	}
+	if err := scoringQueue.Shutdown(shutdownCtx); err != nil {
+		zapLogger.Warn("採点キューの処理が期限内に完了しませんでした。未採点の提出物は次回の起動時に採点します", zap.Error(err))
+	}
+	if err := rescoreUsecase.Shutdown(shutdownCtx); err != nil {
+		zapLogger.Warn("再採点ジョブが期限内に完了しませんでした。次回の起動時に再開します", zap.Error(err))
+	}
+	stopPruning()
+
+	if err := sqlDB.Close(); err != nil {
+		zapLogger.Error("データベース接続の切断に失敗", zap.Error(err))
+	}
+
+	// 残りのスパンを送信する
+	tracingCtx, cancelTracing := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancelTracing()
+	if err := shutdownTracing(tracingCtx); err != nil {
+		zapLogger.Error("トレースの送信に失敗", zap.Error(err))
 	}
-} 
\ No newline at end of file
+
+	zapLogger.Info("サーバー停止完了")
+}
@@ -0,0 +1,45 @@
+package dto
+
+import "time"
+
+// Response DTOs
+type TestAnalyticsResponse struct {
+	TestID       string                      `json:"test_id"`
+	TestTitle    string                      `json:"test_title"`
+	Participants int                         `json:"participants"`
+	Submissions  int                         `json:"submissions"`
+	Total        ScoreStatisticsResponse     `json:"total"`
+	Questions    []QuestionAnalyticsResponse `json:"questions"`
+	RebuiltAt    *time.Time                  `json:"rebuilt_at,omitempty"`
+	UpdatedAt    *time.Time                  `json:"updated_at,omitempty"`
+}
+
+type ScoreStatisticsResponse struct {
+	N          int                    `json:"n"`
+	MaxScore   int                    `json:"max_score"`
+	Mean       float64                `json:"mean"`
+	Median     float64                `json:"median"`
+	StdDev     float64                `json:"std_dev"`
+	Difficulty float64                `json:"difficulty"` // 平均得点率（0〜1、低いほど難しい）
+	Histogram  []HistogramBinResponse `json:"histogram"`
+}
+
+// HistogramBinResponse counts the results scoring between From and To inclusive
+type HistogramBinResponse struct {
+	From  int `json:"from"`
+	To    int `json:"to"`
+	Count int `json:"count"`
+}
+
+type QuestionAnalyticsResponse struct {
+	QuestionNum            int                          `json:"question_num"`
+	Score                  ScoreStatisticsResponse      `json:"score"`
+	AverageLength          float64                      `json:"average_length"`
+	LengthScoreCorrelation float64                      `json:"length_score_correlation"`
+	Criteria               []CriterionAnalyticsResponse `json:"criteria"`
+}
+
+type CriterionAnalyticsResponse struct {
+	CriteriaName string                  `json:"criteria_name"`
+	Score        ScoreStatisticsResponse `json:"score"`
+}
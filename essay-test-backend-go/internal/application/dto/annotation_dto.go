@@ -0,0 +1,33 @@
+package dto
+
+import "time"
+
+// Request DTOs
+type AnnotationRequest struct {
+	AnswerID         string  `json:"answer_id"`
+	Start            int     `json:"start"`
+	End              int     `json:"end"`
+	Category         string  `json:"category" binding:"required"`
+	Severity         string  `json:"severity" binding:"required"`
+	Comment          string  `json:"comment" binding:"required"`
+	SuggestedRewrite *string `json:"suggested_rewrite,omitempty"`
+}
+
+// Response DTOs
+type AnnotationResponse struct {
+	ID               string    `json:"id"`
+	AnswerID         string    `json:"answer_id"`
+	Start            int       `json:"start"`
+	End              int       `json:"end"`
+	NormalizedStart  int       `json:"normalized_start"`
+	NormalizedEnd    int       `json:"normalized_end"`
+	Quote            string    `json:"quote"`
+	Category         string    `json:"category"`
+	Severity         string    `json:"severity"`
+	Comment          string    `json:"comment"`
+	SuggestedRewrite *string   `json:"suggested_rewrite,omitempty"`
+	Source           string    `json:"source"`
+	Rule             string    `json:"rule,omitempty"`
+	AuthorID         string    `json:"author_id,omitempty"`
+	CreatedAt        time.Time `json:"created_at"`
+}
@@ -0,0 +1,61 @@
+package dto
+
+import "time"
+
+// Request DTOs
+type AnchorEssayRequest struct {
+	Title   string                       `json:"title" binding:"required"`
+	Answers []AnswerRequest              `json:"answers" binding:"required"`
+	Scores  []AnchorCriteriaScoreRequest `json:"scores" binding:"required"`
+}
+
+type AnchorCriteriaScoreRequest struct {
+	QuestionNum  int    `json:"question_num" binding:"required"`
+	CriteriaName string `json:"criteria_name" binding:"required"`
+	Score        int    `json:"score"`
+}
+
+type ApplyCalibrationRequest struct {
+	RunID string `json:"run_id" binding:"required"`
+}
+
+// Response DTOs
+type AnchorEssayResponse struct {
+	ID        string                       `json:"id"`
+	TestID    string                       `json:"test_id"`
+	Title     string                       `json:"title"`
+	Answers   []AnswerRequest              `json:"answers"`
+	Scores    []AnchorCriteriaScoreRequest `json:"scores"`
+	CreatedBy string                       `json:"created_by"`
+	CreatedAt time.Time                    `json:"created_at"`
+}
+
+type CalibrationRunResponse struct {
+	ID          string                         `json:"id"`
+	TestID      string                         `json:"test_id"`
+	ScoredBy    string                         `json:"scored_by"`
+	AnchorCount int                            `json:"anchor_count"`
+	Criteria    []CriterionCalibrationResponse `json:"criteria"`
+	CreatedBy   string                         `json:"created_by"`
+	CreatedAt   time.Time                      `json:"created_at"`
+}
+
+type CriterionCalibrationResponse struct {
+	QuestionNum       int     `json:"question_num"`
+	CriteriaName      string  `json:"criteria_name"`
+	MaxScore          int     `json:"max_score"`
+	N                 int     `json:"n"`
+	Bias              float64 `json:"bias"`
+	MeanAbsoluteError float64 `json:"mean_absolute_error"`
+	RMSE              float64 `json:"rmse"`
+	Slope             float64 `json:"slope"`
+	Intercept         float64 `json:"intercept"`
+}
+
+type TestCalibrationResponse struct {
+	TestID    string                         `json:"test_id"`
+	RunID     string                         `json:"run_id"`
+	Criteria  []CriterionCalibrationResponse `json:"criteria"`
+	AppliedBy string                         `json:"applied_by"`
+	UpdatedAt time.Time                      `json:"updated_at"`
+}
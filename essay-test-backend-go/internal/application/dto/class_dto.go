@@ -0,0 +1,127 @@
+package dto
+
+import "time"
+
+// Request DTOs
+type OrganizationRequest struct {
+	Name string `json:"name" binding:"required"`
+	Type string `json:"type"`
+}
+
+type ClassRequest struct {
+	OrganizationID string `json:"organization_id" binding:"required"`
+	Name           string `json:"name" binding:"required"`
+}
+
+type JoinClassRequest struct {
+	InviteCode string `json:"invite_code" binding:"required"`
+}
+
+type ClassTeacherRequest struct {
+	UserID string `json:"user_id" binding:"required"`
+}
+
+type AssignmentRequest struct {
+	TestID            string     `json:"test_id" binding:"required"`
+	Title             string     `json:"title"`
+	OpensAt           *time.Time `json:"opens_at"`
+	DueAt             *time.Time `json:"due_at"`
+	ClosesAt          *time.Time `json:"closes_at"`
+	MaxAttempts       int        `json:"max_attempts"`
+	AttemptPolicy     string     `json:"attempt_policy"` // best, latest（省略時は latest）
+	LatePenaltyPerDay float64    `json:"late_penalty_per_day"`
+	MaxLatePenalty    float64    `json:"max_late_penalty"`
+}
+
+// Response DTOs
+type OrganizationResponse struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Type      string    `json:"type"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type ClassResponse struct {
+	ID             string                `json:"id"`
+	OrganizationID string                `json:"organization_id"`
+	Name           string                `json:"name"`
+	InviteCode     string                `json:"invite_code,omitempty"`
+	Members        []ClassMemberResponse `json:"members,omitempty"`
+	CreatedAt      time.Time             `json:"created_at"`
+}
+
+type ClassMemberResponse struct {
+	UserID   string    `json:"user_id"`
+	Role     string    `json:"role"`
+	JoinedAt time.Time `json:"joined_at"`
+}
+
+type AssignmentResponse struct {
+	ID                string     `json:"id"`
+	ClassID           string     `json:"class_id"`
+	TestID            string     `json:"test_id"`
+	TestTitle         string     `json:"test_title"`
+	Title             string     `json:"title"`
+	OpensAt           *time.Time `json:"opens_at,omitempty"`
+	DueAt             *time.Time `json:"due_at,omitempty"`
+	ClosesAt          *time.Time `json:"closes_at,omitempty"`
+	MaxAttempts       int        `json:"max_attempts"`
+	AttemptPolicy     string     `json:"attempt_policy"`
+	LatePenaltyPerDay float64    `json:"late_penalty_per_day"`
+	MaxLatePenalty    float64    `json:"max_late_penalty"`
+	CreatedAt         time.Time  `json:"created_at"`
+}
+
+// AssignmentAttemptResponse describes how a result counts towards its assignment
+type AssignmentAttemptResponse struct {
+	AssignmentID       string  `json:"assignment_id"`
+	Title              string  `json:"title"`
+	Attempt            int     `json:"attempt"`
+	MaxAttempts        int     `json:"max_attempts"`
+	AttemptPolicy      string  `json:"attempt_policy"`
+	Counted            bool    `json:"counted"` // 課題の成績として採用されている提出か
+	Late               bool    `json:"late"`
+	LatePenaltyPercent float64 `json:"late_penalty_percent"`
+}
+
+// StudentAssignmentResponse is an assignment as seen by one of the class's students
+type StudentAssignmentResponse struct {
+	Assignment AssignmentResponse      `json:"assignment"`
+	ClassName  string                  `json:"class_name"`
+	Status     StudentProgressResponse `json:"status"`
+}
+
+type StudentProgressResponse struct {
+	UserID      string     `json:"user_id"`
+	Submitted   bool       `json:"submitted"`
+	Attempts    int        `json:"attempts"`
+	SubmittedAt *time.Time `json:"submitted_at,omitempty"`
+	Late        bool       `json:"late"`
+	LatePenalty int        `json:"late_penalty,omitempty"`
+	ResultID    string     `json:"result_id,omitempty"`
+	TotalScore  *int       `json:"total_score,omitempty"`
+	MaxScore    int        `json:"max_score,omitempty"`
+	Percentage  *float64   `json:"percentage,omitempty"`
+}
+
+type AssignmentProgressResponse struct {
+	Assignment     AssignmentResponse        `json:"assignment"`
+	StudentCount   int                       `json:"student_count"`
+	CompletedCount int                       `json:"completed_count"`
+	CompletionRate float64                   `json:"completion_rate"`
+	AverageScore   *float64                  `json:"average_score,omitempty"`
+	Students       []StudentProgressResponse `json:"students"`
+}
+
+type ClassScoresResponse struct {
+	Class       ClassResponse           `json:"class"`
+	Assignments []AssignmentResponse    `json:"assignments"`
+	Students    []StudentScoresResponse `json:"students"`
+}
+
+type StudentScoresResponse struct {
+	UserID            string                    `json:"user_id"`
+	Assignments       []StudentProgressResponse `json:"assignments"` // assignments と同じ順序
+	CompletedCount    int                       `json:"completed_count"`
+	AveragePercentage *float64                  `json:"average_percentage,omitempty"`
+}
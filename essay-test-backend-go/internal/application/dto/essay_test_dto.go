@@ -6,15 +6,32 @@ import "time"
 type SubmissionRequest struct {
 	TestID  string          `json:"test_id" binding:"required"`
 	UserID  string          `json:"user_id,omitempty"`
+	AssignmentID string     `json:"assignment_id,omitempty"`
 	Answers []AnswerRequest `json:"answers" binding:"required"`
 }
 
 type AnswerRequest struct {
 	QuestionID string `json:"question_id" binding:"required"`
-	Content    string `json:"content" binding:"required"`
+	Content    string `json:"content" binding:"required_without=TranscriptionID"`
+	TranscriptionID string `json:"transcription_id,omitempty"` // 確認済みの手書き答案の読み取り結果を回答にする
+}
+
+// TestListRequest is the query of the test catalog
+type TestListRequest struct {
+	Category   string `form:"category"`
+	Difficulty string `form:"difficulty"`
+	Q          string `form:"q"`      // タイトル・説明の検索語（空白区切りですべてを含むもの）
+	Sort       string `form:"sort"`   // newest（省略時）, popular
+	Cursor     string `form:"cursor"` // 前のページのnext_cursor
+	Limit      int    `form:"limit"`
 }
 
 // Response DTOs
+type TestPageResponse struct {
+	Tests      []EssayTestResponse `json:"tests"`
+	NextCursor string              `json:"next_cursor,omitempty"` // 次のページがなければ空
+}
+
 type EssayTestResponse struct {
 	ID           string             `json:"id"`
 	Title        string             `json:"title"`
@@ -50,6 +67,8 @@ type SubmissionResponse struct {
 	TotalScore int     `json:"total_score"`
 	MaxScore   int     `json:"max_score"`
 	Percentage float64 `json:"percentage"`
+	Attempt    int     `json:"attempt,omitempty"`
+	LatePenalty int    `json:"late_penalty,omitempty"`
 	Message    string  `json:"message"`
 }
 
@@ -60,28 +79,52 @@ type ScoringResultResponse struct {
 	MaxScore   int                     `json:"max_score"`
 	Percentage float64                 `json:"percentage"`
 	Details    []QuestionScoreResponse `json:"details"`
+	Answers    []AnswerResponse        `json:"answers,omitempty"`
 	Feedback   string                  `json:"feedback"`
+	FeedbackDetail FeedbackResponse    `json:"feedback_detail"`
 	ScoredBy   string                  `json:"scored_by"`
+	AutoTotalScore int                 `json:"auto_total_score"`
+	LatePenalty int                    `json:"late_penalty"`
+	Assignment *AssignmentAttemptResponse `json:"assignment,omitempty"`
+	ReviewedAt *time.Time              `json:"reviewed_at,omitempty"`
+	Version    int                     `json:"version"`
+	IsCurrent  bool                    `json:"is_current"`
+	ScorerVersion string               `json:"scorer_version"`
 	CreatedAt  time.Time               `json:"created_at"`
 	ExpiresAt  time.Time               `json:"expires_at"`
 }
 
 type QuestionScoreResponse struct {
+	ID             string                    `json:"id"`
 	QuestionNum    int                       `json:"question_num"`
 	Score          int                       `json:"score"`
+	AutoScore      int                       `json:"auto_score"`
 	MaxScore       int                       `json:"max_score"`
 	Percentage     float64                   `json:"percentage"`
 	CriteriaScores []CriteriaScoreResponse   `json:"criteria_scores"`
 	Comment        string                    `json:"comment"`
 	Reasoning      string                    `json:"reasoning"`
+	Exemplar       *ExemplarComparisonResponse `json:"exemplar,omitempty"`
 }
 
 type CriteriaScoreResponse struct {
+	ID           string `json:"id"`
 	CriteriaName string `json:"criteria_name"`
 	Score        int    `json:"score"`
 	MaxScore     int    `json:"max_score"`
 	Comment      string `json:"comment"`
 	Reasoning    string `json:"reasoning"`
+	Evidence     []string `json:"evidence"`
+	Suggestions  []string `json:"suggestions"`
+	AutoScore    int    `json:"auto_score"`
+	Overridden   bool   `json:"overridden"`
+}
+
+// FeedbackResponse mirrors the feedback shape of the TypeScript backend
+type FeedbackResponse struct {
+	Strengths         []string `json:"strengths"`
+	Improvements      []string `json:"improvements"`
+	OverallAssessment string   `json:"overall_assessment"`
 }
 
 // API Response wrapper
@@ -0,0 +1,45 @@
+package dto
+
+import "time"
+
+// Request DTOs
+type ModelAnswerRequest struct {
+	QuestionID string `json:"question_id" binding:"required"`
+	Title      string `json:"title"`
+	Content    string `json:"content" binding:"required"`
+	Score      int    `json:"score"`
+	Commentary string `json:"commentary"`
+}
+
+// Response DTOs
+type ModelAnswerResponse struct {
+	ID          string    `json:"id"`
+	TestID      string    `json:"test_id"`
+	QuestionID  string    `json:"question_id"`
+	QuestionNum int       `json:"question_num"`
+	Title       string    `json:"title"`
+	Content     string    `json:"content"`
+	Score       int       `json:"score"`
+	MaxScore    int       `json:"max_score"`
+	Commentary  string    `json:"commentary"`
+	CreatedBy   string    `json:"created_by,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type AnswerStructureResponse struct {
+	Length             int    `json:"length"`
+	Paragraphs         int    `json:"paragraphs"`
+	Sentences          int    `json:"sentences"`
+	ThesisPosition     string `json:"thesis_position"` // opening, middle, closing, none
+	ExampleCount       int    `json:"example_count"`
+	HasCounterargument bool   `json:"has_counterargument"`
+	HasConclusion      bool   `json:"has_conclusion"`
+}
+
+type ExemplarComparisonResponse struct {
+	Exemplar          ModelAnswerResponse     `json:"exemplar"`
+	Student           AnswerStructureResponse `json:"student"`
+	ExemplarStructure AnswerStructureResponse `json:"exemplar_structure"`
+	Differences       []string                `json:"differences"`
+}
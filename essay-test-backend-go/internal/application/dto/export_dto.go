@@ -0,0 +1,13 @@
+package dto
+
+import "time"
+
+// Request DTOs
+type ResultExportRequest struct {
+	Format          string     `form:"format"` // csv（省略時）, xlsx
+	TestID          string     `form:"test_id"`
+	ClassID         string     `form:"class_id"`
+	From            *time.Time `form:"from"`
+	To              *time.Time `form:"to"`
+	IncludeFeedback bool       `form:"include_feedback"`
+}
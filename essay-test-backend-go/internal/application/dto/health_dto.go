@@ -0,0 +1,13 @@
+package dto
+
+// Response DTOs
+type ReadinessResponse struct {
+	Status     string                     `json:"status"` // ready, not_ready
+	Components map[string]ComponentHealth `json:"components"`
+}
+
+type ComponentHealth struct {
+	Status     string `json:"status"` // ok, error
+	Error      string `json:"error,omitempty"`
+	DurationMs int64  `json:"duration_ms"`
+}
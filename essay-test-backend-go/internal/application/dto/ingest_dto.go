@@ -0,0 +1,32 @@
+package dto
+
+// IngestRecord is one submission of a batch file; answers name their question by ID or by number
+type IngestRecord struct {
+	IdempotencyKey string         `json:"idempotency_key"`
+	UserID         string         `json:"user_id"`
+	TestID         string         `json:"test_id"`
+	Answers        []IngestAnswer `json:"answers"`
+}
+
+type IngestAnswer struct {
+	QuestionID  string `json:"question_id,omitempty"`
+	QuestionNum int    `json:"question_num,omitempty"`
+	Content     string `json:"content"`
+}
+
+// Response DTOs
+type IngestReportResponse struct {
+	Total      int                 `json:"total"`
+	Created    int                 `json:"created"`
+	Duplicates int                 `json:"duplicates"`
+	Failed     int                 `json:"failed"`
+	Rows       []IngestRowResponse `json:"rows"`
+}
+
+type IngestRowResponse struct {
+	Line           int    `json:"line"`
+	Status         string `json:"status"` // created, duplicate, error
+	IdempotencyKey string `json:"idempotency_key,omitempty"`
+	SubmissionID   string `json:"submission_id,omitempty"`
+	Error          string `json:"error,omitempty"`
+}
@@ -0,0 +1,92 @@
+package dto
+
+import "time"
+
+// Request DTOs
+type AssignRatersRequest struct {
+	RaterIDs  []string `json:"rater_ids" binding:"required"`
+	Threshold int      `json:"threshold"`
+}
+
+type AssignAdjudicatorRequest struct {
+	RaterID string `json:"rater_id" binding:"required"`
+}
+
+type RaterScoresRequest struct {
+	Scores []RaterScoreRequest `json:"scores" binding:"required"`
+}
+
+type RaterScoreRequest struct {
+	CriteriaScoreID string `json:"criteria_score_id" binding:"required"`
+	Score           int    `json:"score"`
+	Comment         string `json:"comment"`
+}
+
+// Response DTOs
+type RatingSessionResponse struct {
+	ID            string                    `json:"id"`
+	SubmissionID  string                    `json:"submission_id"`
+	TestID        string                    `json:"test_id"`
+	ResultID      string                    `json:"result_id"`
+	Threshold     int                       `json:"threshold"`
+	Status        string                    `json:"status"`
+	Discrepancies []string                  `json:"discrepancies"`
+	Assignments   []RaterAssignmentResponse `json:"assignments"`
+	FinalizedAt   *time.Time                `json:"finalized_at,omitempty"`
+	CreatedAt     time.Time                 `json:"created_at"`
+}
+
+type RaterAssignmentResponse struct {
+	ID           string              `json:"id"`
+	SubmissionID string              `json:"submission_id"`
+	TestID       string              `json:"test_id"`
+	RaterID      string              `json:"rater_id"`
+	Role         string              `json:"role"`
+	Status       string              `json:"status"`
+	Scores       []RaterScoreRequest `json:"scores,omitempty"`
+	SubmittedAt  *time.Time          `json:"submitted_at,omitempty"`
+	CreatedAt    time.Time           `json:"created_at"`
+}
+
+// RatingTaskResponse is what a rater sees: the answers and rubric without the automated or other raters' scores
+type RatingTaskResponse struct {
+	Assignment  RaterAssignmentResponse   `json:"assignment"`
+	Answers     []AnswerResponse          `json:"answers"`
+	Criteria    []RatingCriterionResponse `json:"criteria"`
+	OtherScores []RaterAssignmentResponse `json:"other_scores,omitempty"` // 裁定者のみ
+}
+
+type RatingCriterionResponse struct {
+	CriteriaScoreID string `json:"criteria_score_id"`
+	QuestionNum     int    `json:"question_num"`
+	CriteriaName    string `json:"criteria_name"`
+	MaxScore        int    `json:"max_score"`
+	Disputed        bool   `json:"disputed"`
+}
+
+type AgreementResponse struct {
+	TestID   string                       `json:"test_id"`
+	Sessions int                          `json:"sessions"`
+	Overall  AgreementSummary             `json:"overall"`
+	Criteria []CriterionAgreementResponse `json:"criteria"`
+}
+
+type AgreementSummary struct {
+	RaterAgreement     AgreementStats `json:"rater_agreement"`
+	HumanAutoAgreement AgreementStats `json:"human_auto_agreement"`
+}
+
+type CriterionAgreementResponse struct {
+	QuestionNum        int            `json:"question_num"`
+	CriteriaName       string         `json:"criteria_name"`
+	MaxScore           int            `json:"max_score"`
+	RaterAgreement     AgreementStats `json:"rater_agreement"`
+	HumanAutoAgreement AgreementStats `json:"human_auto_agreement"`
+}
+
+type AgreementStats struct {
+	N                      int     `json:"n"`
+	ExactAgreement         float64 `json:"exact_agreement"`
+	AdjacentAgreement      float64 `json:"adjacent_agreement"`
+	QuadraticWeightedKappa float64 `json:"quadratic_weighted_kappa"`
+}
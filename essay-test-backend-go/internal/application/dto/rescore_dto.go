@@ -0,0 +1,58 @@
+package dto
+
+import "time"
+
+// Request DTOs
+type RescoreJobRequest struct {
+	TestID          string     `json:"test_id"`
+	From            *time.Time `json:"from"`
+	To              *time.Time `json:"to"`
+	IncludeReviewed bool       `json:"include_reviewed"`
+}
+
+// Response DTOs
+type RescoreJobResponse struct {
+	ID               string     `json:"id"`
+	TestID           string     `json:"test_id,omitempty"`
+	From             *time.Time `json:"from,omitempty"`
+	To               *time.Time `json:"to,omitempty"`
+	IncludeReviewed  bool       `json:"include_reviewed"`
+	ScorerVersion    string     `json:"scorer_version"`
+	Status           string     `json:"status"`
+	Total            int        `json:"total"`
+	Processed        int        `json:"processed"`
+	Changed          int        `json:"changed"`
+	ReviewsDiscarded int        `json:"reviews_discarded"`
+	Skipped          int        `json:"skipped"`
+	Failed           int        `json:"failed"`
+	Progress         float64    `json:"progress"`
+	Error            string     `json:"error,omitempty"`
+	CreatedBy        string     `json:"created_by"`
+	StartedAt        *time.Time `json:"started_at,omitempty"`
+	FinishedAt       *time.Time `json:"finished_at,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+}
+
+type RescoreDiffResponse struct {
+	SubmissionID     string                        `json:"submission_id"`
+	OldResultID      string                        `json:"old_result_id,omitempty"`
+	NewResultID      string                        `json:"new_result_id"`
+	OldScorerVersion string                        `json:"old_scorer_version"`
+	OldTotalScore    int                           `json:"old_total_score"`
+	NewTotalScore    int                           `json:"new_total_score"`
+	Delta            int                           `json:"delta"`
+	ReviewDiscarded  bool                          `json:"review_discarded"`
+	CriteriaChanges  []CriteriaScoreChangeResponse `json:"criteria_changes"`
+}
+
+type CriteriaScoreChangeResponse struct {
+	QuestionNum  int    `json:"question_num"`
+	CriteriaName string `json:"criteria_name"`
+	OldScore     int    `json:"old_score"`
+	NewScore     int    `json:"new_score"`
+}
+
+type RescoreReportResponse struct {
+	Job   RescoreJobResponse    `json:"job"`
+	Diffs []RescoreDiffResponse `json:"diffs"`
+}
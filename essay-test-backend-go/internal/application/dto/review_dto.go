@@ -0,0 +1,63 @@
+package dto
+
+import "time"
+
+// Request DTOs
+type ReviewRequest struct {
+	Adjustments      []CriteriaAdjustmentRequest `json:"adjustments"`
+	QuestionComments []QuestionCommentRequest    `json:"question_comments"`
+	Feedback         *string                     `json:"feedback,omitempty"`
+}
+
+type CriteriaAdjustmentRequest struct {
+	CriteriaScoreID string  `json:"criteria_score_id" binding:"required"`
+	Score           *int    `json:"score,omitempty"`
+	Comment         *string `json:"comment,omitempty"`
+}
+
+type QuestionCommentRequest struct {
+	QuestionScoreID string `json:"question_score_id" binding:"required"`
+	Comment         string `json:"comment"`
+}
+
+// Response DTOs
+type ReviewResponse struct {
+	SubmissionID string                 `json:"submission_id"`
+	TestID       string                 `json:"test_id"`
+	UserID       string                 `json:"user_id,omitempty"`
+	Answers      []AnswerResponse       `json:"answers"`
+	Result       *ScoringResultResponse `json:"result"`
+	Review       *ScoreReviewResponse   `json:"review,omitempty"`
+	AuditLogs    []AuditLogResponse     `json:"audit_logs"`
+}
+
+type AnswerResponse struct {
+	ID              string               `json:"id"`
+	QuestionID      string               `json:"question_id"`
+	Content         string               `json:"content"`
+	WordCount       int                  `json:"word_count"`
+	TranscriptionID string               `json:"transcription_id,omitempty"`
+	Annotations     []AnnotationResponse `json:"annotations,omitempty"`
+}
+
+type ScoreReviewResponse struct {
+	ID               string                      `json:"id"`
+	ResultID         string                      `json:"result_id"`
+	ReviewerID       string                      `json:"reviewer_id"`
+	Status           string                      `json:"status"`
+	Adjustments      []CriteriaAdjustmentRequest `json:"adjustments"`
+	QuestionComments []QuestionCommentRequest    `json:"question_comments"`
+	Feedback         *string                     `json:"feedback,omitempty"`
+	PublishedAt      *time.Time                  `json:"published_at,omitempty"`
+	UpdatedAt        time.Time                   `json:"updated_at"`
+}
+
+type AuditLogResponse struct {
+	ID        string    `json:"id"`
+	ActorID   string    `json:"actor_id"`
+	Action    string    `json:"action"`
+	Target    string    `json:"target"`
+	OldValue  string    `json:"old_value"`
+	NewValue  string    `json:"new_value"`
+	CreatedAt time.Time `json:"created_at"`
+}
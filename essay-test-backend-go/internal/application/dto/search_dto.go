@@ -0,0 +1,49 @@
+package dto
+
+import "time"
+
+// EssaySearchRequest is the query of the essay search; scores are total scores, both bounds inclusive
+type EssaySearchRequest struct {
+	Q        string     `form:"q" binding:"required"` // 空白区切りの語をすべて含む回答・講評を探す
+	TestID   string     `form:"test_id"`
+	ClassID  string     `form:"class_id"`
+	ScoreMin *int       `form:"score_min"`
+	ScoreMax *int       `form:"score_max"`
+	From     *time.Time `form:"from"`
+	To       *time.Time `form:"to"`
+	Cursor   string     `form:"cursor"` // 前のページのnext_cursor
+	Limit    int        `form:"limit"`
+}
+
+type EssaySearchResponse struct {
+	Hits       []EssaySearchHitResponse `json:"hits"`
+	NextCursor string                   `json:"next_cursor,omitempty"` // 次のページがなければ空
+}
+
+type EssaySearchHitResponse struct {
+	SubmissionID string                  `json:"submission_id"`
+	ResultID     string                  `json:"result_id"`
+	TestID       string                  `json:"test_id"`
+	TestTitle    string                  `json:"test_title"`
+	UserID       string                  `json:"user_id,omitempty"`
+	TotalScore   int                     `json:"total_score"`
+	MaxScore     int                     `json:"max_score"`
+	SubmittedAt  time.Time               `json:"submitted_at"`
+	Snippets     []SearchSnippetResponse `json:"snippets"`
+}
+
+// SearchSnippetResponse is an excerpt of an answer or of the feedback; start and end locate it in the field
+// and the highlights locate the search terms in text, all as rune offsets
+type SearchSnippetResponse struct {
+	Field      string              `json:"field"` // answer, feedback, overall_assessment
+	QuestionID string              `json:"question_id,omitempty"`
+	Text       string              `json:"text"`
+	Start      int                 `json:"start"`
+	End        int                 `json:"end"`
+	Highlights []HighlightResponse `json:"highlights"`
+}
+
+type HighlightResponse struct {
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
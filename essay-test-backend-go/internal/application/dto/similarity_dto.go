@@ -0,0 +1,30 @@
+package dto
+
+import "time"
+
+type SimilarityMatchResponse struct {
+	ID                  string                `json:"id"`
+	TestID              string                `json:"test_id"`
+	QuestionID          string                `json:"question_id"`
+	SubmissionID        string                `json:"submission_id"`
+	UserID              string                `json:"user_id,omitempty"`
+	SourceType          string                `json:"source_type"`
+	MatchedSubmissionID string                `json:"matched_submission_id,omitempty"`
+	MatchedUserID       string                `json:"matched_user_id,omitempty"`
+	Similarity          float64               `json:"similarity"`
+	Spans               []OverlapSpanResponse `json:"spans"`
+	CreatedAt           time.Time             `json:"created_at"`
+}
+
+type OverlapSpanResponse struct {
+	Start        int    `json:"start"`
+	End          int    `json:"end"`
+	MatchedStart int    `json:"matched_start"`
+	MatchedEnd   int    `json:"matched_end"`
+	Text         string `json:"text,omitempty"`
+}
+
+type ReindexResponse struct {
+	TestID  string `json:"test_id"`
+	Indexed int    `json:"indexed"`
+}
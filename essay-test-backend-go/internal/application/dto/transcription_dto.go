@@ -0,0 +1,26 @@
+package dto
+
+import "time"
+
+// Request DTOs
+type TranscriptionConfirmRequest struct {
+	Content string `json:"content" binding:"required"`
+}
+
+// Response DTOs
+type TranscriptionResponse struct {
+	ID           string     `json:"id"`
+	TestID       string     `json:"test_id"`
+	QuestionID   string     `json:"question_id"`
+	ImageURL     string     `json:"image_url"`
+	ContentType  string     `json:"content_type"`
+	Size         int64      `json:"size"`
+	Engine       string     `json:"engine"`
+	OCRText      string     `json:"ocr_text"`
+	Confidence   float64    `json:"confidence"`
+	Content      string     `json:"content"`
+	Status       string     `json:"status"`
+	ConfirmedAt  *time.Time `json:"confirmed_at,omitempty"`
+	SubmissionID string     `json:"submission_id,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
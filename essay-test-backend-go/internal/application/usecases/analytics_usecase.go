@@ -0,0 +1,236 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"essay-test-backend/internal/application/dto"
+	"essay-test-backend/internal/domain/entities"
+	"essay-test-backend/internal/domain/repositories"
+	"essay-test-backend/pkg/logger"
+	"essay-test-backend/pkg/stats"
+
+	"go.uber.org/zap"
+)
+
+const (
+	defaultHistogramBins = 10
+	maxHistogramBins     = 100
+)
+
+type AnalyticsUsecase struct {
+	testRepo       repositories.EssayTestRepository
+	submissionRepo repositories.SubmissionRepository
+	resultRepo     repositories.ScoringResultRepository
+	analyticsRepo  repositories.AnalyticsRepository
+	logger         *zap.Logger
+}
+
+func NewAnalyticsUsecase(
+	testRepo repositories.EssayTestRepository,
+	submissionRepo repositories.SubmissionRepository,
+	resultRepo repositories.ScoringResultRepository,
+	analyticsRepo repositories.AnalyticsRepository,
+	logger *zap.Logger,
+) *AnalyticsUsecase {
+	return &AnalyticsUsecase{
+		testRepo:       testRepo,
+		submissionRepo: submissionRepo,
+		resultRepo:     resultRepo,
+		analyticsRepo:  analyticsRepo,
+		logger:         logger,
+	}
+}
+
+// OnSubmissionScored adds the new result to the running aggregates of its test
+func (u *AnalyticsUsecase) OnSubmissionScored(ctx context.Context, submission *entities.Submission, test *entities.EssayTest, result *entities.ScoringResult) {
+	participants, err := u.submissionRepo.CountParticipants(ctx, test.ID)
+	if err != nil {
+		logger.FromContext(ctx, u.logger).Error("受験者数の集計に失敗", zap.Error(err), zap.String("test_id", test.ID))
+		return
+	}
+
+	err = u.analyticsRepo.Update(ctx, test.ID, func(analytics *entities.TestAnalytics) {
+		analytics.Add(submission, test, result)
+		analytics.Participants = participants
+	})
+	if err != nil {
+		logger.FromContext(ctx, u.logger).Error("分析データの更新に失敗", zap.Error(err), zap.String("test_id", test.ID))
+	}
+}
+
+// OnScoresChanged marks the analytics of the result's test stale; the running aggregates cannot take back
+// the old scores, so they are rebuilt from the current results the next time they are read
+func (u *AnalyticsUsecase) OnScoresChanged(ctx context.Context, result *entities.ScoringResult) {
+	err := u.analyticsRepo.Update(ctx, result.TestID, func(analytics *entities.TestAnalytics) {
+		analytics.Stale = true
+	})
+	if err != nil {
+		logger.FromContext(ctx, u.logger).Error("分析データの更新に失敗", zap.Error(err), zap.String("test_id", result.TestID))
+	}
+}
+
+// Rebuild recomputes the aggregates from every current result, e.g. after reviews or a re-score changed scores
+func (u *AnalyticsUsecase) Rebuild(ctx context.Context, testID string, bins int) (*dto.TestAnalyticsResponse, error) {
+	test, err := u.getTest(ctx, testID)
+	if err != nil {
+		return nil, err
+	}
+
+	var analytics *entities.TestAnalytics
+	err = u.analyticsRepo.Replace(ctx, test.ID, func() (*entities.TestAnalytics, error) {
+		results, err := u.resultRepo.GetCurrentByTestID(ctx, test.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get results: %w", err)
+		}
+		submissions, err := u.submissionRepo.GetByTestID(ctx, test.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get submissions: %w", err)
+		}
+		participants, err := u.submissionRepo.CountParticipants(ctx, test.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count participants: %w", err)
+		}
+
+		bySubmission := make(map[string]*entities.Submission, len(submissions))
+		for i := range submissions {
+			bySubmission[submissions[i].ID] = &submissions[i]
+		}
+
+		now := time.Now()
+		analytics = &entities.TestAnalytics{TestID: test.ID, Participants: participants, RebuiltAt: &now}
+		for i := range results {
+			if submission, ok := bySubmission[results[i].SubmissionID]; ok {
+				analytics.Add(submission, test, &results[i])
+			}
+		}
+		return analytics, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to rebuild analytics: %w", err)
+	}
+
+	logger.FromContext(ctx, u.logger).Info("分析データを再集計",
+		zap.String("test_id", test.ID),
+		zap.Int("results", analytics.Submissions),
+		zap.Int("participants", analytics.Participants))
+	return convertAnalyticsToDTO(analytics, test, bins), nil
+}
+
+func (u *AnalyticsUsecase) GetAnalytics(ctx context.Context, testID string, bins int) (*dto.TestAnalyticsResponse, error) {
+	test, err := u.getTest(ctx, testID)
+	if err != nil {
+		return nil, err
+	}
+
+	analytics, err := u.analyticsRepo.Get(ctx, test.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get analytics: %w", err)
+	}
+	if analytics == nil {
+		analytics = &entities.TestAnalytics{TestID: test.ID}
+	}
+	if analytics.Stale {
+		return u.Rebuild(ctx, test.ID, bins)
+	}
+	return convertAnalyticsToDTO(analytics, test, bins), nil
+}
+
+func (u *AnalyticsUsecase) getTest(ctx context.Context, testID string) (*entities.EssayTest, error) {
+	test, err := u.testRepo.GetByID(ctx, testID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get test: %w", err)
+	}
+	if test == nil {
+		return nil, fmt.Errorf("test not found")
+	}
+	return test, nil
+}
+
+// ValidateHistogramBins checks the requested number of histogram bins, defaulting when omitted
+func ValidateHistogramBins(bins int) (int, error) {
+	if bins == 0 {
+		return defaultHistogramBins, nil
+	}
+	if bins < 1 || bins > maxHistogramBins {
+		return 0, fmt.Errorf("invalid bins")
+	}
+	return bins, nil
+}
+
+func convertAnalyticsToDTO(a *entities.TestAnalytics, test *entities.EssayTest, bins int) *dto.TestAnalyticsResponse {
+	response := &dto.TestAnalyticsResponse{
+		TestID:       a.TestID,
+		TestTitle:    test.Title,
+		Participants: a.Participants,
+		Submissions:  a.Submissions,
+		Total:        convertDistributionToDTO(a.Total, bins),
+		Questions:    make([]dto.QuestionAnalyticsResponse, 0, len(a.Questions)),
+		RebuiltAt:    a.RebuiltAt,
+	}
+	if !a.UpdatedAt.IsZero() {
+		updatedAt := a.UpdatedAt
+		response.UpdatedAt = &updatedAt
+	}
+
+	for _, q := range a.Questions {
+		question := dto.QuestionAnalyticsResponse{
+			QuestionNum:            q.QuestionNum,
+			Score:                  convertDistributionToDTO(q.Score, bins),
+			LengthScoreCorrelation: stats.Correlation(q.Length.N, q.Length.SumX, q.Length.SumY, q.Length.SumXX, q.Length.SumYY, q.Length.SumXY),
+			Criteria:               make([]dto.CriterionAnalyticsResponse, 0, len(q.Criteria)),
+		}
+		if q.Length.N > 0 {
+			question.AverageLength = q.Length.SumX / q.Length.N
+		}
+		for _, c := range q.Criteria {
+			question.Criteria = append(question.Criteria, dto.CriterionAnalyticsResponse{
+				CriteriaName: c.CriteriaName,
+				Score:        convertDistributionToDTO(c.Score, bins),
+			})
+		}
+		response.Questions = append(response.Questions, question)
+	}
+	sort.Slice(response.Questions, func(i, j int) bool {
+		return response.Questions[i].QuestionNum < response.Questions[j].QuestionNum
+	})
+	return response
+}
+
+// convertDistributionToDTO summarizes a score distribution into statistics and a histogram of at most bins bins
+func convertDistributionToDTO(d entities.ScoreDistribution, bins int) dto.ScoreStatisticsResponse {
+	response := dto.ScoreStatisticsResponse{
+		MaxScore:  d.MaxScore,
+		Mean:      stats.CountsMean(d.Counts),
+		Median:    stats.CountsMedian(d.Counts),
+		StdDev:    stats.CountsStdDev(d.Counts),
+		Histogram: []dto.HistogramBinResponse{},
+	}
+	for _, c := range d.Counts {
+		response.N += c
+	}
+	if d.MaxScore > 0 {
+		response.Difficulty = response.Mean / float64(d.MaxScore)
+	}
+
+	// 満点が小さい基準では1点刻みにし、それ以外は最後の区間に満点を含める
+	count, width := d.MaxScore+1, 1
+	if count > bins {
+		width = (d.MaxScore + bins - 1) / bins
+		count = (d.MaxScore + width - 1) / width
+	}
+	for i := 0; i < count; i++ {
+		bin := dto.HistogramBinResponse{From: i * width, To: (i+1)*width - 1}
+		if i == count-1 {
+			bin.To = d.MaxScore
+		}
+		response.Histogram = append(response.Histogram, bin)
+	}
+	for score, c := range d.Counts {
+		i := min(max(score, 0)/width, count-1)
+		response.Histogram[i].Count += c
+	}
+	return response
+}
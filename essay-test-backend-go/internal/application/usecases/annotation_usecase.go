@@ -0,0 +1,324 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"essay-test-backend/internal/application/dto"
+	"essay-test-backend/internal/domain/entities"
+	"essay-test-backend/internal/domain/repositories"
+	"essay-test-backend/pkg/logger"
+	"essay-test-backend/pkg/textanchor"
+	"essay-test-backend/pkg/textfeatures"
+
+	"go.uber.org/zap"
+)
+
+// 一文がこれより長いと指摘する
+const annotationLongSentenceRunes = 80
+
+// 結論の有無を確認する最低文字数（要約問題などの短い回答は対象外）
+const annotationConclusionMinRunes = 400
+
+var (
+	annotationCategories = map[string]bool{
+		entities.AnnotationCategoryStructure:  true,
+		entities.AnnotationCategoryArgument:   true,
+		entities.AnnotationCategoryEvidence:   true,
+		entities.AnnotationCategoryExpression: true,
+	}
+	annotationSeverities = map[string]bool{
+		entities.AnnotationSeverityInfo:    true,
+		entities.AnnotationSeverityWarning: true,
+		entities.AnnotationSeverityError:   true,
+	}
+)
+
+type AnnotationUsecase struct {
+	submissionRepo repositories.SubmissionRepository
+	annotationRepo repositories.AnnotationRepository
+	logger         *zap.Logger
+}
+
+func NewAnnotationUsecase(
+	submissionRepo repositories.SubmissionRepository,
+	annotationRepo repositories.AnnotationRepository,
+	logger *zap.Logger,
+) *AnnotationUsecase {
+	return &AnnotationUsecase{
+		submissionRepo: submissionRepo,
+		annotationRepo: annotationRepo,
+		logger:         logger,
+	}
+}
+
+// OnSubmissionScored runs the automated checkers over a newly scored submission
+func (u *AnnotationUsecase) OnSubmissionScored(ctx context.Context, submission *entities.Submission, test *entities.EssayTest, result *entities.ScoringResult) {
+	var annotations []entities.Annotation
+	for _, answer := range submission.Answers {
+		annotations = append(annotations, detectAnnotations(submission.ID, answer)...)
+	}
+
+	if err := u.annotationRepo.ReplaceAuto(ctx, submission.ID, annotations); err != nil {
+		logger.FromContext(ctx, u.logger).Error("自動添削の保存に失敗", zap.Error(err), zap.String("submission_id", submission.ID))
+		return
+	}
+
+	logger.FromContext(ctx, u.logger).Info("自動添削を保存",
+		zap.String("submission_id", submission.ID),
+		zap.Int("annotations", len(annotations)))
+}
+
+// GetAnnotations returns the answers of a submission with every annotation resolved against their text
+func (u *AnnotationUsecase) GetAnnotations(ctx context.Context, submissionID string) ([]dto.AnswerResponse, error) {
+	submission, err := u.getSubmission(ctx, submissionID)
+	if err != nil {
+		return nil, err
+	}
+
+	annotations, err := u.annotationRepo.GetBySubmissionID(ctx, submissionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get annotations: %w", err)
+	}
+	return convertAnnotatedAnswersToDTO(submission.Answers, annotations), nil
+}
+
+func (u *AnnotationUsecase) CreateAnnotation(ctx context.Context, submissionID, authorID string, req dto.AnnotationRequest) (*dto.AnnotationResponse, error) {
+	submission, err := u.getSubmission(ctx, submissionID)
+	if err != nil {
+		return nil, err
+	}
+
+	answer := findAnswer(submission, req.AnswerID)
+	if answer == nil {
+		return nil, fmt.Errorf("answer not found")
+	}
+
+	annotation := &entities.Annotation{
+		SubmissionID: submission.ID,
+		AnswerID:     answer.ID,
+		Source:       entities.AnnotationSourceHuman,
+		AuthorID:     authorID,
+	}
+	if err := applyAnnotationRequest(annotation, answer, req); err != nil {
+		return nil, err
+	}
+
+	if err := u.annotationRepo.Create(ctx, annotation); err != nil {
+		return nil, fmt.Errorf("failed to create annotation: %w", err)
+	}
+
+	logger.FromContext(ctx, u.logger).Info("添削を追加",
+		zap.String("submission_id", submission.ID),
+		zap.String("annotation_id", annotation.ID),
+		zap.String("author_id", authorID))
+
+	response := convertAnnotationToDTO(*annotation, answer.Content)
+	return &response, nil
+}
+
+// UpdateAnnotation edits an annotation; an edited automated annotation becomes the grader's own
+func (u *AnnotationUsecase) UpdateAnnotation(ctx context.Context, annotationID, authorID string, req dto.AnnotationRequest) (*dto.AnnotationResponse, error) {
+	annotation, err := u.getAnnotation(ctx, annotationID)
+	if err != nil {
+		return nil, err
+	}
+
+	submission, err := u.getSubmission(ctx, annotation.SubmissionID)
+	if err != nil {
+		return nil, err
+	}
+	answer := findAnswer(submission, annotation.AnswerID)
+	if answer == nil {
+		return nil, fmt.Errorf("answer not found")
+	}
+
+	if err := applyAnnotationRequest(annotation, answer, req); err != nil {
+		return nil, err
+	}
+	annotation.Source = entities.AnnotationSourceHuman
+	annotation.AuthorID = authorID
+
+	if err := u.annotationRepo.Update(ctx, annotation); err != nil {
+		return nil, fmt.Errorf("failed to update annotation: %w", err)
+	}
+
+	response := convertAnnotationToDTO(*annotation, answer.Content)
+	return &response, nil
+}
+
+func (u *AnnotationUsecase) DeleteAnnotation(ctx context.Context, annotationID string) error {
+	if _, err := u.getAnnotation(ctx, annotationID); err != nil {
+		return err
+	}
+	if err := u.annotationRepo.Delete(ctx, annotationID); err != nil {
+		return fmt.Errorf("failed to delete annotation: %w", err)
+	}
+	return nil
+}
+
+func (u *AnnotationUsecase) getSubmission(ctx context.Context, submissionID string) (*entities.Submission, error) {
+	submission, err := u.submissionRepo.GetByID(ctx, submissionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get submission: %w", err)
+	}
+	if submission == nil {
+		return nil, fmt.Errorf("submission not found")
+	}
+	return submission, nil
+}
+
+func (u *AnnotationUsecase) getAnnotation(ctx context.Context, annotationID string) (*entities.Annotation, error) {
+	annotation, err := u.annotationRepo.GetByID(ctx, annotationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get annotation: %w", err)
+	}
+	if annotation == nil {
+		return nil, fmt.Errorf("annotation not found")
+	}
+	return annotation, nil
+}
+
+func applyAnnotationRequest(annotation *entities.Annotation, answer *entities.Answer, req dto.AnnotationRequest) error {
+	if !annotationCategories[req.Category] || !annotationSeverities[req.Severity] {
+		return fmt.Errorf("invalid annotation")
+	}
+
+	runes := []rune(answer.Content)
+	if req.Start < 0 || req.End <= req.Start || req.End > len(runes) {
+		return fmt.Errorf("invalid range")
+	}
+
+	setAnnotationRange(annotation, answer.Content, req.Start, req.End)
+	annotation.Category = req.Category
+	annotation.Severity = req.Severity
+	annotation.Comment = req.Comment
+	annotation.SuggestedRewrite = req.SuggestedRewrite
+	return nil
+}
+
+func setAnnotationRange(annotation *entities.Annotation, content string, start, end int) {
+	annotation.Start = start
+	annotation.End = end
+	annotation.NormalizedStart, annotation.NormalizedEnd = textanchor.Normalize(content, start, end)
+	annotation.Quote = string([]rune(content)[start:end])
+}
+
+func findAnswer(submission *entities.Submission, answerID string) *entities.Answer {
+	for i := range submission.Answers {
+		if submission.Answers[i].ID == answerID {
+			return &submission.Answers[i]
+		}
+	}
+	return nil
+}
+
+// detectAnnotations runs the automated checkers over one answer
+func detectAnnotations(submissionID string, answer entities.Answer) []entities.Annotation {
+	features := textfeatures.Analyze(answer.Content)
+
+	var annotations []entities.Annotation
+	add := func(e textfeatures.Excerpt, category, severity, rule, comment string) {
+		annotation := entities.Annotation{
+			SubmissionID: submissionID,
+			AnswerID:     answer.ID,
+			Category:     category,
+			Severity:     severity,
+			Comment:      comment,
+			Source:       entities.AnnotationSourceAuto,
+			Rule:         rule,
+		}
+		setAnnotationRange(&annotation, answer.Content, e.Start, e.End)
+		annotations = append(annotations, annotation)
+	}
+
+	if features.Opinion != nil {
+		add(*features.Opinion, entities.AnnotationCategoryArgument, entities.AnnotationSeverityInfo,
+			"thesis", "自分の立場が示されています。")
+	}
+	if features.Example != nil {
+		add(*features.Example, entities.AnnotationCategoryEvidence, entities.AnnotationSeverityInfo,
+			"example", "具体例で主張を補強しています。")
+	}
+
+	for _, s := range features.Sentences {
+		if n := len([]rune(s.Text)); n >= annotationLongSentenceRunes {
+			add(s, entities.AnnotationCategoryExpression, entities.AnnotationSeverityWarning,
+				"long_sentence", fmt.Sprintf("一文が%d字と長く、読みにくくなっています。二つ以上の文に分けましょう。", n))
+		}
+	}
+
+	// です・ます調とだ・である調の混在は少ない方を指摘する
+	var polite, plain []textfeatures.Excerpt
+	for _, s := range features.Sentences {
+		switch textfeatures.SentenceStyle(s.Text) {
+		case textfeatures.StylePolite:
+			polite = append(polite, s)
+		case textfeatures.StylePlain:
+			plain = append(plain, s)
+		}
+	}
+	if len(polite) > 0 && len(plain) > 0 {
+		minority, style := polite, "だ・である調"
+		if len(plain) < len(polite) {
+			minority, style = plain, "です・ます調"
+		}
+		for _, s := range minority {
+			add(s, entities.AnnotationCategoryExpression, entities.AnnotationSeverityWarning,
+				"mixed_style", fmt.Sprintf("文体が混在しています。%sに統一しましょう。", style))
+		}
+	}
+
+	if features.Length >= annotationConclusionMinRunes && features.WeakConclusion() && len(features.Sentences) > 0 {
+		add(features.Sentences[len(features.Sentences)-1], entities.AnnotationCategoryStructure, entities.AnnotationSeverityWarning,
+			"weak_conclusion", "結論が明確に示されていません。最終段落で主張をまとめましょう。")
+	}
+
+	sort.SliceStable(annotations, func(i, j int) bool {
+		return annotations[i].Start < annotations[j].Start
+	})
+	return annotations
+}
+
+// convertAnnotatedAnswersToDTO attaches each answer's annotations, re-anchored to the answer's current text
+func convertAnnotatedAnswersToDTO(answers []entities.Answer, annotations []entities.Annotation) []dto.AnswerResponse {
+	byAnswer := make(map[string][]entities.Annotation)
+	for _, a := range annotations {
+		byAnswer[a.AnswerID] = append(byAnswer[a.AnswerID], a)
+	}
+
+	response := convertAnswersToDTO(answers)
+	for i := range response {
+		response[i].Annotations = make([]dto.AnnotationResponse, 0, len(byAnswer[answers[i].ID]))
+		for _, a := range byAnswer[answers[i].ID] {
+			response[i].Annotations = append(response[i].Annotations, convertAnnotationToDTO(a, answers[i].Content))
+		}
+	}
+	return response
+}
+
+func convertAnnotationToDTO(a entities.Annotation, content string) dto.AnnotationResponse {
+	start, end := a.Start, a.End
+	if s, e, ok := textanchor.Resolve(content, a.NormalizedStart, a.NormalizedEnd); ok {
+		start, end = s, e
+	}
+
+	return dto.AnnotationResponse{
+		ID:               a.ID,
+		AnswerID:         a.AnswerID,
+		Start:            start,
+		End:              end,
+		NormalizedStart:  a.NormalizedStart,
+		NormalizedEnd:    a.NormalizedEnd,
+		Quote:            a.Quote,
+		Category:         a.Category,
+		Severity:         a.Severity,
+		Comment:          a.Comment,
+		SuggestedRewrite: a.SuggestedRewrite,
+		Source:           a.Source,
+		Rule:             a.Rule,
+		AuthorID:         a.AuthorID,
+		CreatedAt:        a.CreatedAt,
+	}
+}
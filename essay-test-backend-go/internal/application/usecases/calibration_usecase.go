@@ -0,0 +1,413 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"unicode/utf8"
+
+	"essay-test-backend/internal/application/dto"
+	"essay-test-backend/internal/domain/entities"
+	"essay-test-backend/internal/domain/repositories"
+	"essay-test-backend/internal/domain/services"
+	"essay-test-backend/pkg/logger"
+	"essay-test-backend/pkg/stats"
+
+	"go.uber.org/zap"
+)
+
+// 直線の当てはめに必要な最低限のアンカー数
+const minCalibrationSamples = 2
+
+type CalibrationUsecase struct {
+	testRepo        repositories.EssayTestRepository
+	calibrationRepo repositories.CalibrationRepository
+	scoringService  services.ScoringService
+	logger          *zap.Logger
+}
+
+// NewCalibrationUsecase takes the uncalibrated scorer so that runs measure its raw error
+func NewCalibrationUsecase(
+	testRepo repositories.EssayTestRepository,
+	calibrationRepo repositories.CalibrationRepository,
+	scoringService services.ScoringService,
+	logger *zap.Logger,
+) *CalibrationUsecase {
+	return &CalibrationUsecase{
+		testRepo:        testRepo,
+		calibrationRepo: calibrationRepo,
+		scoringService:  scoringService,
+		logger:          logger,
+	}
+}
+
+func (u *CalibrationUsecase) CreateAnchor(ctx context.Context, testID, createdBy string, req dto.AnchorEssayRequest) (*dto.AnchorEssayResponse, error) {
+	test, err := u.getTest(ctx, testID)
+	if err != nil {
+		return nil, err
+	}
+
+	questions := make(map[string]bool)
+	for _, q := range test.Questions {
+		questions[q.ID] = true
+	}
+	if len(req.Answers) != len(test.Questions) {
+		return nil, fmt.Errorf("invalid anchor")
+	}
+	anchor := &entities.AnchorEssay{
+		TestID:    test.ID,
+		Title:     req.Title,
+		CreatedBy: createdBy,
+	}
+	answered := make(map[string]bool)
+	for _, a := range req.Answers {
+		if !questions[a.QuestionID] || answered[a.QuestionID] {
+			return nil, fmt.Errorf("invalid anchor")
+		}
+		answered[a.QuestionID] = true
+		anchor.Answers = append(anchor.Answers, entities.AnchorAnswer{
+			QuestionID: a.QuestionID,
+			Content:    a.Content,
+		})
+	}
+
+	// テストには採点基準の一覧がないため、採点で使われる基準と配点をアンカー答案の採点から得る
+	rubric, err := u.rubric(ctx, anchor, test)
+	if err != nil {
+		return nil, err
+	}
+	scored := make(map[string]bool)
+	for _, s := range req.Scores {
+		key := fmt.Sprintf("%d|%s", s.QuestionNum, s.CriteriaName)
+		maxScore, ok := rubric[key]
+		if !ok || scored[key] {
+			return nil, fmt.Errorf("invalid criteria score")
+		}
+		if s.Score < 0 || s.Score > maxScore {
+			return nil, fmt.Errorf("score out of range")
+		}
+		scored[key] = true
+		anchor.Scores = append(anchor.Scores, entities.AnchorCriteriaScore{
+			QuestionNum:  s.QuestionNum,
+			CriteriaName: s.CriteriaName,
+			Score:        s.Score,
+		})
+	}
+
+	if err := u.calibrationRepo.CreateAnchor(ctx, anchor); err != nil {
+		return nil, fmt.Errorf("failed to create anchor: %w", err)
+	}
+
+	logger.FromContext(ctx, u.logger).Info("アンカー答案を登録", zap.String("test_id", testID), zap.String("anchor_id", anchor.ID))
+	return convertAnchorToDTO(*anchor), nil
+}
+
+func (u *CalibrationUsecase) ListAnchors(ctx context.Context, testID string) ([]dto.AnchorEssayResponse, error) {
+	if _, err := u.getTest(ctx, testID); err != nil {
+		return nil, err
+	}
+
+	anchors, err := u.calibrationRepo.GetAnchorsByTestID(ctx, testID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get anchors: %w", err)
+	}
+
+	response := make([]dto.AnchorEssayResponse, 0, len(anchors))
+	for _, a := range anchors {
+		response = append(response, *convertAnchorToDTO(a))
+	}
+	return response, nil
+}
+
+func (u *CalibrationUsecase) DeleteAnchor(ctx context.Context, testID, anchorID string) error {
+	deleted, err := u.calibrationRepo.DeleteAnchor(ctx, testID, anchorID)
+	if err != nil {
+		return fmt.Errorf("failed to delete anchor: %w", err)
+	}
+	if !deleted {
+		return fmt.Errorf("anchor not found")
+	}
+	return nil
+}
+
+// Run scores every anchor essay with the active scorer and reports per-criteria bias and error
+func (u *CalibrationUsecase) Run(ctx context.Context, testID, createdBy string) (*dto.CalibrationRunResponse, error) {
+	logger.FromContext(ctx, u.logger).Info("採点較正を実行中", zap.String("test_id", testID))
+
+	test, err := u.getTest(ctx, testID)
+	if err != nil {
+		return nil, err
+	}
+
+	anchors, err := u.calibrationRepo.GetAnchorsByTestID(ctx, testID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get anchors: %w", err)
+	}
+	if len(anchors) == 0 {
+		return nil, fmt.Errorf("no anchors")
+	}
+
+	type samples struct {
+		questionNum  int
+		criteriaName string
+		maxScore     int
+		auto         []float64
+		expected     []float64
+	}
+	byCriterion := make(map[string]*samples)
+	var keys []string
+	scoredBy := ""
+
+	for _, anchor := range anchors {
+		result, err := u.scoringService.ScoreSubmission(ctx, anchorSubmission(anchor, test), test)
+		if err != nil {
+			return nil, fmt.Errorf("failed to score anchor %s: %w", anchor.ID, err)
+		}
+		scoredBy = result.ScoredBy
+
+		expected := make(map[string]int)
+		for _, s := range anchor.Scores {
+			expected[fmt.Sprintf("%d|%s", s.QuestionNum, s.CriteriaName)] = s.Score
+		}
+
+		for _, detail := range result.Details {
+			for _, cs := range detail.CriteriaScores {
+				key := fmt.Sprintf("%d|%s", detail.QuestionNum, cs.CriteriaName)
+				want, ok := expected[key]
+				if !ok {
+					continue
+				}
+				s, ok := byCriterion[key]
+				if !ok {
+					s = &samples{questionNum: detail.QuestionNum, criteriaName: cs.CriteriaName, maxScore: cs.MaxScore}
+					byCriterion[key] = s
+					keys = append(keys, key)
+				}
+				s.auto = append(s.auto, float64(cs.Score))
+				s.expected = append(s.expected, float64(want))
+			}
+		}
+	}
+
+	run := &entities.CalibrationRun{
+		TestID:      testID,
+		ScoredBy:    scoredBy,
+		AnchorCount: len(anchors),
+		CreatedBy:   createdBy,
+	}
+	for _, key := range keys {
+		s := byCriterion[key]
+		slope, intercept := stats.LinearFit(s.auto, s.expected)
+		run.Criteria = append(run.Criteria, entities.CriterionCalibration{
+			QuestionNum:       s.questionNum,
+			CriteriaName:      s.criteriaName,
+			MaxScore:          s.maxScore,
+			N:                 len(s.auto),
+			Bias:              stats.Mean(s.auto) - stats.Mean(s.expected),
+			MeanAbsoluteError: stats.MeanAbsoluteError(s.auto, s.expected),
+			RMSE:              stats.RootMeanSquaredError(s.auto, s.expected),
+			Slope:             slope,
+			Intercept:         intercept,
+		})
+	}
+
+	if err := u.calibrationRepo.CreateRun(ctx, run); err != nil {
+		return nil, fmt.Errorf("failed to save calibration run: %w", err)
+	}
+
+	logger.FromContext(ctx, u.logger).Info("採点較正の実行完了",
+		zap.String("test_id", testID),
+		zap.String("run_id", run.ID),
+		zap.Int("anchors", len(anchors)),
+		zap.Int("criteria", len(run.Criteria)))
+
+	return convertCalibrationRunToDTO(*run), nil
+}
+
+func (u *CalibrationUsecase) ListRuns(ctx context.Context, testID string) ([]dto.CalibrationRunResponse, error) {
+	runs, err := u.calibrationRepo.GetRunsByTestID(ctx, testID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get calibration runs: %w", err)
+	}
+
+	response := make([]dto.CalibrationRunResponse, 0, len(runs))
+	for _, r := range runs {
+		response = append(response, *convertCalibrationRunToDTO(r))
+	}
+	return response, nil
+}
+
+// Apply enables the lines fitted by a run for future automated scores of the test
+func (u *CalibrationUsecase) Apply(ctx context.Context, testID, appliedBy string, req dto.ApplyCalibrationRequest) (*dto.TestCalibrationResponse, error) {
+	run, err := u.calibrationRepo.GetRunByID(ctx, req.RunID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get calibration run: %w", err)
+	}
+	if run == nil || run.TestID != testID {
+		return nil, fmt.Errorf("run not found")
+	}
+
+	calibration := &entities.TestCalibration{
+		TestID:    testID,
+		RunID:     run.ID,
+		AppliedBy: appliedBy,
+	}
+	for _, cc := range run.Criteria {
+		// アンカーが少なすぎる基準は補正しない
+		if cc.N < minCalibrationSamples {
+			continue
+		}
+		calibration.Criteria = append(calibration.Criteria, cc)
+	}
+	if len(calibration.Criteria) == 0 {
+		return nil, fmt.Errorf("not enough anchors")
+	}
+
+	if err := u.calibrationRepo.SaveCalibration(ctx, calibration); err != nil {
+		return nil, fmt.Errorf("failed to save calibration: %w", err)
+	}
+
+	logger.FromContext(ctx, u.logger).Info("採点較正を有効化",
+		zap.String("test_id", testID),
+		zap.String("run_id", run.ID),
+		zap.Int("criteria", len(calibration.Criteria)))
+
+	return convertTestCalibrationToDTO(calibration), nil
+}
+
+func (u *CalibrationUsecase) GetCalibration(ctx context.Context, testID string) (*dto.TestCalibrationResponse, error) {
+	calibration, err := u.calibrationRepo.GetCalibration(ctx, testID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get calibration: %w", err)
+	}
+	if calibration == nil {
+		return nil, fmt.Errorf("calibration not found")
+	}
+	return convertTestCalibrationToDTO(calibration), nil
+}
+
+func (u *CalibrationUsecase) RemoveCalibration(ctx context.Context, testID string) error {
+	if err := u.calibrationRepo.DeleteCalibration(ctx, testID); err != nil {
+		return fmt.Errorf("failed to delete calibration: %w", err)
+	}
+	logger.FromContext(ctx, u.logger).Info("採点較正を解除", zap.String("test_id", testID))
+	return nil
+}
+
+func (u *CalibrationUsecase) getTest(ctx context.Context, testID string) (*entities.EssayTest, error) {
+	test, err := u.testRepo.GetByID(ctx, testID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get test: %w", err)
+	}
+	if test == nil {
+		return nil, fmt.Errorf("test not found")
+	}
+	return test, nil
+}
+
+// anchorSubmission builds an unsaved submission whose answers follow the question order
+// rubric returns the max score of every criteria the scorer grades the test on, keyed by question number and criteria name
+func (u *CalibrationUsecase) rubric(ctx context.Context, anchor *entities.AnchorEssay, test *entities.EssayTest) (map[string]int, error) {
+	result, err := u.scoringService.ScoreSubmission(ctx, anchorSubmission(*anchor, test), test)
+	if err != nil {
+		return nil, fmt.Errorf("failed to score anchor: %w", err)
+	}
+	rubric := make(map[string]int)
+	for _, detail := range result.Details {
+		for _, cs := range detail.CriteriaScores {
+			rubric[fmt.Sprintf("%d|%s", detail.QuestionNum, cs.CriteriaName)] = cs.MaxScore
+		}
+	}
+	return rubric, nil
+}
+
+func anchorSubmission(anchor entities.AnchorEssay, test *entities.EssayTest) *entities.Submission {
+	numbers := make(map[string]int)
+	for _, q := range test.Questions {
+		numbers[q.ID] = q.Number
+	}
+	answers := append([]entities.AnchorAnswer(nil), anchor.Answers...)
+	sort.SliceStable(answers, func(i, j int) bool {
+		return numbers[answers[i].QuestionID] < numbers[answers[j].QuestionID]
+	})
+
+	submission := &entities.Submission{
+		ID:     "anchor-" + anchor.ID,
+		TestID: test.ID,
+		Status: "pending",
+	}
+	for _, a := range answers {
+		submission.Answers = append(submission.Answers, entities.Answer{
+			ID:           fmt.Sprintf("anchor-%s-%s", anchor.ID, a.QuestionID),
+			SubmissionID: submission.ID,
+			QuestionID:   a.QuestionID,
+			Content:      a.Content,
+			WordCount:    utf8.RuneCountInString(a.Content),
+		})
+	}
+	return submission
+}
+
+func convertAnchorToDTO(a entities.AnchorEssay) *dto.AnchorEssayResponse {
+	response := &dto.AnchorEssayResponse{
+		ID:        a.ID,
+		TestID:    a.TestID,
+		Title:     a.Title,
+		CreatedBy: a.CreatedBy,
+		CreatedAt: a.CreatedAt,
+	}
+	for _, ans := range a.Answers {
+		response.Answers = append(response.Answers, dto.AnswerRequest{
+			QuestionID: ans.QuestionID,
+			Content:    ans.Content,
+		})
+	}
+	for _, s := range a.Scores {
+		response.Scores = append(response.Scores, dto.AnchorCriteriaScoreRequest{
+			QuestionNum:  s.QuestionNum,
+			CriteriaName: s.CriteriaName,
+			Score:        s.Score,
+		})
+	}
+	return response
+}
+
+func convertCriterionCalibrationsToDTO(criteria []entities.CriterionCalibration) []dto.CriterionCalibrationResponse {
+	result := make([]dto.CriterionCalibrationResponse, 0, len(criteria))
+	for _, cc := range criteria {
+		result = append(result, dto.CriterionCalibrationResponse{
+			QuestionNum:       cc.QuestionNum,
+			CriteriaName:      cc.CriteriaName,
+			MaxScore:          cc.MaxScore,
+			N:                 cc.N,
+			Bias:              cc.Bias,
+			MeanAbsoluteError: cc.MeanAbsoluteError,
+			RMSE:              cc.RMSE,
+			Slope:             cc.Slope,
+			Intercept:         cc.Intercept,
+		})
+	}
+	return result
+}
+
+func convertCalibrationRunToDTO(r entities.CalibrationRun) *dto.CalibrationRunResponse {
+	return &dto.CalibrationRunResponse{
+		ID:          r.ID,
+		TestID:      r.TestID,
+		ScoredBy:    r.ScoredBy,
+		AnchorCount: r.AnchorCount,
+		Criteria:    convertCriterionCalibrationsToDTO(r.Criteria),
+		CreatedBy:   r.CreatedBy,
+		CreatedAt:   r.CreatedAt,
+	}
+}
+
+func convertTestCalibrationToDTO(c *entities.TestCalibration) *dto.TestCalibrationResponse {
+	return &dto.TestCalibrationResponse{
+		TestID:    c.TestID,
+		RunID:     c.RunID,
+		Criteria:  convertCriterionCalibrationsToDTO(c.Criteria),
+		AppliedBy: c.AppliedBy,
+		UpdatedAt: c.UpdatedAt,
+	}
+}
@@ -0,0 +1,687 @@
+package usecases
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"essay-test-backend/internal/application/dto"
+	"essay-test-backend/internal/domain/entities"
+	"essay-test-backend/internal/domain/repositories"
+	"essay-test-backend/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// 読み間違えやすい文字（0/O、1/I）を除いた招待コードの文字
+	inviteCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+	inviteCodeLength   = 8
+	inviteCodeAttempts = 5
+)
+
+type ClassUsecase struct {
+	testRepo       repositories.EssayTestRepository
+	submissionRepo repositories.SubmissionRepository
+	resultRepo     repositories.ScoringResultRepository
+	classRepo      repositories.ClassRepository
+	logger         *zap.Logger
+}
+
+func NewClassUsecase(
+	testRepo repositories.EssayTestRepository,
+	submissionRepo repositories.SubmissionRepository,
+	resultRepo repositories.ScoringResultRepository,
+	classRepo repositories.ClassRepository,
+	logger *zap.Logger,
+) *ClassUsecase {
+	return &ClassUsecase{
+		testRepo:       testRepo,
+		submissionRepo: submissionRepo,
+		resultRepo:     resultRepo,
+		classRepo:      classRepo,
+		logger:         logger,
+	}
+}
+
+func (u *ClassUsecase) CreateOrganization(ctx context.Context, createdBy string, req dto.OrganizationRequest) (*dto.OrganizationResponse, error) {
+	organization := &entities.Organization{
+		Name:      req.Name,
+		Type:      req.Type,
+		CreatedBy: createdBy,
+	}
+	if err := u.classRepo.CreateOrganization(ctx, organization); err != nil {
+		return nil, fmt.Errorf("failed to create organization: %w", err)
+	}
+
+	logger.FromContext(ctx, u.logger).Info("組織を作成", zap.String("organization_id", organization.ID), zap.String("name", organization.Name))
+	return convertOrganizationToDTO(*organization), nil
+}
+
+func (u *ClassUsecase) ListOrganizations(ctx context.Context) ([]dto.OrganizationResponse, error) {
+	organizations, err := u.classRepo.ListOrganizations(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list organizations: %w", err)
+	}
+
+	response := make([]dto.OrganizationResponse, 0, len(organizations))
+	for _, o := range organizations {
+		response = append(response, *convertOrganizationToDTO(o))
+	}
+	return response, nil
+}
+
+// CreateClass creates a class and makes its creator the first teacher
+func (u *ClassUsecase) CreateClass(ctx context.Context, teacherID string, req dto.ClassRequest) (*dto.ClassResponse, error) {
+	organization, err := u.classRepo.GetOrganization(ctx, req.OrganizationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get organization: %w", err)
+	}
+	if organization == nil {
+		return nil, fmt.Errorf("organization not found")
+	}
+
+	code, err := u.newInviteCode(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	class := &entities.Class{
+		OrganizationID: organization.ID,
+		Name:           req.Name,
+		InviteCode:     code,
+		CreatedBy:      teacherID,
+		Members: []entities.ClassMember{
+			{UserID: teacherID, Role: entities.MemberRoleTeacher},
+		},
+	}
+	if err := u.classRepo.CreateClass(ctx, class); err != nil {
+		return nil, fmt.Errorf("failed to create class: %w", err)
+	}
+
+	logger.FromContext(ctx, u.logger).Info("クラスを作成", zap.String("class_id", class.ID), zap.String("teacher_id", teacherID))
+	return convertClassToDTO(*class, class.Members, true), nil
+}
+
+// ListTeacherClasses returns the classes the teacher teaches
+func (u *ClassUsecase) ListTeacherClasses(ctx context.Context, teacherID string) ([]dto.ClassResponse, error) {
+	classes, err := u.classRepo.ListClassesByMember(ctx, teacherID, entities.MemberRoleTeacher)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list classes: %w", err)
+	}
+
+	response := make([]dto.ClassResponse, 0, len(classes))
+	for _, c := range classes {
+		response = append(response, *convertClassToDTO(c, nil, true))
+	}
+	return response, nil
+}
+
+func (u *ClassUsecase) GetClass(ctx context.Context, classID, actorID string, isAdmin bool) (*dto.ClassResponse, error) {
+	class, err := u.authorizeTeacher(ctx, classID, actorID, isAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := u.classRepo.ListMembers(ctx, class.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list members: %w", err)
+	}
+	return convertClassToDTO(*class, members, true), nil
+}
+
+// RegenerateInviteCode invalidates the current invite code, e.g. after it leaked
+func (u *ClassUsecase) RegenerateInviteCode(ctx context.Context, classID, actorID string, isAdmin bool) (*dto.ClassResponse, error) {
+	class, err := u.authorizeTeacher(ctx, classID, actorID, isAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	code, err := u.newInviteCode(ctx)
+	if err != nil {
+		return nil, err
+	}
+	class.InviteCode = code
+	if err := u.classRepo.UpdateClass(ctx, class); err != nil {
+		return nil, fmt.Errorf("failed to update class: %w", err)
+	}
+
+	logger.FromContext(ctx, u.logger).Info("招待コードを再発行", zap.String("class_id", class.ID))
+	return convertClassToDTO(*class, nil, true), nil
+}
+
+func (u *ClassUsecase) AddTeacher(ctx context.Context, classID, actorID string, isAdmin bool, req dto.ClassTeacherRequest) (*dto.ClassMemberResponse, error) {
+	class, err := u.authorizeTeacher(ctx, classID, actorID, isAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := u.classRepo.GetMember(ctx, class.ID, req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get member: %w", err)
+	}
+	if existing != nil {
+		return nil, fmt.Errorf("already a member")
+	}
+
+	member := &entities.ClassMember{ClassID: class.ID, UserID: req.UserID, Role: entities.MemberRoleTeacher}
+	if err := u.classRepo.AddMember(ctx, member); err != nil {
+		return nil, fmt.Errorf("failed to add member: %w", err)
+	}
+	return convertClassMemberToDTO(*member), nil
+}
+
+// JoinClass adds the student to the class identified by the invite code
+func (u *ClassUsecase) JoinClass(ctx context.Context, userID string, req dto.JoinClassRequest) (*dto.ClassResponse, error) {
+	class, err := u.classRepo.GetClassByInviteCode(ctx, strings.ToUpper(strings.TrimSpace(req.InviteCode)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get class: %w", err)
+	}
+	if class == nil {
+		return nil, fmt.Errorf("invalid invite code")
+	}
+
+	existing, err := u.classRepo.GetMember(ctx, class.ID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get member: %w", err)
+	}
+	if existing == nil {
+		member := &entities.ClassMember{ClassID: class.ID, UserID: userID, Role: entities.MemberRoleStudent}
+		if err := u.classRepo.AddMember(ctx, member); err != nil {
+			return nil, fmt.Errorf("failed to add member: %w", err)
+		}
+		logger.FromContext(ctx, u.logger).Info("クラスに参加", zap.String("class_id", class.ID), zap.String("user_id", userID))
+	}
+
+	return convertClassToDTO(*class, nil, false), nil
+}
+
+func (u *ClassUsecase) ListMyClasses(ctx context.Context, userID string) ([]dto.ClassResponse, error) {
+	classes, err := u.classRepo.ListClassesByMember(ctx, userID, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list classes: %w", err)
+	}
+
+	response := make([]dto.ClassResponse, 0, len(classes))
+	for _, c := range classes {
+		response = append(response, *convertClassToDTO(c, nil, false))
+	}
+	return response, nil
+}
+
+func (u *ClassUsecase) CreateAssignment(ctx context.Context, classID, actorID string, isAdmin bool, req dto.AssignmentRequest) (*dto.AssignmentResponse, error) {
+	class, err := u.authorizeTeacher(ctx, classID, actorID, isAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateAssignment(req); err != nil {
+		return nil, err
+	}
+
+	test, err := u.testRepo.GetByID(ctx, req.TestID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get test: %w", err)
+	}
+	if test == nil {
+		return nil, fmt.Errorf("test not found")
+	}
+
+	title := req.Title
+	if title == "" {
+		title = test.Title
+	}
+	policy := req.AttemptPolicy
+	if policy == "" {
+		policy = entities.AttemptPolicyLatest
+	}
+	assignment := &entities.Assignment{
+		ClassID:           class.ID,
+		TestID:            test.ID,
+		Title:             title,
+		OpensAt:           req.OpensAt,
+		DueAt:             req.DueAt,
+		ClosesAt:          req.ClosesAt,
+		MaxAttempts:       req.MaxAttempts,
+		AttemptPolicy:     policy,
+		LatePenaltyPerDay: req.LatePenaltyPerDay,
+		MaxLatePenalty:    req.MaxLatePenalty,
+		CreatedBy:         actorID,
+	}
+	if err := u.classRepo.CreateAssignment(ctx, assignment); err != nil {
+		return nil, fmt.Errorf("failed to create assignment: %w", err)
+	}
+
+	logger.FromContext(ctx, u.logger).Info("課題を作成",
+		zap.String("class_id", class.ID),
+		zap.String("assignment_id", assignment.ID),
+		zap.String("test_id", test.ID))
+	return convertAssignmentToDTO(*assignment, test), nil
+}
+
+func (u *ClassUsecase) ListAssignments(ctx context.Context, classID, actorID string, isAdmin bool) ([]dto.AssignmentResponse, error) {
+	class, err := u.authorizeTeacher(ctx, classID, actorID, isAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	assignments, err := u.classRepo.ListAssignmentsByClass(ctx, class.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list assignments: %w", err)
+	}
+
+	tests := make(map[string]*entities.EssayTest)
+	response := make([]dto.AssignmentResponse, 0, len(assignments))
+	for _, a := range assignments {
+		test, err := u.getTestCached(ctx, tests, a.TestID)
+		if err != nil {
+			return nil, err
+		}
+		response = append(response, *convertAssignmentToDTO(a, test))
+	}
+	return response, nil
+}
+
+// ListMyAssignments returns the assignments of every class the student belongs to, with their own progress
+func (u *ClassUsecase) ListMyAssignments(ctx context.Context, userID string) ([]dto.StudentAssignmentResponse, error) {
+	classes, err := u.classRepo.ListClassesByMember(ctx, userID, entities.MemberRoleStudent)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list classes: %w", err)
+	}
+
+	classNames := make(map[string]string)
+	var classIDs []string
+	for _, c := range classes {
+		classNames[c.ID] = c.Name
+		classIDs = append(classIDs, c.ID)
+	}
+
+	assignments, err := u.classRepo.ListAssignmentsByClasses(ctx, classIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list assignments: %w", err)
+	}
+
+	tests := make(map[string]*entities.EssayTest)
+	response := make([]dto.StudentAssignmentResponse, 0, len(assignments))
+	for _, a := range assignments {
+		test, err := u.getTestCached(ctx, tests, a.TestID)
+		if err != nil {
+			return nil, err
+		}
+		progress, err := u.studentProgress(ctx, a, []string{userID})
+		if err != nil {
+			return nil, err
+		}
+		response = append(response, dto.StudentAssignmentResponse{
+			Assignment: *convertAssignmentToDTO(a, test),
+			ClassName:  classNames[a.ClassID],
+			Status:     progress[userID],
+		})
+	}
+	return response, nil
+}
+
+// GetAssignmentProgress reports which students completed the assignment and their scores
+func (u *ClassUsecase) GetAssignmentProgress(ctx context.Context, assignmentID, actorID string, isAdmin bool) (*dto.AssignmentProgressResponse, error) {
+	assignment, err := u.classRepo.GetAssignment(ctx, assignmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assignment: %w", err)
+	}
+	if assignment == nil {
+		return nil, fmt.Errorf("assignment not found")
+	}
+	if _, err := u.authorizeTeacher(ctx, assignment.ClassID, actorID, isAdmin); err != nil {
+		if err.Error() == "class not found" {
+			return nil, fmt.Errorf("assignment not found")
+		}
+		return nil, err
+	}
+
+	studentIDs, err := u.studentIDs(ctx, assignment.ClassID)
+	if err != nil {
+		return nil, err
+	}
+	test, err := u.getTestCached(ctx, make(map[string]*entities.EssayTest), assignment.TestID)
+	if err != nil {
+		return nil, err
+	}
+	progress, err := u.studentProgress(ctx, *assignment, studentIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.AssignmentProgressResponse{
+		Assignment:   *convertAssignmentToDTO(*assignment, test),
+		StudentCount: len(studentIDs),
+		Students:     make([]dto.StudentProgressResponse, 0, len(studentIDs)),
+	}
+	var scoreSum int
+	for _, id := range studentIDs {
+		p := progress[id]
+		if p.Submitted {
+			response.CompletedCount++
+		}
+		if p.TotalScore != nil {
+			scoreSum += *p.TotalScore
+		}
+		response.Students = append(response.Students, p)
+	}
+	if response.StudentCount > 0 {
+		response.CompletionRate = float64(response.CompletedCount) / float64(response.StudentCount) * 100
+	}
+	if response.CompletedCount > 0 {
+		average := float64(scoreSum) / float64(response.CompletedCount)
+		response.AverageScore = &average
+	}
+	return response, nil
+}
+
+// GetClassScores builds the teacher dashboard: every student's score on every assignment of the class
+func (u *ClassUsecase) GetClassScores(ctx context.Context, classID, actorID string, isAdmin bool) (*dto.ClassScoresResponse, error) {
+	class, err := u.authorizeTeacher(ctx, classID, actorID, isAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	studentIDs, err := u.studentIDs(ctx, class.ID)
+	if err != nil {
+		return nil, err
+	}
+	assignments, err := u.classRepo.ListAssignmentsByClass(ctx, class.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list assignments: %w", err)
+	}
+
+	response := &dto.ClassScoresResponse{
+		Class:       *convertClassToDTO(*class, nil, true),
+		Assignments: make([]dto.AssignmentResponse, 0, len(assignments)),
+		Students:    make([]dto.StudentScoresResponse, 0, len(studentIDs)),
+	}
+	rows := make(map[string]*dto.StudentScoresResponse)
+	for _, id := range studentIDs {
+		response.Students = append(response.Students, dto.StudentScoresResponse{UserID: id, Assignments: []dto.StudentProgressResponse{}})
+	}
+	for i := range response.Students {
+		rows[response.Students[i].UserID] = &response.Students[i]
+	}
+
+	tests := make(map[string]*entities.EssayTest)
+	percentageSums := make(map[string]float64)
+	for _, a := range assignments {
+		test, err := u.getTestCached(ctx, tests, a.TestID)
+		if err != nil {
+			return nil, err
+		}
+		response.Assignments = append(response.Assignments, *convertAssignmentToDTO(a, test))
+
+		progress, err := u.studentProgress(ctx, a, studentIDs)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range studentIDs {
+			p := progress[id]
+			row := rows[id]
+			row.Assignments = append(row.Assignments, p)
+			if p.Submitted {
+				row.CompletedCount++
+			}
+			if p.Percentage != nil {
+				percentageSums[id] += *p.Percentage
+			}
+		}
+	}
+
+	for i := range response.Students {
+		row := &response.Students[i]
+		if row.CompletedCount > 0 {
+			average := percentageSums[row.UserID] / float64(row.CompletedCount)
+			row.AveragePercentage = &average
+		}
+	}
+	return response, nil
+}
+
+// studentProgress reports each student's attempts at the assignment and the one counted under its attempt policy
+func (u *ClassUsecase) studentProgress(ctx context.Context, assignment entities.Assignment, userIDs []string) (map[string]dto.StudentProgressResponse, error) {
+	submissions, err := u.submissionRepo.GetScoredByAssignment(ctx, assignment.ID, userIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get submissions: %w", err)
+	}
+
+	attempts := make(map[string][]entities.Submission)
+	var submissionIDs []string
+	for _, s := range submissions {
+		attempts[s.UserID] = append(attempts[s.UserID], s)
+		submissionIDs = append(submissionIDs, s.ID)
+	}
+
+	results, err := u.resultRepo.GetCurrentBySubmissionIDs(ctx, submissionIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get results: %w", err)
+	}
+	bySubmission := make(map[string]entities.ScoringResult)
+	for _, r := range results {
+		bySubmission[r.SubmissionID] = r
+	}
+
+	progress := make(map[string]dto.StudentProgressResponse, len(userIDs))
+	for _, id := range userIDs {
+		p := dto.StudentProgressResponse{UserID: id, Attempts: len(attempts[id])}
+		if s, r, ok := countedAttempt(assignment.AttemptPolicy, attempts[id], bySubmission); ok {
+			submittedAt := s.CreatedAt
+			total, percentage := r.TotalScore, r.Percentage
+			p.Submitted = true
+			p.SubmittedAt = &submittedAt
+			p.Late = s.Late
+			p.LatePenalty = r.LatePenalty
+			p.ResultID = r.ID
+			p.TotalScore = &total
+			p.MaxScore = r.MaxScore
+			p.Percentage = &percentage
+		}
+		progress[id] = p
+	}
+	return progress, nil
+}
+
+func (u *ClassUsecase) studentIDs(ctx context.Context, classID string) ([]string, error) {
+	members, err := u.classRepo.ListMembers(ctx, classID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list members: %w", err)
+	}
+
+	ids := []string{}
+	for _, m := range members {
+		if m.Role == entities.MemberRoleStudent {
+			ids = append(ids, m.UserID)
+		}
+	}
+	return ids, nil
+}
+
+func (u *ClassUsecase) authorizeTeacher(ctx context.Context, classID, actorID string, isAdmin bool) (*entities.Class, error) {
+	return authorizeClassTeacher(ctx, u.classRepo, classID, actorID, isAdmin)
+}
+
+// authorizeClassTeacher loads the class if the caller teaches it; other classes are reported as not found
+func authorizeClassTeacher(ctx context.Context, classRepo repositories.ClassRepository, classID, actorID string, isAdmin bool) (*entities.Class, error) {
+	class, err := classRepo.GetClass(ctx, classID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get class: %w", err)
+	}
+	if class == nil {
+		return nil, fmt.Errorf("class not found")
+	}
+	if isAdmin {
+		return class, nil
+	}
+
+	member, err := classRepo.GetMember(ctx, classID, actorID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get member: %w", err)
+	}
+	if member == nil || member.Role != entities.MemberRoleTeacher {
+		return nil, fmt.Errorf("class not found")
+	}
+	return class, nil
+}
+
+// taughtAssignmentIDs returns the IDs of the assignments in every class the teacher teaches;
+// the slice is never nil, so an empty one scopes a query to nothing
+func taughtAssignmentIDs(ctx context.Context, classRepo repositories.ClassRepository, teacherID string) ([]string, error) {
+	classes, err := classRepo.ListClassesByMember(ctx, teacherID, entities.MemberRoleTeacher)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list classes: %w", err)
+	}
+	classIDs := make([]string, 0, len(classes))
+	for _, c := range classes {
+		classIDs = append(classIDs, c.ID)
+	}
+
+	assignments, err := classRepo.ListAssignmentsByClasses(ctx, classIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list assignments: %w", err)
+	}
+	ids := make([]string, 0, len(assignments))
+	for _, a := range assignments {
+		ids = append(ids, a.ID)
+	}
+	return ids, nil
+}
+
+// countedAttempt picks the attempt that counts for the assignment: the highest scored one or the most recent one
+func countedAttempt(policy string, attempts []entities.Submission, results map[string]entities.ScoringResult) (entities.Submission, entities.ScoringResult, bool) {
+	var counted entities.Submission
+	var countedResult entities.ScoringResult
+	found := false
+	for _, s := range attempts {
+		r, ok := results[s.ID]
+		if !ok {
+			continue
+		}
+		// 同点の場合は先に提出した答案を採用する
+		if !found || policy != entities.AttemptPolicyBest || r.TotalScore > countedResult.TotalScore {
+			counted, countedResult, found = s, r, true
+		}
+	}
+	return counted, countedResult, found
+}
+
+func validateAssignment(req dto.AssignmentRequest) error {
+	switch req.AttemptPolicy {
+	case "", entities.AttemptPolicyBest, entities.AttemptPolicyLatest:
+	default:
+		return fmt.Errorf("invalid assignment")
+	}
+	if req.MaxAttempts < 0 || req.LatePenaltyPerDay < 0 || req.LatePenaltyPerDay > 100 || req.MaxLatePenalty < 0 || req.MaxLatePenalty > 100 {
+		return fmt.Errorf("invalid assignment")
+	}
+	if req.OpensAt != nil && req.ClosesAt != nil && !req.OpensAt.Before(*req.ClosesAt) {
+		return fmt.Errorf("invalid assignment")
+	}
+	if req.DueAt != nil {
+		if (req.OpensAt != nil && req.DueAt.Before(*req.OpensAt)) || (req.ClosesAt != nil && req.DueAt.After(*req.ClosesAt)) {
+			return fmt.Errorf("invalid assignment")
+		}
+	}
+	return nil
+}
+
+func (u *ClassUsecase) getTestCached(ctx context.Context, tests map[string]*entities.EssayTest, testID string) (*entities.EssayTest, error) {
+	if test, ok := tests[testID]; ok {
+		return test, nil
+	}
+	test, err := u.testRepo.GetByID(ctx, testID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get test: %w", err)
+	}
+	if test == nil {
+		return nil, fmt.Errorf("test not found")
+	}
+	tests[testID] = test
+	return test, nil
+}
+
+func (u *ClassUsecase) newInviteCode(ctx context.Context) (string, error) {
+	for i := 0; i < inviteCodeAttempts; i++ {
+		code, err := randomInviteCode()
+		if err != nil {
+			return "", fmt.Errorf("failed to generate invite code: %w", err)
+		}
+		existing, err := u.classRepo.GetClassByInviteCode(ctx, code)
+		if err != nil {
+			return "", fmt.Errorf("failed to check invite code: %w", err)
+		}
+		if existing == nil {
+			return code, nil
+		}
+	}
+	return "", fmt.Errorf("failed to generate unique invite code")
+}
+
+func randomInviteCode() (string, error) {
+	var b strings.Builder
+	size := big.NewInt(int64(len(inviteCodeAlphabet)))
+	for i := 0; i < inviteCodeLength; i++ {
+		n, err := rand.Int(rand.Reader, size)
+		if err != nil {
+			return "", err
+		}
+		b.WriteByte(inviteCodeAlphabet[n.Int64()])
+	}
+	return b.String(), nil
+}
+
+func convertOrganizationToDTO(o entities.Organization) *dto.OrganizationResponse {
+	return &dto.OrganizationResponse{
+		ID:        o.ID,
+		Name:      o.Name,
+		Type:      o.Type,
+		CreatedAt: o.CreatedAt,
+	}
+}
+
+// convertClassToDTO shows the invite code only to the class's teachers
+func convertClassToDTO(c entities.Class, members []entities.ClassMember, withInviteCode bool) *dto.ClassResponse {
+	response := &dto.ClassResponse{
+		ID:             c.ID,
+		OrganizationID: c.OrganizationID,
+		Name:           c.Name,
+		CreatedAt:      c.CreatedAt,
+	}
+	if withInviteCode {
+		response.InviteCode = c.InviteCode
+	}
+	for _, m := range members {
+		response.Members = append(response.Members, *convertClassMemberToDTO(m))
+	}
+	return response
+}
+
+func convertClassMemberToDTO(m entities.ClassMember) *dto.ClassMemberResponse {
+	return &dto.ClassMemberResponse{
+		UserID:   m.UserID,
+		Role:     m.Role,
+		JoinedAt: m.CreatedAt,
+	}
+}
+
+func convertAssignmentToDTO(a entities.Assignment, test *entities.EssayTest) *dto.AssignmentResponse {
+	return &dto.AssignmentResponse{
+		ID:                a.ID,
+		ClassID:           a.ClassID,
+		TestID:            a.TestID,
+		TestTitle:         test.Title,
+		Title:             a.Title,
+		OpensAt:           a.OpensAt,
+		DueAt:             a.DueAt,
+		ClosesAt:          a.ClosesAt,
+		MaxAttempts:       a.MaxAttempts,
+		AttemptPolicy:     a.AttemptPolicy,
+		LatePenaltyPerDay: a.LatePenaltyPerDay,
+		MaxLatePenalty:    a.MaxLatePenalty,
+		CreatedAt:         a.CreatedAt,
+	}
+}
@@ -0,0 +1,83 @@
+package usecases
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"essay-test-backend/internal/domain/repositories"
+)
+
+// テスト一覧の1ページの件数
+const (
+	testCatalogDefaultLimit = 20
+	testCatalogMaxLimit     = 100
+)
+
+// testCursor is the serialized position in the catalog; it records the order so that a cursor is not reused with another one
+type testCursor struct {
+	Sort         string    `json:"s"`
+	ID           string    `json:"id"`
+	CreatedAt    time.Time `json:"c"`
+	Participants int       `json:"p,omitempty"`
+}
+
+func encodeTestCursor(sort string, cursor repositories.TestCursor) string {
+	return encodeCursor(testCursor{
+		Sort:         sort,
+		ID:           cursor.ID,
+		CreatedAt:    cursor.CreatedAt,
+		Participants: cursor.Participants,
+	})
+}
+
+func decodeTestCursor(value, sort string) (*repositories.TestCursor, error) {
+	var cursor testCursor
+	if err := decodeCursor(value, &cursor); err != nil {
+		return nil, err
+	}
+	if cursor.Sort != sort || cursor.ID == "" {
+		return nil, fmt.Errorf("cursor does not match sort %q", sort)
+	}
+	return &repositories.TestCursor{
+		ID:           cursor.ID,
+		CreatedAt:    cursor.CreatedAt,
+		Participants: cursor.Participants,
+	}, nil
+}
+
+// searchCursor is the serialized position in the results of an essay search
+type searchCursor struct {
+	SubmittedAt time.Time `json:"t"`
+	ResultID    string    `json:"id"`
+}
+
+func encodeSearchCursor(cursor repositories.EssaySearchCursor) string {
+	return encodeCursor(searchCursor{SubmittedAt: cursor.SubmittedAt, ResultID: cursor.ResultID})
+}
+
+func decodeSearchCursor(value string) (*repositories.EssaySearchCursor, error) {
+	var cursor searchCursor
+	if err := decodeCursor(value, &cursor); err != nil {
+		return nil, err
+	}
+	if cursor.ResultID == "" {
+		return nil, fmt.Errorf("cursor without result id")
+	}
+	return &repositories.EssaySearchCursor{SubmittedAt: cursor.SubmittedAt, ResultID: cursor.ResultID}, nil
+}
+
+// encodeCursor makes an opaque page token of a sort key
+func encodeCursor(key interface{}) string {
+	data, _ := json.Marshal(key)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(value string, key interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, key)
+}
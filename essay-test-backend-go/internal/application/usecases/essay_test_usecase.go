@@ -2,23 +2,41 @@ package usecases
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"os"
+	"time"
 	"unicode/utf8"
 
 	"essay-test-backend/internal/application/dto"
 	"essay-test-backend/internal/domain/entities"
 	"essay-test-backend/internal/domain/repositories"
 	"essay-test-backend/internal/domain/services"
+	"essay-test-backend/pkg/config"
+	"essay-test-backend/pkg/logger"
+	"essay-test-backend/pkg/tracing"
 
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
+var tracer = tracing.Tracer("essay-test-backend/internal/application/usecases")
+
 type EssayTestUsecase struct {
 	testRepo       repositories.EssayTestRepository
 	submissionRepo repositories.SubmissionRepository
 	resultRepo     repositories.ScoringResultRepository
+	exemplarRepo   repositories.ModelAnswerRepository
+	annotationRepo repositories.AnnotationRepository
+	classRepo      repositories.ClassRepository
+	transcriptionRepo repositories.TranscriptionRepository
+	ratingRepo     repositories.RatingRepository
 	scoringService services.ScoringService
+	maxAnswerChars int
+	listeners      []services.SubmissionListener
+	claimOwner     string // 採点中の提出物に記録するこのプロセスのID。複数のレプリカで同じ提出物を二重に採点しないために使う
 	logger         *zap.Logger
 }
 
@@ -26,30 +44,97 @@ func NewEssayTestUsecase(
 	testRepo repositories.EssayTestRepository,
 	submissionRepo repositories.SubmissionRepository,
 	resultRepo repositories.ScoringResultRepository,
+	exemplarRepo repositories.ModelAnswerRepository,
+	annotationRepo repositories.AnnotationRepository,
+	classRepo repositories.ClassRepository,
+	transcriptionRepo repositories.TranscriptionRepository,
+	ratingRepo repositories.RatingRepository,
 	scoringService services.ScoringService,
+	limits config.LimitsConfig,
 	logger *zap.Logger,
+	listeners ...services.SubmissionListener,
 ) *EssayTestUsecase {
 	return &EssayTestUsecase{
 		testRepo:       testRepo,
 		submissionRepo: submissionRepo,
 		resultRepo:     resultRepo,
+		exemplarRepo:   exemplarRepo,
+		annotationRepo: annotationRepo,
+		classRepo:      classRepo,
+		transcriptionRepo: transcriptionRepo,
+		ratingRepo:     ratingRepo,
 		scoringService: scoringService,
+		maxAnswerChars: limits.MaxAnswerChars,
+		listeners:      listeners,
+		claimOwner:     newClaimOwner(),
 		logger:         logger,
 	}
 }
 
-func (u *EssayTestUsecase) GetAllTests(ctx context.Context) ([]dto.EssayTestResponse, error) {
-	u.logger.Info("すべてのテストを取得中")
-	
-	tests, err := u.testRepo.GetAll(ctx)
+// newClaimOwner identifies this process among the replicas
+func newClaimOwner() string {
+	host, err := os.Hostname()
+	if err != nil || host == "" {
+		host = "unknown"
+	}
+	return host + "-" + uuid.New().String()
+}
+
+func (u *EssayTestUsecase) GetAllTests(ctx context.Context, req dto.TestListRequest) (*dto.TestPageResponse, error) {
+	logger.FromContext(ctx, u.logger).Info("テスト一覧を取得中",
+		zap.String("category", req.Category),
+		zap.String("difficulty", req.Difficulty),
+		zap.String("q", req.Q),
+		zap.String("sort", req.Sort))
+
+	query := repositories.TestCatalogQuery{
+		Category:   req.Category,
+		Difficulty: req.Difficulty,
+		Search:     req.Q,
+		Sort:       req.Sort,
+		Limit:      req.Limit,
+	}
+	if query.Sort == "" {
+		query.Sort = repositories.TestSortNewest
+	}
+	if query.Sort != repositories.TestSortNewest && query.Sort != repositories.TestSortPopular {
+		return nil, fmt.Errorf("invalid sort")
+	}
+	if query.Limit == 0 {
+		query.Limit = testCatalogDefaultLimit
+	}
+	if query.Limit < 0 || query.Limit > testCatalogMaxLimit {
+		return nil, fmt.Errorf("invalid limit")
+	}
+	if req.Cursor != "" {
+		after, err := decodeTestCursor(req.Cursor, query.Sort)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cursor")
+		}
+		query.After = after
+	}
+
+	// 1件多く取得して次のページの有無を判定する
+	limit := query.Limit
+	query.Limit++
+	tests, err := u.testRepo.List(ctx, query)
 	if err != nil {
-		u.logger.Error("テストの取得に失敗", zap.Error(err))
+		logger.FromContext(ctx, u.logger).Error("テストの取得に失敗", zap.Error(err))
 		return nil, fmt.Errorf("failed to get tests: %w", err)
 	}
 
-	var response []dto.EssayTestResponse
+	response := &dto.TestPageResponse{Tests: []dto.EssayTestResponse{}}
+	if len(tests) > limit {
+		tests = tests[:limit]
+		last := tests[limit-1]
+		response.NextCursor = encodeTestCursor(query.Sort, repositories.TestCursor{
+			ID:           last.ID,
+			CreatedAt:    last.CreatedAt,
+			Participants: last.Participants,
+		})
+	}
 	for _, test := range tests {
-		response = append(response, dto.EssayTestResponse{
+		response.Tests = append(response.Tests, dto.EssayTestResponse{
 			ID:           test.ID,
 			Title:        test.Title,
 			Description:  test.Description,
@@ -63,21 +148,38 @@ func (u *EssayTestUsecase) GetAllTests(ctx context.Context) ([]dto.EssayTestResp
 		})
 	}
 
-	u.logger.Info("テスト取得完了", zap.Int("count", len(response)))
+	logger.FromContext(ctx, u.logger).Info("テスト取得完了", zap.Int("count", len(response.Tests)), zap.Bool("has_next", response.NextCursor != ""))
 	return response, nil
 }
 
+// ListAllTests returns the whole catalog, newest first, for the old API which did not page
+func (u *EssayTestUsecase) ListAllTests(ctx context.Context) ([]dto.EssayTestResponse, error) {
+	tests := []dto.EssayTestResponse{}
+	req := dto.TestListRequest{Limit: testCatalogMaxLimit}
+	for {
+		page, err := u.GetAllTests(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		tests = append(tests, page.Tests...)
+		if page.NextCursor == "" {
+			return tests, nil
+		}
+		req.Cursor = page.NextCursor
+	}
+}
+
 func (u *EssayTestUsecase) GetTestByID(ctx context.Context, id string) (*dto.EssayTestResponse, error) {
-	u.logger.Info("テストを取得中", zap.String("test_id", id))
+	logger.FromContext(ctx, u.logger).Info("テストを取得中", zap.String("test_id", id))
 	
 	test, err := u.testRepo.GetByID(ctx, id)
 	if err != nil {
-		u.logger.Error("テストの取得に失敗", zap.Error(err), zap.String("test_id", id))
+		logger.FromContext(ctx, u.logger).Error("テストの取得に失敗", zap.Error(err), zap.String("test_id", id))
 		return nil, fmt.Errorf("failed to get test: %w", err)
 	}
 
 	if test == nil {
-		u.logger.Warn("テストが見つかりません", zap.String("test_id", id))
+		logger.FromContext(ctx, u.logger).Warn("テストが見つかりません", zap.String("test_id", id))
 		return nil, fmt.Errorf("test not found")
 	}
 
@@ -100,12 +202,25 @@ func (u *EssayTestUsecase) GetTestByID(ctx context.Context, id string) (*dto.Ess
 		},
 	}
 
-	u.logger.Info("テスト取得完了", zap.String("test_id", id), zap.String("title", test.Title))
+	logger.FromContext(ctx, u.logger).Info("テスト取得完了", zap.String("test_id", id), zap.String("title", test.Title))
 	return response, nil
 }
 
-func (u *EssayTestUsecase) SubmitEssay(ctx context.Context, req dto.SubmissionRequest) (*dto.SubmissionResponse, error) {
-	u.logger.Info("小論文提出開始", 
+// SubmitEssay stores and scores a submission; userID is the authenticated caller, empty for anonymous callers,
+// whose user_id in the request is only a label and never grants access to an assignment or a transcription
+func (u *EssayTestUsecase) SubmitEssay(ctx context.Context, userID string, req dto.SubmissionRequest) (response *dto.SubmissionResponse, err error) {
+	ctx, span := tracer.Start(ctx, "EssayTestUsecase.SubmitEssay", trace.WithAttributes(
+		attribute.String("test_id", req.TestID),
+		attribute.Int("answers", len(req.Answers))))
+	defer func() { tracing.End(span, err) }()
+	log := logger.FromContext(ctx, u.logger)
+
+	// ログイン中の利用者を提出者とする
+	if userID != "" {
+		req.UserID = userID
+	}
+
+	log.Info("小論文提出開始", 
 		zap.String("test_id", req.TestID),
 		zap.String("user_id", req.UserID),
 		zap.Int("answers_count", len(req.Answers)))
@@ -113,73 +228,118 @@ func (u *EssayTestUsecase) SubmitEssay(ctx context.Context, req dto.SubmissionRe
 	// テストの存在確認
 	test, err := u.testRepo.GetByID(ctx, req.TestID)
 	if err != nil {
-		u.logger.Error("テストの取得に失敗", zap.Error(err), zap.String("test_id", req.TestID))
+		log.Error("テストの取得に失敗", zap.Error(err), zap.String("test_id", req.TestID))
 		return nil, fmt.Errorf("failed to get test: %w", err)
 	}
 
 	if test == nil {
-		u.logger.Warn("テストが見つかりません", zap.String("test_id", req.TestID))
+		log.Warn("テストが見つかりません", zap.String("test_id", req.TestID))
 		return nil, fmt.Errorf("test not found")
 	}
 
 	// 回答数の検証
 	if len(req.Answers) != len(test.Questions) {
-		u.logger.Error("回答数が不正", 
+		log.Error("回答数が不正", 
 			zap.Int("expected", len(test.Questions)), 
 			zap.Int("actual", len(req.Answers)))
 		return nil, fmt.Errorf("expected %d answers, got %d", len(test.Questions), len(req.Answers))
 	}
 
-	// 提出データの作成
+	// 提出データの作成。保存した時点からこのプロセスが採点中として記録し、他のレプリカに採点させない
+	claimedAt := time.Now()
 	submission := &entities.Submission{
 		ID:     uuid.New().String(),
 		TestID: req.TestID,
 		UserID: req.UserID,
-		Status: "pending",
+		Status: "scoring",
+		ClaimedBy: u.claimOwner,
+		ClaimedAt: &claimedAt,
+	}
+
+	// 課題と手書き答案は本人のものに限るため、認証済みの利用者のみ提出できる
+	if userID == "" && (req.AssignmentID != "" || usesTranscription(req.Answers)) {
+		log.Warn("認証されていない課題・手書き答案の提出", zap.String("assignment_id", req.AssignmentID))
+		return nil, fmt.Errorf("authentication required")
+	}
+
+	// 課題としての提出は受付期間・提出回数を確認し、遅延提出の減点率を記録する
+	maxAttempts := 0
+	if req.AssignmentID != "" {
+		if maxAttempts, err = u.prepareAssignmentSubmission(ctx, submission, req.AssignmentID); err != nil {
+			log.Warn("課題の提出を受け付けられません",
+				zap.Error(err),
+				zap.String("assignment_id", req.AssignmentID),
+				zap.String("user_id", req.UserID))
+			return nil, err
+		}
 	}
 
 	for i, answer := range req.Answers {
+		content := answer.Content
+
+		// 手書き答案は生徒が確認した読み取り結果を回答とする
+		if answer.TranscriptionID != "" {
+			transcription, err := u.confirmedTranscription(ctx, answer.TranscriptionID, submission, answer.QuestionID)
+			if err != nil {
+				log.Warn("手書き答案の読み取り結果を使用できません",
+					zap.Error(err),
+					zap.String("transcription_id", answer.TranscriptionID))
+				return nil, err
+			}
+			content = transcription.Content
+		}
+		if answerTooLong(content, u.maxAnswerChars) {
+			log.Warn("回答が長すぎます",
+				zap.Int("question_num", i+1),
+				zap.Int("word_count", utf8.RuneCountInString(content)),
+				zap.Int("max", u.maxAnswerChars))
+			return nil, fmt.Errorf("answer too long")
+		}
+
 		submission.Answers = append(submission.Answers, entities.Answer{
 			ID:           uuid.New().String(),
 			SubmissionID: submission.ID,
 			QuestionID:   answer.QuestionID,
-			Content:      answer.Content,
-			WordCount:    utf8.RuneCountInString(answer.Content),
+			Content:      content,
+			WordCount:    utf8.RuneCountInString(content),
+			TranscriptionID: answer.TranscriptionID,
 		})
 		
-		u.logger.Debug("回答詳細", 
+		log.Debug("回答詳細", 
 			zap.Int("question_num", i+1),
 			zap.String("question_id", answer.QuestionID),
-			zap.Int("word_count", utf8.RuneCountInString(answer.Content)))
+			zap.Int("word_count", utf8.RuneCountInString(content)))
 	}
 
-	// 提出データの保存
-	if err := u.submissionRepo.Create(ctx, submission); err != nil {
-		u.logger.Error("提出データの保存に失敗", zap.Error(err))
+	// 提出データの保存。課題の提出は回数の確認と同じトランザクションで保存し、同時の提出でも上限を超えない
+	if submission.AssignmentID != "" {
+		err = u.submissionRepo.CreateAttempt(ctx, submission, maxAttempts)
+	} else {
+		err = u.submissionRepo.Create(ctx, submission)
+	}
+	if errors.Is(err, repositories.ErrAttemptLimitReached) {
+		log.Warn("提出回数の上限に達しています", zap.String("assignment_id", submission.AssignmentID), zap.String("user_id", submission.UserID))
+		return nil, fmt.Errorf("attempt limit reached")
+	}
+	// 同じ読み取り結果を使った提出が先に保存された場合は、この提出を保存しない
+	if errors.Is(err, repositories.ErrTranscriptionUsed) {
+		log.Warn("手書き答案の読み取り結果は別の提出で使用済み", zap.String("user_id", submission.UserID))
+		return nil, fmt.Errorf("invalid transcription")
+	}
+	if err != nil {
+		log.Error("提出データの保存に失敗", zap.Error(err))
 		return nil, fmt.Errorf("failed to create submission: %w", err)
 	}
 
-	u.logger.Info("提出データ保存完了", zap.String("submission_id", submission.ID))
+	log.Info("提出データ保存完了", zap.String("submission_id", submission.ID))
 
-	// 採点の実行
-	result, err := u.scoringService.ScoreSubmission(ctx, submission, test)
+	// 採点の実行。クライアントが切断しても採点待ちのまま残らないよう、リクエストの取り消しを引き継がない
+	result, err := u.scoreAndSave(context.WithoutCancel(ctx), submission, test)
 	if err != nil {
-		submission.Status = "failed"
-		u.submissionRepo.Update(ctx, submission)
-		u.logger.Error("採点に失敗", zap.Error(err), zap.String("submission_id", submission.ID))
-		return nil, fmt.Errorf("failed to score submission: %w", err)
+		return nil, err
 	}
 
-	// 結果の保存
-	if err := u.resultRepo.Create(ctx, result); err != nil {
-		u.logger.Error("結果の保存に失敗", zap.Error(err))
-		return nil, fmt.Errorf("failed to save result: %w", err)
-	}
-
-	submission.Status = "scored"
-	u.submissionRepo.Update(ctx, submission)
-
-	u.logger.Info("採点完了", 
+	log.Info("採点完了", 
 		zap.String("result_id", result.ID),
 		zap.Int("total_score", result.TotalScore),
 		zap.Float64("percentage", result.Percentage))
@@ -189,26 +349,213 @@ func (u *EssayTestUsecase) SubmitEssay(ctx context.Context, req dto.SubmissionRe
 		TotalScore: result.TotalScore,
 		MaxScore:   result.MaxScore,
 		Percentage: result.Percentage,
+		Attempt:    submission.Attempt,
+		LatePenalty: result.LatePenalty,
 		Message:    "採点が完了しました",
 	}, nil
 }
 
+// ScorePending scores a submission stored by the batch ingestion or left unscored by a stopped process.
+// Submissions no longer pending, or claimed by another process since staleBefore, are skipped
+func (u *EssayTestUsecase) ScorePending(ctx context.Context, submissionID string, staleBefore time.Time) (err error) {
+	ctx, span := tracer.Start(ctx, "EssayTestUsecase.ScorePending", trace.WithAttributes(
+		attribute.String("submission_id", submissionID)))
+	defer func() { tracing.End(span, err) }()
+
+	// 採点待ちの提出物を採点中として取得できたプロセスだけが採点する
+	claimed, err := u.submissionRepo.Claim(ctx, submissionID, u.claimOwner, staleBefore)
+	if err != nil {
+		return fmt.Errorf("failed to claim submission: %w", err)
+	}
+	if !claimed {
+		return nil
+	}
+
+	submission, err := u.submissionRepo.GetByID(ctx, submissionID)
+	if err != nil {
+		u.releaseClaim(ctx, submissionID)
+		return fmt.Errorf("failed to get submission: %w", err)
+	}
+	if submission == nil {
+		return nil
+	}
+
+	test, err := u.testRepo.GetByID(ctx, submission.TestID)
+	if err != nil {
+		u.releaseClaim(ctx, submissionID)
+		return fmt.Errorf("failed to get test: %w", err)
+	}
+	if test == nil {
+		u.releaseClaim(ctx, submissionID)
+		return fmt.Errorf("test not found")
+	}
+
+	result, err := u.scoreAndSave(ctx, submission, test)
+	if err != nil {
+		return err
+	}
+
+	logger.FromContext(ctx, u.logger).Info("採点完了",
+		zap.String("submission_id", submission.ID),
+		zap.String("result_id", result.ID),
+		zap.Int("total_score", result.TotalScore))
+	return nil
+}
+
+// scoreAndSave scores a stored submission, saves the result and notifies the listeners
+func (u *EssayTestUsecase) scoreAndSave(ctx context.Context, submission *entities.Submission, test *entities.EssayTest) (*entities.ScoringResult, error) {
+	log := logger.FromContext(ctx, u.logger)
+	result, err := u.scoringService.ScoreSubmission(ctx, submission, test)
+	if err != nil {
+		// 停止で中断されたキューの採点は採点待ちに戻し、次回の起動時に採点し直す
+		if ctx.Err() != nil {
+			log.Warn("採点を中断", zap.Error(err), zap.String("submission_id", submission.ID))
+			u.releaseClaim(ctx, submission.ID)
+			return nil, fmt.Errorf("scoring interrupted: %w", err)
+		}
+		submission.Status = "failed"
+		submission.ClaimedBy, submission.ClaimedAt = "", nil
+		u.submissionRepo.Update(ctx, submission)
+		log.Error("採点に失敗", zap.Error(err), zap.String("submission_id", submission.ID))
+		return nil, fmt.Errorf("failed to score submission: %w", err)
+	}
+
+	if submission.LatePenaltyPercent > 0 {
+		result.ApplyLatePenalty(submission.LatePenaltyPercent)
+	}
+
+	// 結果の保存（人による修正後も自動採点の点数を残す）
+	result.SnapshotAutoScores()
+	if err := u.resultRepo.CreateVersion(ctx, result); err != nil {
+		log.Error("結果の保存に失敗", zap.Error(err))
+		u.releaseClaim(ctx, submission.ID)
+		return nil, fmt.Errorf("failed to save result: %w", err)
+	}
+
+	submission.Status = "scored"
+	submission.ClaimedBy, submission.ClaimedAt = "", nil
+	u.submissionRepo.Update(ctx, submission)
+
+	u.notifyListeners(ctx, submission, test, result)
+	return result, nil
+}
+
+// releaseClaim returns a submission this process could not finish scoring to pending. It runs even when ctx
+// was cancelled by the shutdown, and a submission left claimed is taken over once the claim goes stale
+func (u *EssayTestUsecase) releaseClaim(ctx context.Context, submissionID string) {
+	if err := u.submissionRepo.Release(context.WithoutCancel(ctx), submissionID, u.claimOwner); err != nil {
+		logger.FromContext(ctx, u.logger).Error("採点中の記録の解除に失敗", zap.Error(err), zap.String("submission_id", submissionID))
+	}
+}
+
+func (u *EssayTestUsecase) notifyListeners(ctx context.Context, submission *entities.Submission, test *entities.EssayTest, result *entities.ScoringResult) {
+	ctx, span := tracer.Start(ctx, "EssayTestUsecase.notifyListeners", trace.WithAttributes(
+		attribute.Int("listeners", len(u.listeners))))
+	defer span.End()
+
+	for _, listener := range u.listeners {
+		listener.OnSubmissionScored(ctx, submission, test, result)
+	}
+}
+
+// usesTranscription reports whether any answer is taken from a transcription
+func usesTranscription(answers []dto.AnswerRequest) bool {
+	for _, answer := range answers {
+		if answer.TranscriptionID != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// answerTooLong reports whether content exceeds the configured number of characters; 0 means no limit
+func answerTooLong(content string, maxChars int) bool {
+	return maxChars > 0 && utf8.RuneCountInString(content) > maxChars
+}
+
+// confirmedTranscription returns the student's confirmed, not yet submitted transcription of the question
+func (u *EssayTestUsecase) confirmedTranscription(ctx context.Context, transcriptionID string, submission *entities.Submission, questionID string) (*entities.Transcription, error) {
+	transcription, err := u.transcriptionRepo.GetByID(ctx, transcriptionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transcription: %w", err)
+	}
+	if transcription == nil ||
+		submission.UserID == "" ||
+		transcription.UserID != submission.UserID ||
+		transcription.TestID != submission.TestID ||
+		transcription.QuestionID != questionID ||
+		transcription.SubmissionID != "" {
+		return nil, fmt.Errorf("invalid transcription")
+	}
+	if transcription.Status != entities.TranscriptionStatusConfirmed {
+		return nil, fmt.Errorf("transcription not confirmed")
+	}
+	return transcription, nil
+}
+
+// prepareAssignmentSubmission checks the assignment's rules for the submitting student and returns the attempt limit.
+// The attempt itself is numbered by CreateAttempt when the submission is stored.
+func (u *EssayTestUsecase) prepareAssignmentSubmission(ctx context.Context, submission *entities.Submission, assignmentID string) (int, error) {
+	assignment, err := u.classRepo.GetAssignment(ctx, assignmentID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get assignment: %w", err)
+	}
+	if assignment == nil || assignment.TestID != submission.TestID {
+		return 0, fmt.Errorf("assignment not found")
+	}
+
+	if submission.UserID == "" {
+		return 0, fmt.Errorf("not a class member")
+	}
+	member, err := u.classRepo.GetMember(ctx, assignment.ClassID, submission.UserID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get member: %w", err)
+	}
+	if member == nil || member.Role != entities.MemberRoleStudent {
+		return 0, fmt.Errorf("not a class member")
+	}
+
+	now := time.Now()
+	if !assignment.IsOpen(now) {
+		return 0, fmt.Errorf("assignment not open")
+	}
+
+	// 回答を処理する前に上限を確認する。同時の提出はCreateAttemptが改めて確認する
+	attempts, err := u.submissionRepo.CountAttempts(ctx, assignment.ID, submission.UserID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count attempts: %w", err)
+	}
+	if assignment.MaxAttempts > 0 && attempts >= assignment.MaxAttempts {
+		return 0, fmt.Errorf("attempt limit reached")
+	}
+
+	submission.AssignmentID = assignment.ID
+	submission.Late = assignment.DueAt != nil && now.After(*assignment.DueAt)
+	submission.LatePenaltyPercent = assignment.LatePenaltyAt(now)
+	return assignment.MaxAttempts, nil
+}
+
 func (u *EssayTestUsecase) GetResult(ctx context.Context, resultID string) (*dto.ScoringResultResponse, error) {
-	u.logger.Info("結果を取得中", zap.String("result_id", resultID))
+	logger.FromContext(ctx, u.logger).Info("結果を取得中", zap.String("result_id", resultID))
 	
 	result, err := u.resultRepo.GetByID(ctx, resultID)
 	if err != nil {
-		u.logger.Error("結果の取得に失敗", zap.Error(err), zap.String("result_id", resultID))
+		logger.FromContext(ctx, u.logger).Error("結果の取得に失敗", zap.Error(err), zap.String("result_id", resultID))
 		return nil, fmt.Errorf("failed to get result: %w", err)
 	}
 
 	if result == nil {
-		u.logger.Warn("結果が見つかりません", zap.String("result_id", resultID))
+		logger.FromContext(ctx, u.logger).Warn("結果が見つかりません", zap.String("result_id", resultID))
 		return nil, fmt.Errorf("result not found")
 	}
+	if err := ensureRatingClosed(ctx, u.ratingRepo, result.SubmissionID); err != nil {
+		return nil, err
+	}
 
 	response := convertResultToDTO(result)
-	u.logger.Info("結果取得完了", 
+	u.attachAnswers(ctx, result, response)
+
+	logger.FromContext(ctx, u.logger).Info("結果取得完了", 
 		zap.String("result_id", resultID),
 		zap.String("test_title", result.TestTitle),
 		zap.Int("total_score", result.TotalScore))
@@ -216,6 +563,83 @@ func (u *EssayTestUsecase) GetResult(ctx context.Context, resultID string) (*dto
 	return response, nil
 }
 
+// attachAnswers adds the annotated answers and the model answers; failures only drop these extras, not the result
+func (u *EssayTestUsecase) attachAnswers(ctx context.Context, result *entities.ScoringResult, response *dto.ScoringResultResponse) {
+	submission, err := u.submissionRepo.GetByID(ctx, result.SubmissionID)
+	if err != nil || submission == nil {
+		logger.FromContext(ctx, u.logger).Error("提出データの取得に失敗", zap.Error(err), zap.String("submission_id", result.SubmissionID))
+		return
+	}
+
+	annotations, err := u.annotationRepo.GetBySubmissionID(ctx, submission.ID)
+	if err != nil {
+		logger.FromContext(ctx, u.logger).Error("添削の取得に失敗", zap.Error(err), zap.String("submission_id", submission.ID))
+	}
+	response.Answers = convertAnnotatedAnswersToDTO(submission.Answers, annotations)
+
+	if submission.AssignmentID != "" {
+		u.attachAssignment(ctx, submission, response)
+	}
+
+	exemplars, err := u.exemplarRepo.GetByTestID(ctx, result.TestID)
+	if err != nil {
+		logger.FromContext(ctx, u.logger).Error("模範解答の取得に失敗", zap.Error(err), zap.String("test_id", result.TestID))
+		return
+	}
+	if len(exemplars) == 0 {
+		return
+	}
+
+	test, err := u.testRepo.GetByID(ctx, result.TestID)
+	if err != nil || test == nil {
+		logger.FromContext(ctx, u.logger).Error("テストの取得に失敗", zap.Error(err), zap.String("test_id", result.TestID))
+		return
+	}
+
+	attachExemplars(response, test, submission, exemplars)
+}
+
+// attachAssignment shows which attempt the result is and whether it is the one counted for the assignment
+func (u *EssayTestUsecase) attachAssignment(ctx context.Context, submission *entities.Submission, response *dto.ScoringResultResponse) {
+	assignment, err := u.classRepo.GetAssignment(ctx, submission.AssignmentID)
+	if err != nil || assignment == nil {
+		logger.FromContext(ctx, u.logger).Error("課題の取得に失敗", zap.Error(err), zap.String("assignment_id", submission.AssignmentID))
+		return
+	}
+
+	response.Assignment = &dto.AssignmentAttemptResponse{
+		AssignmentID:       assignment.ID,
+		Title:              assignment.Title,
+		Attempt:            submission.Attempt,
+		MaxAttempts:        assignment.MaxAttempts,
+		AttemptPolicy:      assignment.AttemptPolicy,
+		Late:               submission.Late,
+		LatePenaltyPercent: submission.LatePenaltyPercent,
+	}
+
+	attempts, err := u.submissionRepo.GetScoredByAssignment(ctx, assignment.ID, []string{submission.UserID})
+	if err != nil {
+		logger.FromContext(ctx, u.logger).Error("提出履歴の取得に失敗", zap.Error(err), zap.String("assignment_id", assignment.ID))
+		return
+	}
+	var ids []string
+	for _, a := range attempts {
+		ids = append(ids, a.ID)
+	}
+	results, err := u.resultRepo.GetCurrentBySubmissionIDs(ctx, ids)
+	if err != nil {
+		logger.FromContext(ctx, u.logger).Error("結果の取得に失敗", zap.Error(err), zap.String("assignment_id", assignment.ID))
+		return
+	}
+	bySubmission := make(map[string]entities.ScoringResult)
+	for _, r := range results {
+		bySubmission[r.SubmissionID] = r
+	}
+
+	counted, _, ok := countedAttempt(assignment.AttemptPolicy, attempts, bySubmission)
+	response.Assignment.Counted = ok && counted.ID == submission.ID
+}
+
 // Helper functions
 func convertQuestionsToDTO(questions []entities.Question) []dto.QuestionResponse {
 	var result []dto.QuestionResponse
@@ -238,17 +662,24 @@ func convertResultToDTO(result *entities.ScoringResult) *dto.ScoringResultRespon
 		var criteriaScores []dto.CriteriaScoreResponse
 		for _, cs := range detail.CriteriaScores {
 			criteriaScores = append(criteriaScores, dto.CriteriaScoreResponse{
+				ID:           cs.ID,
 				CriteriaName: cs.CriteriaName,
 				Score:        cs.Score,
 				MaxScore:     cs.MaxScore,
 				Comment:      cs.Comment,
 				Reasoning:    cs.Reasoning,
+				Evidence:     nonNilStrings(cs.Evidence),
+				Suggestions:  nonNilStrings(cs.Suggestions),
+				AutoScore:    cs.AutoScore,
+				Overridden:   cs.Overridden,
 			})
 		}
 
 		details = append(details, dto.QuestionScoreResponse{
+			ID:             detail.ID,
 			QuestionNum:    detail.QuestionNum,
 			Score:          detail.Score,
+			AutoScore:      detail.AutoScore,
 			MaxScore:       detail.MaxScore,
 			Percentage:     detail.Percentage,
 			CriteriaScores: criteriaScores,
@@ -265,8 +696,27 @@ func convertResultToDTO(result *entities.ScoringResult) *dto.ScoringResultRespon
 		Percentage: result.Percentage,
 		Details:    details,
 		Feedback:   result.Feedback,
+		FeedbackDetail: dto.FeedbackResponse{
+			Strengths:         nonNilStrings(result.Strengths),
+			Improvements:      nonNilStrings(result.Improvements),
+			OverallAssessment: result.OverallAssessment,
+		},
 		ScoredBy:   result.ScoredBy,
+		AutoTotalScore: result.AutoTotalScore,
+		LatePenalty: result.LatePenalty,
+		ReviewedAt: result.ReviewedAt,
+		Version:    result.Version,
+		IsCurrent:  result.IsCurrent,
+		ScorerVersion: result.ScorerVersion,
 		CreatedAt:  result.CreatedAt,
 		ExpiresAt:  result.ExpiresAt,
 	}
-} 
\ No newline at end of file
+} 
+
+// nonNilStrings keeps empty lists as [] in JSON for results stored before the field existed
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
\ No newline at end of file
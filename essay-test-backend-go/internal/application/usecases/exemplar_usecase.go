@@ -0,0 +1,280 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"essay-test-backend/internal/application/dto"
+	"essay-test-backend/internal/domain/entities"
+	"essay-test-backend/internal/domain/repositories"
+	"essay-test-backend/pkg/logger"
+	"essay-test-backend/pkg/textfeatures"
+
+	"go.uber.org/zap"
+)
+
+type ExemplarUsecase struct {
+	testRepo       repositories.EssayTestRepository
+	submissionRepo repositories.SubmissionRepository
+	exemplarRepo   repositories.ModelAnswerRepository
+	logger         *zap.Logger
+}
+
+func NewExemplarUsecase(
+	testRepo repositories.EssayTestRepository,
+	submissionRepo repositories.SubmissionRepository,
+	exemplarRepo repositories.ModelAnswerRepository,
+	logger *zap.Logger,
+) *ExemplarUsecase {
+	return &ExemplarUsecase{
+		testRepo:       testRepo,
+		submissionRepo: submissionRepo,
+		exemplarRepo:   exemplarRepo,
+		logger:         logger,
+	}
+}
+
+func (u *ExemplarUsecase) CreateExemplar(ctx context.Context, testID, createdBy string, req dto.ModelAnswerRequest) (*dto.ModelAnswerResponse, error) {
+	test, err := u.getTest(ctx, testID)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateExemplar(test, req); err != nil {
+		return nil, err
+	}
+
+	exemplar := &entities.ModelAnswer{
+		TestID:     test.ID,
+		QuestionID: req.QuestionID,
+		Title:      req.Title,
+		Content:    req.Content,
+		Score:      req.Score,
+		Commentary: req.Commentary,
+		CreatedBy:  createdBy,
+	}
+	if err := u.exemplarRepo.Create(ctx, exemplar); err != nil {
+		return nil, fmt.Errorf("failed to create exemplar: %w", err)
+	}
+
+	logger.FromContext(ctx, u.logger).Info("模範解答を登録", zap.String("test_id", testID), zap.String("exemplar_id", exemplar.ID))
+	return convertModelAnswerToDTO(*exemplar, test), nil
+}
+
+func (u *ExemplarUsecase) UpdateExemplar(ctx context.Context, exemplarID string, req dto.ModelAnswerRequest) (*dto.ModelAnswerResponse, error) {
+	exemplar, err := u.getExemplar(ctx, exemplarID)
+	if err != nil {
+		return nil, err
+	}
+	test, err := u.getTest(ctx, exemplar.TestID)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateExemplar(test, req); err != nil {
+		return nil, err
+	}
+
+	exemplar.QuestionID = req.QuestionID
+	exemplar.Title = req.Title
+	exemplar.Content = req.Content
+	exemplar.Score = req.Score
+	exemplar.Commentary = req.Commentary
+	if err := u.exemplarRepo.Update(ctx, exemplar); err != nil {
+		return nil, fmt.Errorf("failed to update exemplar: %w", err)
+	}
+
+	return convertModelAnswerToDTO(*exemplar, test), nil
+}
+
+func (u *ExemplarUsecase) DeleteExemplar(ctx context.Context, exemplarID string) error {
+	if _, err := u.getExemplar(ctx, exemplarID); err != nil {
+		return err
+	}
+	if err := u.exemplarRepo.Delete(ctx, exemplarID); err != nil {
+		return fmt.Errorf("failed to delete exemplar: %w", err)
+	}
+	return nil
+}
+
+// ListExemplars returns every exemplar of a test for the teacher view
+func (u *ExemplarUsecase) ListExemplars(ctx context.Context, testID string) ([]dto.ModelAnswerResponse, error) {
+	test, err := u.getTest(ctx, testID)
+	if err != nil {
+		return nil, err
+	}
+	return u.listExemplars(ctx, test)
+}
+
+// ListReleasedExemplars returns the exemplars to a student only after they have submitted the test
+func (u *ExemplarUsecase) ListReleasedExemplars(ctx context.Context, testID, userID string) ([]dto.ModelAnswerResponse, error) {
+	test, err := u.getTest(ctx, testID)
+	if err != nil {
+		return nil, err
+	}
+
+	if userID == "" {
+		return nil, fmt.Errorf("exemplars not released")
+	}
+	submitted, err := u.submissionRepo.HasScoredSubmission(ctx, testID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check submission: %w", err)
+	}
+	if !submitted {
+		return nil, fmt.Errorf("exemplars not released")
+	}
+
+	return u.listExemplars(ctx, test)
+}
+
+func (u *ExemplarUsecase) listExemplars(ctx context.Context, test *entities.EssayTest) ([]dto.ModelAnswerResponse, error) {
+	exemplars, err := u.exemplarRepo.GetByTestID(ctx, test.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get exemplars: %w", err)
+	}
+
+	response := make([]dto.ModelAnswerResponse, 0, len(exemplars))
+	for _, e := range exemplars {
+		response = append(response, *convertModelAnswerToDTO(e, test))
+	}
+	return response, nil
+}
+
+func (u *ExemplarUsecase) getTest(ctx context.Context, testID string) (*entities.EssayTest, error) {
+	test, err := u.testRepo.GetByID(ctx, testID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get test: %w", err)
+	}
+	if test == nil {
+		return nil, fmt.Errorf("test not found")
+	}
+	return test, nil
+}
+
+func (u *ExemplarUsecase) getExemplar(ctx context.Context, exemplarID string) (*entities.ModelAnswer, error) {
+	exemplar, err := u.exemplarRepo.GetByID(ctx, exemplarID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get exemplar: %w", err)
+	}
+	if exemplar == nil {
+		return nil, fmt.Errorf("exemplar not found")
+	}
+	return exemplar, nil
+}
+
+func validateExemplar(test *entities.EssayTest, req dto.ModelAnswerRequest) error {
+	question := findQuestion(test, req.QuestionID)
+	if question == nil {
+		return fmt.Errorf("invalid question")
+	}
+	if req.Score < 0 || req.Score > question.Points {
+		return fmt.Errorf("invalid score")
+	}
+	return nil
+}
+
+func findQuestion(test *entities.EssayTest, questionID string) *entities.Question {
+	for i := range test.Questions {
+		if test.Questions[i].ID == questionID {
+			return &test.Questions[i]
+		}
+	}
+	return nil
+}
+
+// attachExemplars adds to each question the exemplar nearest above the student's score with a structural comparison
+func attachExemplars(response *dto.ScoringResultResponse, test *entities.EssayTest, submission *entities.Submission, exemplars []entities.ModelAnswer) {
+	questionIDs := make(map[int]string)
+	for _, q := range test.Questions {
+		questionIDs[q.Number] = q.ID
+	}
+	contents := make(map[string]string)
+	for _, a := range submission.Answers {
+		contents[a.QuestionID] = a.Content
+	}
+
+	for i := range response.Details {
+		detail := &response.Details[i]
+		questionID := questionIDs[detail.QuestionNum]
+
+		var nearest *entities.ModelAnswer
+		for j := range exemplars {
+			e := &exemplars[j]
+			if e.QuestionID != questionID || e.Score <= detail.Score {
+				continue
+			}
+			if nearest == nil || e.Score < nearest.Score {
+				nearest = e
+			}
+		}
+		if nearest == nil {
+			continue
+		}
+
+		student := textfeatures.Analyze(contents[questionID])
+		exemplar := textfeatures.Analyze(nearest.Content)
+		summary := convertModelAnswerToDTO(*nearest, test)
+		summary.CreatedBy = ""
+		detail.Exemplar = &dto.ExemplarComparisonResponse{
+			Exemplar:          *summary,
+			Student:           convertStructureToDTO(student),
+			ExemplarStructure: convertStructureToDTO(exemplar),
+			Differences:       compareStructure(student, exemplar),
+		}
+	}
+}
+
+// compareStructure describes where the exemplar is built differently from the student's answer
+func compareStructure(student, exemplar textfeatures.Features) []string {
+	differences := []string{}
+	if exemplar.Paragraphs > student.Paragraphs {
+		differences = append(differences, fmt.Sprintf("模範解答は%d段落で構成されています（あなたの答案は%d段落）。段落を分けて論点を整理しましょう。", exemplar.Paragraphs, student.Paragraphs))
+	}
+	if exemplar.ThesisPosition() == textfeatures.PositionOpening && student.ThesisPosition() != textfeatures.PositionOpening {
+		differences = append(differences, "模範解答は冒頭で自分の立場を示しています。")
+	}
+	if exemplar.ExampleCount > student.ExampleCount {
+		differences = append(differences, fmt.Sprintf("模範解答は具体例を%d箇所で用いています（あなたの答案は%d箇所）。", exemplar.ExampleCount, student.ExampleCount))
+	}
+	if exemplar.Counterargument != nil && student.Counterargument == nil {
+		differences = append(differences, "模範解答は反対意見に触れたうえで反論しています。")
+	}
+	if !exemplar.WeakConclusion() && student.WeakConclusion() {
+		differences = append(differences, "模範解答は最終段落で結論をまとめています。")
+	}
+	// 2割以上の字数差があるときだけ指摘する
+	if exemplar.Length > 0 && (student.Length*5 < exemplar.Length*4 || student.Length*5 > exemplar.Length*6) {
+		differences = append(differences, fmt.Sprintf("模範解答は%d字です（あなたの答案は%d字）。", exemplar.Length, student.Length))
+	}
+	return differences
+}
+
+func convertStructureToDTO(f textfeatures.Features) dto.AnswerStructureResponse {
+	return dto.AnswerStructureResponse{
+		Length:             f.Length,
+		Paragraphs:         f.Paragraphs,
+		Sentences:          len(f.Sentences),
+		ThesisPosition:     f.ThesisPosition(),
+		ExampleCount:       f.ExampleCount,
+		HasCounterargument: f.Counterargument != nil,
+		HasConclusion:      !f.WeakConclusion(),
+	}
+}
+
+func convertModelAnswerToDTO(e entities.ModelAnswer, test *entities.EssayTest) *dto.ModelAnswerResponse {
+	response := &dto.ModelAnswerResponse{
+		ID:         e.ID,
+		TestID:     e.TestID,
+		QuestionID: e.QuestionID,
+		Title:      e.Title,
+		Content:    e.Content,
+		Score:      e.Score,
+		Commentary: e.Commentary,
+		CreatedBy:  e.CreatedBy,
+		CreatedAt:  e.CreatedAt,
+		UpdatedAt:  e.UpdatedAt,
+	}
+	if q := findQuestion(test, e.QuestionID); q != nil {
+		response.QuestionNum = q.Number
+		response.MaxScore = q.Points
+	}
+	return response
+}
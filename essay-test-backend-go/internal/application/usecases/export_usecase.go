@@ -0,0 +1,322 @@
+package usecases
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"time"
+
+	"essay-test-backend/internal/application/dto"
+	"essay-test-backend/internal/domain/entities"
+	"essay-test-backend/internal/domain/repositories"
+	"essay-test-backend/pkg/logger"
+	"essay-test-backend/pkg/xlsx"
+
+	"go.uber.org/zap"
+)
+
+// 一度に読み出す採点結果の件数
+const exportBatchSize = 200
+
+const (
+	ExportFormatCSV  = "csv"
+	ExportFormatXLSX = "xlsx"
+)
+
+type ExportUsecase struct {
+	testRepo       repositories.EssayTestRepository
+	submissionRepo repositories.SubmissionRepository
+	resultRepo     repositories.ScoringResultRepository
+	classRepo      repositories.ClassRepository
+	logger         *zap.Logger
+}
+
+func NewExportUsecase(
+	testRepo repositories.EssayTestRepository,
+	submissionRepo repositories.SubmissionRepository,
+	resultRepo repositories.ScoringResultRepository,
+	classRepo repositories.ClassRepository,
+	logger *zap.Logger,
+) *ExportUsecase {
+	return &ExportUsecase{
+		testRepo:       testRepo,
+		submissionRepo: submissionRepo,
+		resultRepo:     resultRepo,
+		classRepo:      classRepo,
+		logger:         logger,
+	}
+}
+
+// ResultExport is a validated grade book export, written once the response headers are sent
+type ResultExport struct {
+	Filename    string
+	ContentType string
+
+	usecase         *ExportUsecase
+	format          string
+	filter          repositories.ResultExportFilter
+	columns         []repositories.CriteriaColumn
+	includeFeedback bool
+}
+
+// rowWriter is the part of the CSV and XLSX writers an export needs
+type rowWriter interface {
+	WriteRow(values []interface{}) error
+	Flush() error
+	Close() error
+}
+
+// PrepareResultExport checks the scope and finds the criteria columns before anything is streamed
+func (u *ExportUsecase) PrepareResultExport(ctx context.Context, actorID string, isAdmin bool, req dto.ResultExportRequest) (*ResultExport, error) {
+	format := req.Format
+	if format == "" {
+		format = ExportFormatCSV
+	}
+	if format != ExportFormatCSV && format != ExportFormatXLSX {
+		return nil, fmt.Errorf("invalid export format")
+	}
+	if req.TestID == "" && req.ClassID == "" && req.From == nil && req.To == nil {
+		return nil, fmt.Errorf("export scope required")
+	}
+	if req.From != nil && req.To != nil && !req.From.Before(*req.To) {
+		return nil, fmt.Errorf("invalid date range")
+	}
+
+	filter := repositories.ResultExportFilter{TestID: req.TestID, From: req.From, To: req.To}
+	scope := []string{"results"}
+	if req.TestID != "" {
+		test, err := u.testRepo.GetByID(ctx, req.TestID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get test: %w", err)
+		}
+		if test == nil {
+			return nil, fmt.Errorf("test not found")
+		}
+		scope = append(scope, test.ID)
+	}
+	if req.ClassID != "" {
+		class, err := authorizeClassTeacher(ctx, u.classRepo, req.ClassID, actorID, isAdmin)
+		if err != nil {
+			return nil, err
+		}
+		assignments, err := u.classRepo.ListAssignmentsByClass(ctx, class.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list assignments: %w", err)
+		}
+		filter.AssignmentIDs = make([]string, 0, len(assignments))
+		for _, a := range assignments {
+			filter.AssignmentIDs = append(filter.AssignmentIDs, a.ID)
+		}
+		scope = append(scope, class.ID)
+	} else if !isAdmin {
+		// テストや期間だけの指定でも、教員は担当するクラスの課題の提出物に限る
+		ids, err := taughtAssignmentIDs(ctx, u.classRepo, actorID)
+		if err != nil {
+			return nil, err
+		}
+		filter.AssignmentIDs = ids
+	}
+
+	columns, err := u.resultRepo.GetCriteriaColumns(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get criteria columns: %w", err)
+	}
+
+	export := &ResultExport{
+		Filename:        fmt.Sprintf("%s-%s.%s", strings.Join(scope, "-"), time.Now().Format("20060102"), format),
+		ContentType:     "text/csv; charset=utf-8",
+		usecase:         u,
+		format:          format,
+		filter:          filter,
+		columns:         columns,
+		includeFeedback: req.IncludeFeedback,
+	}
+	if format == ExportFormatXLSX {
+		export.ContentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+
+	logger.FromContext(ctx, u.logger).Info("成績の出力を開始",
+		zap.String("actor_id", actorID),
+		zap.String("format", format),
+		zap.String("test_id", req.TestID),
+		zap.String("class_id", req.ClassID))
+	return export, nil
+}
+
+// Write streams one row per submission, flushing after every batch of results
+func (e *ResultExport) Write(ctx context.Context, w io.Writer) error {
+	rows, err := e.newRowWriter(w)
+	if err != nil {
+		return err
+	}
+	if err := rows.WriteRow(e.header()); err != nil {
+		return err
+	}
+
+	tests := make(map[string]*entities.EssayTest)
+	count := 0
+	err = e.usecase.resultRepo.StreamCurrent(ctx, e.filter, exportBatchSize, func(results []entities.ScoringResult) error {
+		ids := make([]string, 0, len(results))
+		for _, r := range results {
+			ids = append(ids, r.SubmissionID)
+		}
+		submissions, err := e.usecase.submissionRepo.GetByIDs(ctx, ids)
+		if err != nil {
+			return fmt.Errorf("failed to get submissions: %w", err)
+		}
+		bySubmission := make(map[string]entities.Submission, len(submissions))
+		for _, s := range submissions {
+			bySubmission[s.ID] = s
+		}
+
+		for i := range results {
+			if _, ok := tests[results[i].TestID]; !ok {
+				test, err := e.usecase.testRepo.GetByID(ctx, results[i].TestID)
+				if err != nil {
+					return fmt.Errorf("failed to get test: %w", err)
+				}
+				tests[results[i].TestID] = test
+			}
+			if err := rows.WriteRow(e.row(&results[i], bySubmission[results[i].SubmissionID], tests[results[i].TestID])); err != nil {
+				return err
+			}
+		}
+		count += len(results)
+
+		if err := rows.Flush(); err != nil {
+			return err
+		}
+		if f, ok := w.(interface{ Flush() }); ok {
+			f.Flush()
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	logger.FromContext(ctx, e.usecase.logger).Info("成績の出力が完了", zap.String("filename", e.Filename), zap.Int("rows", count))
+	return rows.Close()
+}
+
+func (e *ResultExport) newRowWriter(w io.Writer) (rowWriter, error) {
+	if e.format == ExportFormatXLSX {
+		return xlsx.NewWriter(w, "成績")
+	}
+	// Excel で文字化けしないよう BOM を付ける
+	if _, err := io.WriteString(w, "\ufeff"); err != nil {
+		return nil, err
+	}
+	return &csvRowWriter{w: csv.NewWriter(w)}, nil
+}
+
+func (e *ResultExport) header() []interface{} {
+	header := []interface{}{"結果ID", "提出ID", "利用者ID", "テストID", "テスト名", "課題ID", "提出回数", "提出日時", "合計点", "満点", "得点率", "遅延減点", "採点方法"}
+	for i, c := range e.columns {
+		if i == 0 || e.columns[i-1].QuestionNum != c.QuestionNum {
+			header = append(header, fmt.Sprintf("問%d", c.QuestionNum))
+		}
+		header = append(header, fmt.Sprintf("問%d %s", c.QuestionNum, c.CriteriaName))
+	}
+	if e.includeFeedback {
+		header = append(header, "講評")
+	}
+	return header
+}
+
+// row lays out a result in the header's columns; scores the result does not have stay empty
+func (e *ResultExport) row(result *entities.ScoringResult, submission entities.Submission, test *entities.EssayTest) []interface{} {
+	testTitle := result.TestTitle
+	if test != nil {
+		testTitle = test.Title
+	}
+	var attempt, submittedAt interface{}
+	if submission.Attempt > 0 {
+		attempt = submission.Attempt
+	}
+	if !submission.CreatedAt.IsZero() {
+		submittedAt = submission.CreatedAt.Format("2006-01-02 15:04:05")
+	}
+
+	row := []interface{}{
+		result.ID,
+		result.SubmissionID,
+		submission.UserID,
+		result.TestID,
+		testTitle,
+		submission.AssignmentID,
+		attempt,
+		submittedAt,
+		result.TotalScore,
+		result.MaxScore,
+		math.Round(result.Percentage*10) / 10,
+		result.LatePenalty,
+		result.ScoredBy,
+	}
+
+	questionScores := make(map[int]int)
+	criteriaScores := make(map[repositories.CriteriaColumn]int)
+	for _, detail := range result.Details {
+		questionScores[detail.QuestionNum] = detail.Score
+		for _, cs := range detail.CriteriaScores {
+			criteriaScores[repositories.CriteriaColumn{QuestionNum: detail.QuestionNum, CriteriaName: cs.CriteriaName}] = cs.Score
+		}
+	}
+	for i, c := range e.columns {
+		if i == 0 || e.columns[i-1].QuestionNum != c.QuestionNum {
+			var score interface{}
+			if s, ok := questionScores[c.QuestionNum]; ok {
+				score = s
+			}
+			row = append(row, score)
+		}
+		var score interface{}
+		if s, ok := criteriaScores[c]; ok {
+			score = s
+		}
+		row = append(row, score)
+	}
+	if e.includeFeedback {
+		row = append(row, result.Feedback)
+	}
+	return row
+}
+
+type csvRowWriter struct {
+	w *csv.Writer
+}
+
+func (c *csvRowWriter) WriteRow(values []interface{}) error {
+	record := make([]string, len(values))
+	for i, v := range values {
+		switch v := v.(type) {
+		case nil:
+		case string:
+			record[i] = escapeFormula(v)
+		default:
+			record[i] = fmt.Sprint(v)
+		}
+	}
+	return c.w.Write(record)
+}
+
+// escapeFormula prefixes text that a spreadsheet would evaluate as a formula with an apostrophe,
+// so that answers and feedback written by users open as plain text
+func escapeFormula(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
+
+func (c *csvRowWriter) Flush() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+func (c *csvRowWriter) Close() error {
+	return c.Flush()
+}
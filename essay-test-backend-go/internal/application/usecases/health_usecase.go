@@ -0,0 +1,104 @@
+package usecases
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"essay-test-backend/internal/application/dto"
+	"essay-test-backend/internal/domain/services"
+	"essay-test-backend/pkg/config"
+	"essay-test-backend/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+// HealthUsecase checks the dependencies the service needs to handle requests
+type HealthUsecase struct {
+	checks  map[string]services.HealthChecker
+	timeout time.Duration
+	logger  *zap.Logger
+}
+
+func NewHealthUsecase(
+	database services.HealthChecker,
+	migrations services.HealthChecker,
+	scoringService services.ScoringService,
+	scoringQueue *ScoringQueue,
+	cfg config.HealthConfig,
+	logger *zap.Logger,
+) *HealthUsecase {
+	checks := map[string]services.HealthChecker{
+		"database":        database,
+		"migrations":      migrations,
+		"scoring_workers": scoringQueue,
+	}
+	// 外部に依存しない採点器は常に利用可能とみなす
+	if scorer, ok := scoringService.(services.HealthChecker); ok {
+		checks["scorer"] = scorer
+	} else {
+		checks["scorer"] = alwaysHealthy{}
+	}
+
+	timeout := cfg.CheckTimeout
+	if timeout <= 0 {
+		timeout = 2 * time.Second
+	}
+	return &HealthUsecase{
+		checks:  checks,
+		timeout: timeout,
+		logger:  logger,
+	}
+}
+
+// Readiness runs every check concurrently, each within the configured timeout
+func (u *HealthUsecase) Readiness(ctx context.Context) *dto.ReadinessResponse {
+	response := &dto.ReadinessResponse{
+		Status:     "ready",
+		Components: make(map[string]dto.ComponentHealth, len(u.checks)),
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for name, check := range u.checks {
+		wg.Add(1)
+		go func(name string, check services.HealthChecker) {
+			defer wg.Done()
+			component := u.run(ctx, check)
+
+			mu.Lock()
+			defer mu.Unlock()
+			response.Components[name] = component
+			if component.Status != "ok" {
+				response.Status = "not_ready"
+				logger.FromContext(ctx, u.logger).Warn("依存サービスが利用できません",
+					zap.String("component", name),
+					zap.String("error", component.Error))
+			}
+		}(name, check)
+	}
+	wg.Wait()
+
+	return response
+}
+
+func (u *HealthUsecase) run(ctx context.Context, check services.HealthChecker) dto.ComponentHealth {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := check.CheckHealth(ctx)
+	component := dto.ComponentHealth{
+		Status:     "ok",
+		DurationMs: time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		component.Status = "error"
+		component.Error = err.Error()
+	}
+	return component
+}
+
+type alwaysHealthy struct{}
+
+func (alwaysHealthy) CheckHealth(context.Context) error { return nil }
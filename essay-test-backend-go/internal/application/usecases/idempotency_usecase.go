@@ -0,0 +1,143 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"essay-test-backend/internal/domain/entities"
+	"essay-test-backend/internal/domain/repositories"
+	"essay-test-backend/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// 再送を受け付ける期間
+	idempotencyTTL = 24 * time.Hour
+	// 応答が記録されないまま残った受付は、処理していたプロセスが停止したものとみなして引き継ぐ
+	idempotencyLockTimeout = 5 * time.Minute
+	// 期限切れの記録を削除する間隔
+	idempotencyPruneInterval = time.Hour
+)
+
+// IdempotencyUsecase keeps the response to a request sent with an Idempotency-Key so that retries get it again instead of repeating the request
+type IdempotencyUsecase struct {
+	repo   repositories.IdempotencyRepository
+	logger *zap.Logger
+}
+
+func NewIdempotencyUsecase(repo repositories.IdempotencyRepository, logger *zap.Logger) *IdempotencyUsecase {
+	return &IdempotencyUsecase{
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+// Begin claims the owner's key for a request with the given fingerprint. When the key already holds the response
+// to the same request, it returns that record with replay set so the caller can send the response again.
+func (u *IdempotencyUsecase) Begin(ctx context.Context, owner, key, fingerprint string) (*entities.IdempotencyRecord, bool, error) {
+	// 期限切れの受付を削除した後、もう一度だけ取り直す
+	for attempt := 0; attempt < 2; attempt++ {
+		now := time.Now()
+		record := &entities.IdempotencyRecord{
+			UserID:      owner,
+			Key:         key,
+			Fingerprint: fingerprint,
+			ExpiresAt:   now.Add(idempotencyTTL),
+		}
+		claimed, err := u.repo.Claim(ctx, record)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to claim idempotency key: %w", err)
+		}
+		if claimed {
+			return record, false, nil
+		}
+
+		existing, err := u.repo.Get(ctx, owner, key)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to get idempotency record: %w", err)
+		}
+		if existing == nil {
+			continue
+		}
+
+		abandoned := existing.CompletedAt == nil && now.Sub(existing.CreatedAt) > idempotencyLockTimeout
+		switch {
+		case now.After(existing.ExpiresAt), abandoned && existing.Fingerprint == fingerprint:
+			if err := u.repo.Delete(ctx, existing.ID); err != nil {
+				return nil, false, fmt.Errorf("failed to delete idempotency record: %w", err)
+			}
+		case existing.Fingerprint != fingerprint:
+			return nil, false, fmt.Errorf("idempotency key reused")
+		case existing.CompletedAt == nil:
+			return nil, false, fmt.Errorf("request in progress")
+		default:
+			logger.FromContext(ctx, u.logger).Info("保存済みの応答を再送",
+				zap.String("idempotency_key", key),
+				zap.Int("status", existing.StatusCode))
+			return existing, true, nil
+		}
+	}
+	return nil, false, fmt.Errorf("request in progress")
+}
+
+// Complete stores the response of a claimed request. Server errors are not stored, so the client can retry them.
+func (u *IdempotencyUsecase) Complete(ctx context.Context, record *entities.IdempotencyRecord, status int, contentType string, body []byte) error {
+	if status >= 500 {
+		return u.Release(ctx, record)
+	}
+
+	now := time.Now()
+	record.StatusCode = status
+	record.ContentType = contentType
+	record.Response = string(body)
+	record.CompletedAt = &now
+	if err := u.repo.Update(ctx, record); err != nil {
+		return fmt.Errorf("failed to save idempotent response: %w", err)
+	}
+	return nil
+}
+
+// Release gives up the claim of a request that did not finish, so a retry runs it again
+func (u *IdempotencyUsecase) Release(ctx context.Context, record *entities.IdempotencyRecord) error {
+	if err := u.repo.Delete(ctx, record.ID); err != nil {
+		return fmt.Errorf("failed to delete idempotency record: %w", err)
+	}
+	return nil
+}
+
+// PruneExpired deletes the records whose retry window has passed
+func (u *IdempotencyUsecase) PruneExpired(ctx context.Context) error {
+	deleted, err := u.repo.DeleteExpired(ctx, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to delete expired idempotency records: %w", err)
+	}
+	if deleted > 0 {
+		logger.FromContext(ctx, u.logger).Info("期限切れの再送記録を削除", zap.Int64("count", deleted))
+	}
+	return nil
+}
+
+// StartPruning deletes the expired records now and then periodically until ctx is done
+func (u *IdempotencyUsecase) StartPruning(ctx context.Context) {
+	prune := func() {
+		if err := u.PruneExpired(ctx); err != nil && ctx.Err() == nil {
+			logger.FromContext(ctx, u.logger).Error("期限切れの再送記録の削除に失敗", zap.Error(err))
+		}
+	}
+	prune()
+
+	go func() {
+		ticker := time.NewTicker(idempotencyPruneInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				prune()
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
@@ -0,0 +1,403 @@
+package usecases
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"essay-test-backend/internal/application/dto"
+	"essay-test-backend/internal/domain/entities"
+	"essay-test-backend/internal/domain/repositories"
+	"essay-test-backend/pkg/config"
+	"essay-test-backend/pkg/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// Ingest file formats accepted by IngestSubmissions
+const (
+	IngestFormatCSV   = "csv"
+	IngestFormatJSONL = "jsonl"
+)
+
+const (
+	ingestRowCreated   = "created"
+	ingestRowDuplicate = "duplicate"
+	ingestRowError     = "error"
+)
+
+// CSVの回答列は answer_1, answer_2 ... のように問題番号を付ける
+const ingestAnswerColumnPrefix = "answer_"
+
+// JSONLの1行の最大バイト数
+const ingestMaxLineBytes = 1 << 20
+
+type IngestUsecase struct {
+	testRepo       repositories.EssayTestRepository
+	submissionRepo repositories.SubmissionRepository
+	queue          *ScoringQueue
+	maxRows        int
+	maxAnswerChars int
+	logger         *zap.Logger
+}
+
+func NewIngestUsecase(
+	testRepo repositories.EssayTestRepository,
+	submissionRepo repositories.SubmissionRepository,
+	queue *ScoringQueue,
+	cfg config.IngestConfig,
+	limits config.LimitsConfig,
+	logger *zap.Logger,
+) *IngestUsecase {
+	return &IngestUsecase{
+		testRepo:       testRepo,
+		submissionRepo: submissionRepo,
+		queue:          queue,
+		maxRows:        cfg.MaxRows,
+		maxAnswerChars: limits.MaxAnswerChars,
+		logger:         logger,
+	}
+}
+
+// ingestRow is a parsed line of a batch file; err is set when the line itself could not be read
+type ingestRow struct {
+	line   int
+	record dto.IngestRecord
+	err    string
+}
+
+// IngestSubmissions stores every valid row as a pending submission, queues it for scoring and reports each row's outcome.
+// Idempotency keys are scoped to the uploader and the test, so only the same uploader's rows for the same test count as duplicates.
+func (u *IngestUsecase) IngestSubmissions(ctx context.Context, uploaderID, format string, r io.Reader) (*dto.IngestReportResponse, error) {
+	var rows []ingestRow
+	var err error
+	switch format {
+	case IngestFormatCSV:
+		rows, err = parseIngestCSV(r)
+	case IngestFormatJSONL:
+		rows, err = parseIngestJSONL(r)
+	default:
+		return nil, fmt.Errorf("invalid ingest format")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("ingest file empty")
+	}
+	if u.maxRows > 0 && len(rows) > u.maxRows {
+		return nil, fmt.Errorf("too many rows")
+	}
+
+	report := &dto.IngestReportResponse{
+		Total: len(rows),
+		Rows:  make([]dto.IngestRowResponse, 0, len(rows)),
+	}
+	tests := make(map[string]*entities.EssayTest)
+	for _, row := range rows {
+		result := u.ingestRow(ctx, uploaderID, row, tests)
+		switch result.Status {
+		case ingestRowCreated:
+			report.Created++
+		case ingestRowDuplicate:
+			report.Duplicates++
+		default:
+			report.Failed++
+		}
+		report.Rows = append(report.Rows, result)
+	}
+
+	logger.FromContext(ctx, u.logger).Info("提出物を一括登録",
+		zap.String("format", format),
+		zap.Int("total", report.Total),
+		zap.Int("created", report.Created),
+		zap.Int("duplicates", report.Duplicates),
+		zap.Int("failed", report.Failed))
+
+	return report, nil
+}
+
+func (u *IngestUsecase) ingestRow(ctx context.Context, uploaderID string, row ingestRow, tests map[string]*entities.EssayTest) dto.IngestRowResponse {
+	result := dto.IngestRowResponse{Line: row.line, IdempotencyKey: row.record.IdempotencyKey}
+	fail := func(message string) dto.IngestRowResponse {
+		result.Status = ingestRowError
+		result.Error = message
+		return result
+	}
+	if row.err != "" {
+		return fail(row.err)
+	}
+
+	record := row.record
+	if record.UserID == "" {
+		return fail("user_idがありません")
+	}
+	if record.TestID == "" {
+		return fail("test_idがありません")
+	}
+
+	test, ok := tests[record.TestID]
+	if !ok {
+		found, err := u.testRepo.GetByID(ctx, record.TestID)
+		if err != nil {
+			logger.FromContext(ctx, u.logger).Error("テストの取得に失敗", zap.Error(err), zap.String("test_id", record.TestID))
+			return fail("テストの取得に失敗しました")
+		}
+		tests[record.TestID] = found
+		test = found
+	}
+	if test == nil {
+		return fail("テストが見つかりません")
+	}
+
+	answers, message := matchIngestAnswers(test, record.Answers)
+	if message != "" {
+		return fail(message)
+	}
+	for _, a := range answers {
+		if answerTooLong(a.Content, u.maxAnswerChars) {
+			return fail(fmt.Sprintf("回答が長すぎます（%d文字まで）", u.maxAnswerChars))
+		}
+	}
+
+	// キーのない行は内容から導出したキーを使い、同じファイルの再アップロードで重複させない
+	key := record.IdempotencyKey
+	if key == "" {
+		key = deriveIngestKey(record.TestID, record.UserID, answers)
+	}
+	if utf8.RuneCountInString(key) > 191 {
+		return fail("idempotency_keyが長すぎます")
+	}
+	result.IdempotencyKey = key
+
+	existing, err := u.submissionRepo.GetByIdempotencyKey(ctx, uploaderID, test.ID, key)
+	if err != nil {
+		logger.FromContext(ctx, u.logger).Error("提出物の確認に失敗", zap.Error(err), zap.String("idempotency_key", key))
+		return fail("提出物の確認に失敗しました")
+	}
+	if existing != nil {
+		result.Status = ingestRowDuplicate
+		result.SubmissionID = existing.ID
+		return result
+	}
+
+	submission := &entities.Submission{
+		ID:             uuid.New().String(),
+		TestID:         test.ID,
+		UserID:         record.UserID,
+		Status:         "pending",
+		IngestedBy:     uploaderID,
+		IdempotencyKey: &key,
+	}
+	for _, a := range answers {
+		submission.Answers = append(submission.Answers, entities.Answer{
+			ID:           uuid.New().String(),
+			SubmissionID: submission.ID,
+			QuestionID:   a.QuestionID,
+			Content:      a.Content,
+			WordCount:    utf8.RuneCountInString(a.Content),
+		})
+	}
+	if err := u.submissionRepo.Create(ctx, submission); err != nil {
+		// 同じキーの行が同時に登録された場合は、先に保存された提出物を重複として返す
+		if errors.Is(err, repositories.ErrDuplicate) {
+			existing, err := u.submissionRepo.GetByIdempotencyKey(ctx, uploaderID, test.ID, key)
+			if err == nil && existing != nil {
+				result.Status = ingestRowDuplicate
+				result.SubmissionID = existing.ID
+				return result
+			}
+		}
+		logger.FromContext(ctx, u.logger).Error("提出データの保存に失敗", zap.Error(err), zap.Int("line", row.line))
+		return fail("提出データの保存に失敗しました")
+	}
+
+	// キューに入れられなかった提出物も採点待ちとして残り、次回の起動時に採点される
+	if err := u.queue.Enqueue(ctx, submission.ID); err != nil {
+		logger.FromContext(ctx, u.logger).Warn("採点キューへの登録に失敗", zap.Error(err), zap.String("submission_id", submission.ID))
+	}
+
+	result.Status = ingestRowCreated
+	result.SubmissionID = submission.ID
+	return result
+}
+
+// matchIngestAnswers resolves each answer to a question of the test and returns them in question order
+func matchIngestAnswers(test *entities.EssayTest, answers []dto.IngestAnswer) ([]dto.IngestAnswer, string) {
+	byQuestion := make(map[string]dto.IngestAnswer)
+	for _, a := range answers {
+		var question *entities.Question
+		if a.QuestionID != "" {
+			question = findQuestion(test, a.QuestionID)
+		} else {
+			for i := range test.Questions {
+				if test.Questions[i].Number == a.QuestionNum {
+					question = &test.Questions[i]
+				}
+			}
+		}
+		if question == nil {
+			if a.QuestionID != "" {
+				return nil, fmt.Sprintf("問題が見つかりません（%s）", a.QuestionID)
+			}
+			return nil, fmt.Sprintf("問題が見つかりません（問%d）", a.QuestionNum)
+		}
+		if _, ok := byQuestion[question.ID]; ok {
+			return nil, fmt.Sprintf("問%dの回答が重複しています", question.Number)
+		}
+		a.QuestionID = question.ID
+		a.QuestionNum = question.Number
+		byQuestion[question.ID] = a
+	}
+
+	matched := make([]dto.IngestAnswer, 0, len(test.Questions))
+	for _, q := range test.Questions {
+		a, ok := byQuestion[q.ID]
+		if !ok || strings.TrimSpace(a.Content) == "" {
+			return nil, fmt.Sprintf("問%dの回答がありません", q.Number)
+		}
+		matched = append(matched, a)
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		return matched[i].QuestionNum < matched[j].QuestionNum
+	})
+	return matched, ""
+}
+
+func deriveIngestKey(testID, userID string, answers []dto.IngestAnswer) string {
+	h := sha256.New()
+	h.Write([]byte(testID + "\x00" + userID))
+	for _, a := range answers {
+		h.Write([]byte("\x00" + a.QuestionID + "\x00" + a.Content))
+	}
+	return "sha256:" + hex.EncodeToString(h.Sum(nil))
+}
+
+// parseIngestCSV reads a CSV with a header of idempotency_key, user_id, test_id and answer_N columns
+func parseIngestCSV(r io.Reader) ([]ingestRow, error) {
+	reader := csv.NewReader(r)
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid ingest file")
+	}
+
+	columns := make(map[string]int)
+	answerColumns := make(map[int]int)
+	for i, name := range header {
+		name = strings.TrimSpace(name)
+		// Excelで保存したCSVの先頭に付くBOMを取り除く
+		if i == 0 {
+			name = strings.TrimPrefix(name, "\ufeff")
+		}
+		if num, ok := strings.CutPrefix(name, ingestAnswerColumnPrefix); ok {
+			n, err := strconv.Atoi(num)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("invalid ingest file")
+			}
+			answerColumns[n] = i
+			continue
+		}
+		columns[name] = i
+	}
+	if _, ok := columns["user_id"]; !ok {
+		return nil, fmt.Errorf("invalid ingest file")
+	}
+	if _, ok := columns["test_id"]; !ok {
+		return nil, fmt.Errorf("invalid ingest file")
+	}
+	if len(answerColumns) == 0 {
+		return nil, fmt.Errorf("invalid ingest file")
+	}
+	nums := make([]int, 0, len(answerColumns))
+	for n := range answerColumns {
+		nums = append(nums, n)
+	}
+	sort.Ints(nums)
+
+	field := func(record []string, name string) string {
+		if i, ok := columns[name]; ok {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	var rows []ingestRow
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			// 列数の誤りはその行だけを失敗にし、引用符の崩れなど以降を読めない誤りは全体を失敗にする
+			if !errors.Is(parseErr.Err, csv.ErrFieldCount) {
+				return nil, fmt.Errorf("invalid ingest file")
+			}
+			rows = append(rows, ingestRow{line: parseErr.StartLine, err: "列数がヘッダーと一致しません"})
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("invalid ingest file")
+		}
+		line, _ := reader.FieldPos(0)
+
+		row := ingestRow{
+			line: line,
+			record: dto.IngestRecord{
+				IdempotencyKey: field(record, "idempotency_key"),
+				UserID:         field(record, "user_id"),
+				TestID:         field(record, "test_id"),
+			},
+		}
+		for _, n := range nums {
+			row.record.Answers = append(row.record.Answers, dto.IngestAnswer{
+				QuestionNum: n,
+				Content:     record[answerColumns[n]],
+			})
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// parseIngestJSONL reads one IngestRecord per line; blank lines are skipped
+func parseIngestJSONL(r io.Reader) ([]ingestRow, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), ingestMaxLineBytes)
+
+	var rows []ingestRow
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if line == 1 {
+			text = strings.TrimPrefix(text, "\ufeff")
+		}
+		if text == "" {
+			continue
+		}
+
+		row := ingestRow{line: line}
+		if err := json.Unmarshal([]byte(text), &row.record); err != nil {
+			row.err = "JSONとして読み取れません"
+		}
+		rows = append(rows, row)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("invalid ingest file")
+	}
+	return rows, nil
+}
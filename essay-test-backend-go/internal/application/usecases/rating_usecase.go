@@ -0,0 +1,692 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"essay-test-backend/internal/application/dto"
+	"essay-test-backend/internal/domain/entities"
+	"essay-test-backend/internal/domain/repositories"
+	"essay-test-backend/internal/domain/services"
+	"essay-test-backend/pkg/logger"
+	"essay-test-backend/pkg/stats"
+
+	"go.uber.org/zap"
+)
+
+// 採点者間でこの点数差を超えた採点基準は裁定に回す
+const defaultDiscrepancyThreshold = 2
+
+type RatingUsecase struct {
+	submissionRepo repositories.SubmissionRepository
+	resultRepo     repositories.ScoringResultRepository
+	ratingRepo     repositories.RatingRepository
+	reviewRepo     repositories.ScoreReviewRepository
+	feedback       services.FeedbackGenerator
+	listeners      []services.ScoreChangeListener
+	logger         *zap.Logger
+}
+
+func NewRatingUsecase(
+	submissionRepo repositories.SubmissionRepository,
+	resultRepo repositories.ScoringResultRepository,
+	ratingRepo repositories.RatingRepository,
+	reviewRepo repositories.ScoreReviewRepository,
+	feedback services.FeedbackGenerator,
+	logger *zap.Logger,
+	listeners ...services.ScoreChangeListener,
+) *RatingUsecase {
+	return &RatingUsecase{
+		submissionRepo: submissionRepo,
+		resultRepo:     resultRepo,
+		ratingRepo:     ratingRepo,
+		reviewRepo:     reviewRepo,
+		feedback:       feedback,
+		listeners:      listeners,
+		logger:         logger,
+	}
+}
+
+// AssignRaters starts a double-blind rating session for a submission
+func (u *RatingUsecase) AssignRaters(ctx context.Context, submissionID, createdBy string, req dto.AssignRatersRequest) (*dto.RatingSessionResponse, error) {
+	logger.FromContext(ctx, u.logger).Info("採点者を割り当て中",
+		zap.String("submission_id", submissionID),
+		zap.Strings("rater_ids", req.RaterIDs))
+
+	raterIDs := uniqueStrings(req.RaterIDs)
+	if len(raterIDs) < 2 {
+		return nil, fmt.Errorf("at least two raters required")
+	}
+
+	submission, err := u.submissionRepo.GetByID(ctx, submissionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get submission: %w", err)
+	}
+	if submission == nil {
+		return nil, fmt.Errorf("submission not found")
+	}
+
+	result, err := u.resultRepo.GetBySubmissionID(ctx, submissionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get result: %w", err)
+	}
+	if result == nil {
+		return nil, fmt.Errorf("result not found")
+	}
+
+	existing, err := u.ratingRepo.GetSessionBySubmissionID(ctx, submissionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rating session: %w", err)
+	}
+	if existing != nil {
+		return nil, fmt.Errorf("rating already exists")
+	}
+
+	threshold := req.Threshold
+	if threshold <= 0 {
+		threshold = defaultDiscrepancyThreshold
+	}
+
+	session := &entities.RatingSession{
+		SubmissionID: submission.ID,
+		TestID:       submission.TestID,
+		ResultID:     result.ID,
+		Threshold:    threshold,
+		Status:       entities.RatingStatusInProgress,
+		CreatedBy:    createdBy,
+	}
+	var assignments []entities.RaterAssignment
+	for _, raterID := range raterIDs {
+		assignments = append(assignments, entities.RaterAssignment{
+			SubmissionID: submission.ID,
+			TestID:       submission.TestID,
+			RaterID:      raterID,
+			Role:         entities.RaterRoleRater,
+			Status:       entities.AssignmentStatusAssigned,
+		})
+	}
+
+	if err := u.ratingRepo.CreateSession(ctx, session, assignments); err != nil {
+		return nil, fmt.Errorf("failed to create rating session: %w", err)
+	}
+
+	return convertRatingSessionToDTO(session, assignments), nil
+}
+
+// AssignAdjudicator adds a third teacher to settle criteria on which the raters disagree
+func (u *RatingUsecase) AssignAdjudicator(ctx context.Context, submissionID string, req dto.AssignAdjudicatorRequest) (*dto.RatingSessionResponse, error) {
+	session, err := u.ratingRepo.GetSessionBySubmissionID(ctx, submissionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rating session: %w", err)
+	}
+	if session == nil {
+		return nil, fmt.Errorf("rating not found")
+	}
+	if session.Status != entities.RatingStatusNeedsAdjudication {
+		return nil, fmt.Errorf("adjudication not required")
+	}
+
+	assignments, err := u.ratingRepo.GetAssignmentsBySessionID(ctx, session.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assignments: %w", err)
+	}
+	for _, a := range assignments {
+		if a.RaterID == req.RaterID {
+			return nil, fmt.Errorf("adjudicator must differ from raters")
+		}
+		if a.Role == entities.RaterRoleAdjudicator {
+			return nil, fmt.Errorf("adjudicator already assigned")
+		}
+	}
+
+	adjudicator := entities.RaterAssignment{
+		SessionID:    session.ID,
+		SubmissionID: session.SubmissionID,
+		TestID:       session.TestID,
+		RaterID:      req.RaterID,
+		Role:         entities.RaterRoleAdjudicator,
+		Status:       entities.AssignmentStatusAssigned,
+	}
+	if err := u.ratingRepo.CreateAssignment(ctx, &adjudicator); err != nil {
+		return nil, fmt.Errorf("failed to create assignment: %w", err)
+	}
+
+	logger.FromContext(ctx, u.logger).Info("裁定者を割り当て",
+		zap.String("submission_id", submissionID),
+		zap.String("rater_id", req.RaterID))
+
+	return convertRatingSessionToDTO(session, append(assignments, adjudicator)), nil
+}
+
+// GetSession returns a rating session with every rater's scores for coordinators
+func (u *RatingUsecase) GetSession(ctx context.Context, submissionID string) (*dto.RatingSessionResponse, error) {
+	session, err := u.ratingRepo.GetSessionBySubmissionID(ctx, submissionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rating session: %w", err)
+	}
+	if session == nil {
+		return nil, fmt.Errorf("rating not found")
+	}
+
+	assignments, err := u.ratingRepo.GetAssignmentsBySessionID(ctx, session.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assignments: %w", err)
+	}
+	return convertRatingSessionToDTO(session, assignments), nil
+}
+
+// ListAssignments returns the rater's own assignments
+func (u *RatingUsecase) ListAssignments(ctx context.Context, raterID string) ([]dto.RaterAssignmentResponse, error) {
+	assignments, err := u.ratingRepo.GetAssignmentsByRaterID(ctx, raterID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assignments: %w", err)
+	}
+
+	response := make([]dto.RaterAssignmentResponse, 0, len(assignments))
+	for _, a := range assignments {
+		response = append(response, convertRaterAssignmentToDTO(a))
+	}
+	return response, nil
+}
+
+// GetTask returns the answers and rubric for an assignment, hiding the automated and other raters' scores
+func (u *RatingUsecase) GetTask(ctx context.Context, assignmentID, raterID string) (*dto.RatingTaskResponse, error) {
+	assignment, session, err := u.loadOwnAssignment(ctx, assignmentID, raterID)
+	if err != nil {
+		return nil, err
+	}
+
+	submission, err := u.submissionRepo.GetByID(ctx, assignment.SubmissionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get submission: %w", err)
+	}
+	if submission == nil {
+		return nil, fmt.Errorf("submission not found")
+	}
+
+	result, err := u.resultRepo.GetByID(ctx, session.ResultID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get result: %w", err)
+	}
+	if result == nil {
+		return nil, fmt.Errorf("result not found")
+	}
+
+	disputed := make(map[string]bool)
+	for _, id := range session.Discrepancies {
+		disputed[id] = true
+	}
+
+	response := &dto.RatingTaskResponse{
+		Assignment: convertRaterAssignmentToDTO(*assignment),
+		Answers:    convertAnswersToDTO(submission.Answers),
+	}
+	for _, detail := range result.Details {
+		for _, cs := range detail.CriteriaScores {
+			response.Criteria = append(response.Criteria, dto.RatingCriterionResponse{
+				CriteriaScoreID: cs.ID,
+				QuestionNum:     detail.QuestionNum,
+				CriteriaName:    cs.CriteriaName,
+				MaxScore:        cs.MaxScore,
+				Disputed:        disputed[cs.ID],
+			})
+		}
+	}
+
+	// 裁定者には採点者の点数を開示する
+	if assignment.Role == entities.RaterRoleAdjudicator {
+		others, err := u.ratingRepo.GetAssignmentsBySessionID(ctx, session.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get assignments: %w", err)
+		}
+		for _, a := range others {
+			if a.Role == entities.RaterRoleRater {
+				response.OtherScores = append(response.OtherScores, convertRaterAssignmentToDTO(a))
+			}
+		}
+	}
+
+	return response, nil
+}
+
+// SubmitScores records a rater's scores and finalizes the session once every rater has submitted
+func (u *RatingUsecase) SubmitScores(ctx context.Context, assignmentID, raterID string, req dto.RaterScoresRequest) (*dto.RaterAssignmentResponse, error) {
+	logger.FromContext(ctx, u.logger).Info("採点者の採点を提出中",
+		zap.String("assignment_id", assignmentID),
+		zap.String("rater_id", raterID),
+		zap.Int("scores", len(req.Scores)))
+
+	assignment, session, err := u.loadOwnAssignment(ctx, assignmentID, raterID)
+	if err != nil {
+		return nil, err
+	}
+	if assignment.Status == entities.AssignmentStatusSubmitted {
+		return nil, fmt.Errorf("assignment already submitted")
+	}
+	if session.Status == entities.RatingStatusFinalized {
+		return nil, fmt.Errorf("rating already finalized")
+	}
+
+	result, err := u.resultRepo.GetByID(ctx, session.ResultID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get result: %w", err)
+	}
+	if result == nil {
+		return nil, fmt.Errorf("result not found")
+	}
+	if !result.IsCurrent {
+		return nil, fmt.Errorf("result superseded")
+	}
+
+	criteria := make(map[string]entities.CriteriaScore)
+	for _, detail := range result.Details {
+		for _, cs := range detail.CriteriaScores {
+			criteria[cs.ID] = cs
+		}
+	}
+
+	submitted := make(map[string]bool)
+	var scores []entities.RaterCriteriaScore
+	for _, s := range req.Scores {
+		cs, ok := criteria[s.CriteriaScoreID]
+		if !ok {
+			return nil, fmt.Errorf("invalid criteria score")
+		}
+		if s.Score < 0 || s.Score > cs.MaxScore {
+			return nil, fmt.Errorf("score out of range")
+		}
+		submitted[s.CriteriaScoreID] = true
+		scores = append(scores, entities.RaterCriteriaScore{
+			CriteriaScoreID: s.CriteriaScoreID,
+			Score:           s.Score,
+			Comment:         s.Comment,
+		})
+	}
+
+	// 採点者は全基準、裁定者は不一致の基準のみ必須
+	required := session.Discrepancies
+	if assignment.Role == entities.RaterRoleRater {
+		required = nil
+		for id := range criteria {
+			required = append(required, id)
+		}
+	}
+	for _, id := range required {
+		if !submitted[id] {
+			return nil, fmt.Errorf("missing criteria scores")
+		}
+	}
+
+	now := time.Now()
+	assignment.Scores = scores
+	assignment.Status = entities.AssignmentStatusSubmitted
+	assignment.SubmittedAt = &now
+	if err := u.ratingRepo.UpdateAssignment(ctx, assignment); err != nil {
+		return nil, fmt.Errorf("failed to update assignment: %w", err)
+	}
+
+	if err := u.evaluate(ctx, session, result, raterID); err != nil {
+		return nil, err
+	}
+
+	response := convertRaterAssignmentToDTO(*assignment)
+	return &response, nil
+}
+
+// GetAgreement computes inter-rater and human/automated agreement for a test
+func (u *RatingUsecase) GetAgreement(ctx context.Context, testID string) (*dto.AgreementResponse, error) {
+	sessions, err := u.ratingRepo.GetSessionsByTestID(ctx, testID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rating sessions: %w", err)
+	}
+
+	type ratingPairs struct {
+		questionNum  int
+		criteriaName string
+		maxScore     int
+		first        []int
+		second       []int
+		human        []int
+		auto         []int
+	}
+	pairs := make(map[string]*ratingPairs)
+	var keys []string
+	count := 0
+
+	for _, session := range sessions {
+		assignments, err := u.ratingRepo.GetAssignmentsBySessionID(ctx, session.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get assignments: %w", err)
+		}
+
+		var raters []entities.RaterAssignment
+		for _, a := range assignments {
+			if a.Role == entities.RaterRoleRater && a.Status == entities.AssignmentStatusSubmitted {
+				raters = append(raters, a)
+			}
+		}
+		if len(raters) < 2 {
+			continue
+		}
+
+		result, err := u.resultRepo.GetByID(ctx, session.ResultID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get result: %w", err)
+		}
+		if result == nil {
+			continue
+		}
+		count++
+
+		// 3人以上の採点者は全ての組み合わせを1組ずつ数え、人の点数は全員の平均とする
+		scoreMaps := make([]map[string]int, len(raters))
+		for i, r := range raters {
+			scoreMaps[i] = raterScoreMap(r)
+		}
+		for _, detail := range result.Details {
+			for _, cs := range detail.CriteriaScores {
+				var scores []int
+				for _, m := range scoreMaps {
+					if score, ok := m[cs.ID]; ok {
+						scores = append(scores, score)
+					}
+				}
+				if len(scores) < 2 {
+					continue
+				}
+
+				key := fmt.Sprintf("%d|%s", detail.QuestionNum, cs.CriteriaName)
+				p, ok := pairs[key]
+				if !ok {
+					p = &ratingPairs{questionNum: detail.QuestionNum, criteriaName: cs.CriteriaName, maxScore: cs.MaxScore}
+					pairs[key] = p
+					keys = append(keys, key)
+				}
+				sum := 0
+				for i, a := range scores {
+					sum += a
+					for _, b := range scores[i+1:] {
+						p.first = append(p.first, a)
+						p.second = append(p.second, b)
+					}
+				}
+				p.human = append(p.human, int(math.Round(float64(sum)/float64(len(scores)))))
+				p.auto = append(p.auto, cs.AutoScore)
+			}
+		}
+	}
+
+	response := &dto.AgreementResponse{TestID: testID, Sessions: count}
+	var allFirst, allSecond, allHuman, allAuto []int
+	var raterKappa, autoKappa float64
+	for _, key := range keys {
+		p := pairs[key]
+		criterion := dto.CriterionAgreementResponse{
+			QuestionNum:        p.questionNum,
+			CriteriaName:       p.criteriaName,
+			MaxScore:           p.maxScore,
+			RaterAgreement:     agreementStats(p.first, p.second, p.maxScore),
+			HumanAutoAgreement: agreementStats(p.human, p.auto, p.maxScore),
+		}
+		response.Criteria = append(response.Criteria, criterion)
+
+		allFirst = append(allFirst, p.first...)
+		allSecond = append(allSecond, p.second...)
+		allHuman = append(allHuman, p.human...)
+		allAuto = append(allAuto, p.auto...)
+		raterKappa += criterion.RaterAgreement.QuadraticWeightedKappa * float64(len(p.first))
+		autoKappa += criterion.HumanAutoAgreement.QuadraticWeightedKappa * float64(len(p.human))
+	}
+
+	// テスト全体は一致率を全ペアで集計し、κは基準ごとの値をペア数で加重平均する
+	if n := len(allFirst); n > 0 {
+		response.Overall = dto.AgreementSummary{
+			RaterAgreement: dto.AgreementStats{
+				N:                      n,
+				ExactAgreement:         stats.ExactAgreement(allFirst, allSecond),
+				AdjacentAgreement:      stats.AdjacentAgreement(allFirst, allSecond, 1),
+				QuadraticWeightedKappa: raterKappa / float64(n),
+			},
+			HumanAutoAgreement: dto.AgreementStats{
+				N:                      len(allHuman),
+				ExactAgreement:         stats.ExactAgreement(allHuman, allAuto),
+				AdjacentAgreement:      stats.AdjacentAgreement(allHuman, allAuto, 1),
+				QuadraticWeightedKappa: autoKappa / float64(len(allHuman)),
+			},
+		}
+	}
+
+	return response, nil
+}
+
+func (u *RatingUsecase) loadOwnAssignment(ctx context.Context, assignmentID, raterID string) (*entities.RaterAssignment, *entities.RatingSession, error) {
+	assignment, err := u.ratingRepo.GetAssignmentByID(ctx, assignmentID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get assignment: %w", err)
+	}
+	// 他の採点者の割り当ては存在自体を見せない
+	if assignment == nil || assignment.RaterID != raterID {
+		return nil, nil, fmt.Errorf("assignment not found")
+	}
+
+	session, err := u.ratingRepo.GetSessionBySubmissionID(ctx, assignment.SubmissionID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get rating session: %w", err)
+	}
+	if session == nil {
+		return nil, nil, fmt.Errorf("rating not found")
+	}
+	return assignment, session, nil
+}
+
+// evaluate finalizes the session when the raters agree or every disagreement has been adjudicated
+func (u *RatingUsecase) evaluate(ctx context.Context, session *entities.RatingSession, result *entities.ScoringResult, actorID string) error {
+	assignments, err := u.ratingRepo.GetAssignmentsBySessionID(ctx, session.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get assignments: %w", err)
+	}
+
+	raterScores := make(map[string][]int)
+	adjudicated := make(map[string]int)
+	for _, a := range assignments {
+		if a.Status != entities.AssignmentStatusSubmitted {
+			if a.Role == entities.RaterRoleRater {
+				return nil // 全員の採点が揃うまで待つ
+			}
+			continue
+		}
+		for _, s := range a.Scores {
+			if a.Role == entities.RaterRoleAdjudicator {
+				adjudicated[s.CriteriaScoreID] = s.Score
+			} else {
+				raterScores[s.CriteriaScoreID] = append(raterScores[s.CriteriaScoreID], s.Score)
+			}
+		}
+	}
+
+	var discrepancies, unresolved []string
+	for _, detail := range result.Details {
+		for _, cs := range detail.CriteriaScores {
+			scores := raterScores[cs.ID]
+			if len(scores) == 0 {
+				continue
+			}
+			lo, hi := scores[0], scores[0]
+			for _, s := range scores {
+				lo, hi = min(lo, s), max(hi, s)
+			}
+			if hi-lo > session.Threshold {
+				discrepancies = append(discrepancies, cs.ID)
+				if _, ok := adjudicated[cs.ID]; !ok {
+					unresolved = append(unresolved, cs.ID)
+				}
+			}
+		}
+	}
+
+	if len(unresolved) > 0 {
+		session.Status = entities.RatingStatusNeedsAdjudication
+		session.Discrepancies = discrepancies
+		if err := u.ratingRepo.UpdateSession(ctx, session); err != nil {
+			return fmt.Errorf("failed to update rating session: %w", err)
+		}
+		logger.FromContext(ctx, u.logger).Info("採点者間の不一致により裁定が必要",
+			zap.String("submission_id", session.SubmissionID),
+			zap.Int("discrepancies", len(discrepancies)))
+		return nil
+	}
+
+	// 不一致の基準は裁定者の点数、それ以外は採点者の平均を最終点とする
+	review, err := u.reviewRepo.GetByResultID(ctx, result.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get review: %w", err)
+	}
+	if review == nil {
+		review = &entities.ScoreReview{
+			ResultID:     result.ID,
+			SubmissionID: result.SubmissionID,
+		}
+	}
+	review.ReviewerID = actorID
+	review.Adjustments = nil
+	review.QuestionComments = nil
+	review.Feedback = nil
+	for _, detail := range result.Details {
+		for _, cs := range detail.CriteriaScores {
+			scores := raterScores[cs.ID]
+			if len(scores) == 0 {
+				continue
+			}
+			final, ok := adjudicated[cs.ID]
+			if !ok {
+				sum := 0
+				for _, s := range scores {
+					sum += s
+				}
+				final = int(math.Round(float64(sum) / float64(len(scores))))
+			}
+			review.Adjustments = append(review.Adjustments, entities.CriteriaAdjustment{
+				CriteriaScoreID: cs.ID,
+				Score:           &final,
+			})
+		}
+	}
+
+	// 採点中に新しい版ができた結果は確定しない
+	if !result.IsCurrent {
+		return fmt.Errorf("result superseded")
+	}
+
+	// 最後の採点者たちの提出が同時に届いても公開は一度だけにするため、確定の状態を条件付きで取ってから公開する
+	previous := session.Status
+	now := time.Now()
+	session.Status = entities.RatingStatusFinalized
+	session.Discrepancies = discrepancies
+	session.FinalizedAt = &now
+	finalized, err := u.ratingRepo.FinalizeSession(ctx, session)
+	if err != nil {
+		return fmt.Errorf("failed to update rating session: %w", err)
+	}
+	if !finalized {
+		return nil
+	}
+
+	if err := publishReview(ctx, u.reviewRepo, u.feedback, result, review, actorID); err != nil {
+		session.Status = previous
+		session.FinalizedAt = nil
+		if rollbackErr := u.ratingRepo.UpdateSession(ctx, session); rollbackErr != nil {
+			logger.FromContext(ctx, u.logger).Error("採点の確定の取り消しに失敗", zap.Error(rollbackErr), zap.String("submission_id", session.SubmissionID))
+		}
+		return err
+	}
+	notifyScoresChanged(ctx, u.listeners, result)
+
+	logger.FromContext(ctx, u.logger).Info("複数採点者による採点を確定",
+		zap.String("submission_id", session.SubmissionID),
+		zap.Int("total_score", result.TotalScore))
+	return nil
+}
+
+// ensureRatingClosed rejects access to a submission's scores while several raters are grading it blind,
+// so the interim and adjudicated scores stay hidden until the rating is finalized
+func ensureRatingClosed(ctx context.Context, ratingRepo repositories.RatingRepository, submissionID string) error {
+	session, err := ratingRepo.GetSessionBySubmissionID(ctx, submissionID)
+	if err != nil {
+		return fmt.Errorf("failed to get rating session: %w", err)
+	}
+	if session != nil && session.Status != entities.RatingStatusFinalized {
+		return fmt.Errorf("rating in progress")
+	}
+	return nil
+}
+
+func agreementStats(a, b []int, maxScore int) dto.AgreementStats {
+	return dto.AgreementStats{
+		N:                      len(a),
+		ExactAgreement:         stats.ExactAgreement(a, b),
+		AdjacentAgreement:      stats.AdjacentAgreement(a, b, 1),
+		QuadraticWeightedKappa: stats.QuadraticWeightedKappa(a, b, 0, maxScore),
+	}
+}
+
+func raterScoreMap(a entities.RaterAssignment) map[string]int {
+	scores := make(map[string]int, len(a.Scores))
+	for _, s := range a.Scores {
+		scores[s.CriteriaScoreID] = s.Score
+	}
+	return scores
+}
+
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]bool)
+	var result []string
+	for _, v := range values {
+		if v == "" || seen[v] {
+			continue
+		}
+		seen[v] = true
+		result = append(result, v)
+	}
+	return result
+}
+
+func convertRatingSessionToDTO(session *entities.RatingSession, assignments []entities.RaterAssignment) *dto.RatingSessionResponse {
+	response := &dto.RatingSessionResponse{
+		ID:            session.ID,
+		SubmissionID:  session.SubmissionID,
+		TestID:        session.TestID,
+		ResultID:      session.ResultID,
+		Threshold:     session.Threshold,
+		Status:        session.Status,
+		Discrepancies: session.Discrepancies,
+		FinalizedAt:   session.FinalizedAt,
+		CreatedAt:     session.CreatedAt,
+	}
+	for _, a := range assignments {
+		response.Assignments = append(response.Assignments, convertRaterAssignmentToDTO(a))
+	}
+	return response
+}
+
+func convertRaterAssignmentToDTO(a entities.RaterAssignment) dto.RaterAssignmentResponse {
+	response := dto.RaterAssignmentResponse{
+		ID:           a.ID,
+		SubmissionID: a.SubmissionID,
+		TestID:       a.TestID,
+		RaterID:      a.RaterID,
+		Role:         a.Role,
+		Status:       a.Status,
+		SubmittedAt:  a.SubmittedAt,
+		CreatedAt:    a.CreatedAt,
+	}
+	for _, s := range a.Scores {
+		response.Scores = append(response.Scores, dto.RaterScoreRequest{
+			CriteriaScoreID: s.CriteriaScoreID,
+			Score:           s.Score,
+			Comment:         s.Comment,
+		})
+	}
+	return response
+}
@@ -0,0 +1,93 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"essay-test-backend/internal/domain/repositories"
+	"essay-test-backend/internal/domain/services"
+	"essay-test-backend/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+type ReportUsecase struct {
+	testRepo       repositories.EssayTestRepository
+	submissionRepo repositories.SubmissionRepository
+	resultRepo     repositories.ScoringResultRepository
+	ratingRepo     repositories.RatingRepository
+	renderer       services.ReportRenderer
+	logger         *zap.Logger
+}
+
+func NewReportUsecase(
+	testRepo repositories.EssayTestRepository,
+	submissionRepo repositories.SubmissionRepository,
+	resultRepo repositories.ScoringResultRepository,
+	ratingRepo repositories.RatingRepository,
+	renderer services.ReportRenderer,
+	logger *zap.Logger,
+) *ReportUsecase {
+	return &ReportUsecase{
+		testRepo:       testRepo,
+		submissionRepo: submissionRepo,
+		resultRepo:     resultRepo,
+		ratingRepo:     ratingRepo,
+		renderer:       renderer,
+		logger:         logger,
+	}
+}
+
+// RenderResultReport renders the score report of a result as a PDF and returns it with its file name
+func (u *ReportUsecase) RenderResultReport(ctx context.Context, resultID string) ([]byte, string, error) {
+	result, err := u.resultRepo.GetByID(ctx, resultID)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get result: %w", err)
+	}
+	if result == nil {
+		return nil, "", fmt.Errorf("result not found")
+	}
+	if err := ensureRatingClosed(ctx, u.ratingRepo, result.SubmissionID); err != nil {
+		return nil, "", err
+	}
+
+	test, err := u.testRepo.GetByID(ctx, result.TestID)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get test: %w", err)
+	}
+	if test == nil {
+		return nil, "", fmt.Errorf("test not found")
+	}
+
+	submission, err := u.submissionRepo.GetByID(ctx, result.SubmissionID)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get submission: %w", err)
+	}
+
+	higher, takers, err := u.resultRepo.CountRank(ctx, result.TestID, result.TotalScore)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to count rank: %w", err)
+	}
+
+	report := &services.ResultReport{
+		Result:     result,
+		Test:       test,
+		Submission: submission,
+		Rank:       higher + 1,
+		Takers:     takers,
+	}
+	if takers > 0 {
+		report.Percentile = float64(takers-higher) / float64(takers) * 100
+	}
+
+	pdf, err := u.renderer.RenderResultReport(ctx, report)
+	if err != nil {
+		return nil, "", err
+	}
+
+	logger.FromContext(ctx, u.logger).Info("採点レポートを生成",
+		zap.String("result_id", resultID),
+		zap.Int("bytes", len(pdf)))
+
+	return pdf, fmt.Sprintf("report-%s.pdf", result.ID), nil
+}
@@ -0,0 +1,496 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"essay-test-backend/internal/application/dto"
+	"essay-test-backend/internal/domain/entities"
+	"essay-test-backend/internal/domain/repositories"
+	"essay-test-backend/internal/domain/services"
+	"essay-test-backend/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+// 一覧で返すジョブの最大件数
+const rescoreJobListLimit = 50
+
+type RescoreUsecase struct {
+	testRepo       repositories.EssayTestRepository
+	submissionRepo repositories.SubmissionRepository
+	resultRepo     repositories.ScoringResultRepository
+	jobRepo        repositories.RescoreJobRepository
+	ratingRepo     repositories.RatingRepository
+	reviewRepo     repositories.ScoreReviewRepository
+	scoringService services.ScoringService
+	listeners      []services.ScoreChangeListener
+	jobs           context.Context // Shutdownの期限を過ぎると取り消され、実行中のジョブを中断する
+	cancelJobs     context.CancelFunc
+	wg             sync.WaitGroup
+	logger         *zap.Logger
+}
+
+func NewRescoreUsecase(
+	testRepo repositories.EssayTestRepository,
+	submissionRepo repositories.SubmissionRepository,
+	resultRepo repositories.ScoringResultRepository,
+	jobRepo repositories.RescoreJobRepository,
+	ratingRepo repositories.RatingRepository,
+	reviewRepo repositories.ScoreReviewRepository,
+	scoringService services.ScoringService,
+	logger *zap.Logger,
+	listeners ...services.ScoreChangeListener,
+) *RescoreUsecase {
+	jobs, cancelJobs := context.WithCancel(context.Background())
+	return &RescoreUsecase{
+		testRepo:       testRepo,
+		submissionRepo: submissionRepo,
+		resultRepo:     resultRepo,
+		jobRepo:        jobRepo,
+		ratingRepo:     ratingRepo,
+		reviewRepo:     reviewRepo,
+		scoringService: scoringService,
+		listeners:      listeners,
+		jobs:           jobs,
+		cancelJobs:     cancelJobs,
+		logger:         logger,
+	}
+}
+
+// StartJob queues a re-scoring of the submissions in scope and runs it in the background
+func (u *RescoreUsecase) StartJob(ctx context.Context, createdBy string, req dto.RescoreJobRequest) (*dto.RescoreJobResponse, error) {
+	if req.TestID == "" && req.From == nil && req.To == nil {
+		return nil, fmt.Errorf("rescore scope required")
+	}
+	if req.From != nil && req.To != nil && !req.From.Before(*req.To) {
+		return nil, fmt.Errorf("invalid date range")
+	}
+	if req.TestID != "" {
+		test, err := u.testRepo.GetByID(ctx, req.TestID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get test: %w", err)
+		}
+		if test == nil {
+			return nil, fmt.Errorf("test not found")
+		}
+	}
+
+	job := &entities.RescoreJob{
+		TestID:          req.TestID,
+		From:            req.From,
+		To:              req.To,
+		IncludeReviewed: req.IncludeReviewed,
+		ScorerVersion:   u.scoringService.Version(),
+		Status:          entities.JobStatusQueued,
+		CreatedBy:       createdBy,
+	}
+	if err := u.jobRepo.Create(ctx, job); err != nil {
+		return nil, fmt.Errorf("failed to create rescore job: %w", err)
+	}
+
+	logger.FromContext(ctx, u.logger).Info("再採点ジョブを登録",
+		zap.String("job_id", job.ID),
+		zap.String("test_id", job.TestID),
+		zap.String("scorer_version", job.ScorerVersion))
+
+	// リクエストが終わってもジョブは続けるため、リクエストのコンテキストは引き継がない（ログの相関IDのみ引き継ぐ）
+	u.launch(logger.FromContext(ctx, u.logger), job)
+
+	return convertRescoreJobToDTO(*job), nil
+}
+
+// ResumeJobs restarts the jobs left queued or running by a previous process.
+// Submissions already re-scored by the job come out unchanged, so each job simply runs again from the start.
+func (u *RescoreUsecase) ResumeJobs(ctx context.Context) error {
+	jobs, err := u.jobRepo.GetUnfinished(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get unfinished rescore jobs: %w", err)
+	}
+
+	for i := range jobs {
+		job := &jobs[i]
+		// 変更件数と破棄した修正の件数は前回までの差分が残るため引き継ぎ、ほかの件数は数え直す
+		job.Processed, job.Skipped, job.Failed = 0, 0, 0
+		logger.FromContext(ctx, u.logger).Info("中断された再採点ジョブを再開", zap.String("job_id", job.ID))
+		u.launch(logger.FromContext(ctx, u.logger), job)
+	}
+	return nil
+}
+
+// Shutdown waits for the running jobs until ctx is done, then interrupts the rest and returns them to the queue for ResumeJobs
+func (u *RescoreUsecase) Shutdown(ctx context.Context) error {
+	if waitGroupDone(ctx, &u.wg) {
+		return nil
+	}
+
+	u.cancelJobs()
+	grace, cancel := context.WithTimeout(context.Background(), interruptGracePeriod)
+	defer cancel()
+	waitGroupDone(grace, &u.wg)
+	return ctx.Err()
+}
+
+func (u *RescoreUsecase) launch(log *zap.Logger, job *entities.RescoreJob) {
+	u.wg.Add(1)
+	go func() {
+		defer u.wg.Done()
+		u.run(logger.WithContext(u.jobs, log), job)
+	}()
+}
+
+func (u *RescoreUsecase) GetJob(ctx context.Context, jobID string) (*dto.RescoreJobResponse, error) {
+	job, err := u.getJob(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	return convertRescoreJobToDTO(*job), nil
+}
+
+func (u *RescoreUsecase) ListJobs(ctx context.Context) ([]dto.RescoreJobResponse, error) {
+	jobs, err := u.jobRepo.List(ctx, rescoreJobListLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list rescore jobs: %w", err)
+	}
+
+	response := make([]dto.RescoreJobResponse, 0, len(jobs))
+	for _, j := range jobs {
+		response = append(response, *convertRescoreJobToDTO(j))
+	}
+	return response, nil
+}
+
+// GetReport returns the job together with the score changes it produced
+func (u *RescoreUsecase) GetReport(ctx context.Context, jobID string) (*dto.RescoreReportResponse, error) {
+	job, err := u.getJob(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	diffs, err := u.jobRepo.GetDiffsByJobID(ctx, jobID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rescore diffs: %w", err)
+	}
+
+	report := &dto.RescoreReportResponse{
+		Job:   *convertRescoreJobToDTO(*job),
+		Diffs: make([]dto.RescoreDiffResponse, 0, len(diffs)),
+	}
+	for _, d := range diffs {
+		changes := make([]dto.CriteriaScoreChangeResponse, 0, len(d.CriteriaChanges))
+		for _, c := range d.CriteriaChanges {
+			changes = append(changes, dto.CriteriaScoreChangeResponse{
+				QuestionNum:  c.QuestionNum,
+				CriteriaName: c.CriteriaName,
+				OldScore:     c.OldScore,
+				NewScore:     c.NewScore,
+			})
+		}
+		report.Diffs = append(report.Diffs, dto.RescoreDiffResponse{
+			SubmissionID:     d.SubmissionID,
+			OldResultID:      d.OldResultID,
+			NewResultID:      d.NewResultID,
+			OldScorerVersion: d.OldScorerVersion,
+			OldTotalScore:    d.OldTotalScore,
+			NewTotalScore:    d.NewTotalScore,
+			Delta:            d.Delta,
+			ReviewDiscarded:  d.ReviewDiscarded,
+			CriteriaChanges:  changes,
+		})
+	}
+	return report, nil
+}
+
+// GetResultVersions returns every stored result of a submission, oldest first
+func (u *RescoreUsecase) GetResultVersions(ctx context.Context, submissionID string) ([]dto.ScoringResultResponse, error) {
+	submission, err := u.submissionRepo.GetByID(ctx, submissionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get submission: %w", err)
+	}
+	if submission == nil {
+		return nil, fmt.Errorf("submission not found")
+	}
+	if err := ensureRatingClosed(ctx, u.ratingRepo, submissionID); err != nil {
+		return nil, err
+	}
+
+	results, err := u.resultRepo.GetVersionsBySubmissionID(ctx, submissionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get results: %w", err)
+	}
+
+	response := make([]dto.ScoringResultResponse, 0, len(results))
+	for i := range results {
+		response = append(response, *convertResultToDTO(&results[i]))
+	}
+	return response, nil
+}
+
+func (u *RescoreUsecase) run(ctx context.Context, job *entities.RescoreJob) {
+	started := time.Now()
+	job.Status = entities.JobStatusRunning
+	job.StartedAt = &started
+	u.saveProgress(ctx, job)
+
+	ids, err := u.submissionRepo.GetIDs(ctx, job.TestID, job.From, job.To)
+	if err != nil {
+		if ctx.Err() != nil {
+			u.interrupt(ctx, job)
+			return
+		}
+		u.finish(ctx, job, fmt.Errorf("failed to get submissions: %w", err))
+		return
+	}
+	job.Total = len(ids)
+	u.saveProgress(ctx, job)
+
+	tests := make(map[string]*entities.EssayTest)
+	for _, id := range ids {
+		if ctx.Err() != nil {
+			u.interrupt(ctx, job)
+			return
+		}
+		changed, skipped, err := u.rescoreSubmission(ctx, job, id, tests)
+		switch {
+		case err != nil && ctx.Err() != nil:
+			u.interrupt(ctx, job)
+			return
+		case err != nil:
+			job.Failed++
+			logger.FromContext(ctx, u.logger).Error("再採点に失敗", zap.Error(err), zap.String("job_id", job.ID), zap.String("submission_id", id))
+		case skipped:
+			job.Skipped++
+		case changed:
+			job.Changed++
+		}
+		job.Processed++
+		u.saveProgress(ctx, job)
+	}
+
+	u.finish(ctx, job, nil)
+}
+
+// rescoreSubmission stores a new result version when the scorer now gives a different outcome
+func (u *RescoreUsecase) rescoreSubmission(ctx context.Context, job *entities.RescoreJob, submissionID string, tests map[string]*entities.EssayTest) (changed bool, skipped bool, err error) {
+	submission, err := u.submissionRepo.GetByID(ctx, submissionID)
+	if err != nil {
+		return false, false, fmt.Errorf("failed to get submission: %w", err)
+	}
+	if submission == nil {
+		return false, false, fmt.Errorf("submission not found")
+	}
+
+	current, err := u.resultRepo.GetBySubmissionID(ctx, submissionID)
+	if err != nil {
+		return false, false, fmt.Errorf("failed to get result: %w", err)
+	}
+
+	// 教員が修正した結果は明示的に指定された場合のみ上書きする
+	if current != nil && current.ReviewedAt != nil && !job.IncludeReviewed {
+		return false, true, nil
+	}
+
+	// 複数採点者による採点中やレビューの下書きがある結果は、採点者の作業対象を差し替えないよう飛ばす
+	session, err := u.ratingRepo.GetSessionBySubmissionID(ctx, submissionID)
+	if err != nil {
+		return false, false, fmt.Errorf("failed to get rating session: %w", err)
+	}
+	if session != nil && session.Status != entities.RatingStatusFinalized {
+		return false, true, nil
+	}
+	if current != nil {
+		review, err := u.reviewRepo.GetByResultID(ctx, current.ID)
+		if err != nil {
+			return false, false, fmt.Errorf("failed to get review: %w", err)
+		}
+		if review != nil && review.Status == entities.ReviewStatusDraft {
+			return false, true, nil
+		}
+	}
+
+	test, ok := tests[submission.TestID]
+	if !ok {
+		test, err = u.testRepo.GetByID(ctx, submission.TestID)
+		if err != nil {
+			return false, false, fmt.Errorf("failed to get test: %w", err)
+		}
+		if test == nil {
+			return false, false, fmt.Errorf("test not found")
+		}
+		tests[submission.TestID] = test
+	}
+
+	result, err := u.scoringService.ScoreSubmission(ctx, submission, test)
+	if err != nil {
+		return false, false, fmt.Errorf("failed to score submission: %w", err)
+	}
+	if submission.LatePenaltyPercent > 0 {
+		result.ApplyLatePenalty(submission.LatePenaltyPercent)
+	}
+
+	diff := &entities.RescoreDiff{
+		JobID:         job.ID,
+		SubmissionID:  submissionID,
+		NewTotalScore: result.TotalScore,
+	}
+	if current != nil {
+		diff.OldResultID = current.ID
+		diff.OldScorerVersion = current.ScorerVersion
+		diff.OldTotalScore = current.TotalScore
+		diff.CriteriaChanges = diffCriteriaScores(current, result)
+
+		if len(diff.CriteriaChanges) == 0 && current.TotalScore == result.TotalScore && current.ScorerVersion == result.ScorerVersion {
+			return false, false, nil
+		}
+	}
+	diff.Delta = diff.NewTotalScore - diff.OldTotalScore
+	// 新しい版は自動採点の点数から作り直すため、公開済みの教員の修正は引き継がれない
+	diff.ReviewDiscarded = current != nil && current.ReviewedAt != nil
+
+	result.SnapshotAutoScores()
+	if err := u.resultRepo.CreateVersion(ctx, result); err != nil {
+		return false, false, fmt.Errorf("failed to save result: %w", err)
+	}
+	notifyScoresChanged(ctx, u.listeners, result)
+
+	diff.NewResultID = result.ID
+	if err := u.jobRepo.CreateDiff(ctx, diff); err != nil {
+		return false, false, fmt.Errorf("failed to save rescore diff: %w", err)
+	}
+	if diff.ReviewDiscarded {
+		job.ReviewsDiscarded++
+		logger.FromContext(ctx, u.logger).Warn("再採点で教員の修正を破棄",
+			zap.String("job_id", job.ID),
+			zap.String("submission_id", submissionID),
+			zap.String("old_result_id", current.ID),
+			zap.String("reviewed_by", current.ReviewedBy))
+	}
+	return true, false, nil
+}
+
+func (u *RescoreUsecase) finish(ctx context.Context, job *entities.RescoreJob, err error) {
+	finished := time.Now()
+	job.FinishedAt = &finished
+	job.Status = entities.JobStatusCompleted
+	if err != nil {
+		job.Status = entities.JobStatusFailed
+		job.Error = err.Error()
+		logger.FromContext(ctx, u.logger).Error("再採点ジョブが失敗", zap.Error(err), zap.String("job_id", job.ID))
+	}
+	u.saveProgress(ctx, job)
+
+	logger.FromContext(ctx, u.logger).Info("再採点ジョブ終了",
+		zap.String("job_id", job.ID),
+		zap.String("status", job.Status),
+		zap.Int("total", job.Total),
+		zap.Int("changed", job.Changed),
+		zap.Int("reviews_discarded", job.ReviewsDiscarded),
+		zap.Int("skipped", job.Skipped),
+		zap.Int("failed", job.Failed))
+}
+
+// interrupt returns a job stopped by Shutdown to the queue so that ResumeJobs runs it on the next start
+func (u *RescoreUsecase) interrupt(ctx context.Context, job *entities.RescoreJob) {
+	// 取り消されたコンテキストでは保存できないため、取り消しだけを外す
+	ctx = context.WithoutCancel(ctx)
+	job.Status = entities.JobStatusQueued
+	u.saveProgress(ctx, job)
+
+	logger.FromContext(ctx, u.logger).Warn("再採点ジョブを中断",
+		zap.String("job_id", job.ID),
+		zap.Int("processed", job.Processed),
+		zap.Int("total", job.Total))
+}
+
+func (u *RescoreUsecase) saveProgress(ctx context.Context, job *entities.RescoreJob) {
+	if err := u.jobRepo.Update(ctx, job); err != nil {
+		logger.FromContext(ctx, u.logger).Error("再採点ジョブの進捗保存に失敗", zap.Error(err), zap.String("job_id", job.ID))
+	}
+}
+
+func (u *RescoreUsecase) getJob(ctx context.Context, jobID string) (*entities.RescoreJob, error) {
+	job, err := u.jobRepo.GetByID(ctx, jobID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rescore job: %w", err)
+	}
+	if job == nil {
+		return nil, fmt.Errorf("job not found")
+	}
+	return job, nil
+}
+
+// diffCriteriaScores lists the criteria whose score differs between two results
+func diffCriteriaScores(prev, next *entities.ScoringResult) []entities.CriteriaScoreChange {
+	type key struct {
+		questionNum  int
+		criteriaName string
+	}
+	oldScores := make(map[key]int)
+	for _, detail := range prev.Details {
+		for _, cs := range detail.CriteriaScores {
+			oldScores[key{detail.QuestionNum, cs.CriteriaName}] = cs.Score
+		}
+	}
+
+	var changes []entities.CriteriaScoreChange
+	for _, detail := range next.Details {
+		for _, cs := range detail.CriteriaScores {
+			k := key{detail.QuestionNum, cs.CriteriaName}
+			oldScore, ok := oldScores[k]
+			delete(oldScores, k)
+			if ok && oldScore == cs.Score {
+				continue
+			}
+			changes = append(changes, entities.CriteriaScoreChange{
+				QuestionNum:  detail.QuestionNum,
+				CriteriaName: cs.CriteriaName,
+				OldScore:     oldScore,
+				NewScore:     cs.Score,
+			})
+		}
+	}
+
+	// 新しい採点でなくなった観点
+	for _, detail := range prev.Details {
+		for _, cs := range detail.CriteriaScores {
+			if _, ok := oldScores[key{detail.QuestionNum, cs.CriteriaName}]; ok {
+				changes = append(changes, entities.CriteriaScoreChange{
+					QuestionNum:  detail.QuestionNum,
+					CriteriaName: cs.CriteriaName,
+					OldScore:     cs.Score,
+				})
+			}
+		}
+	}
+	return changes
+}
+
+func convertRescoreJobToDTO(j entities.RescoreJob) *dto.RescoreJobResponse {
+	progress := 0.0
+	if j.Total > 0 {
+		progress = float64(j.Processed) / float64(j.Total) * 100
+	}
+	return &dto.RescoreJobResponse{
+		ID:               j.ID,
+		TestID:           j.TestID,
+		From:             j.From,
+		To:               j.To,
+		IncludeReviewed:  j.IncludeReviewed,
+		ScorerVersion:    j.ScorerVersion,
+		Status:           j.Status,
+		Total:            j.Total,
+		Processed:        j.Processed,
+		Changed:          j.Changed,
+		ReviewsDiscarded: j.ReviewsDiscarded,
+		Skipped:          j.Skipped,
+		Failed:           j.Failed,
+		Progress:         progress,
+		Error:            j.Error,
+		CreatedBy:        j.CreatedBy,
+		StartedAt:        j.StartedAt,
+		FinishedAt:       j.FinishedAt,
+		CreatedAt:        j.CreatedAt,
+	}
+}
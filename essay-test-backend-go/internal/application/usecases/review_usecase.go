@@ -0,0 +1,450 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"essay-test-backend/internal/application/dto"
+	"essay-test-backend/internal/domain/entities"
+	"essay-test-backend/internal/domain/repositories"
+	"essay-test-backend/internal/domain/services"
+	"essay-test-backend/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+type ReviewUsecase struct {
+	submissionRepo repositories.SubmissionRepository
+	resultRepo     repositories.ScoringResultRepository
+	reviewRepo     repositories.ScoreReviewRepository
+	ratingRepo     repositories.RatingRepository
+	feedback       services.FeedbackGenerator
+	listeners      []services.ScoreChangeListener
+	logger         *zap.Logger
+}
+
+func NewReviewUsecase(
+	submissionRepo repositories.SubmissionRepository,
+	resultRepo repositories.ScoringResultRepository,
+	reviewRepo repositories.ScoreReviewRepository,
+	ratingRepo repositories.RatingRepository,
+	feedback services.FeedbackGenerator,
+	logger *zap.Logger,
+	listeners ...services.ScoreChangeListener,
+) *ReviewUsecase {
+	return &ReviewUsecase{
+		submissionRepo: submissionRepo,
+		resultRepo:     resultRepo,
+		reviewRepo:     reviewRepo,
+		ratingRepo:     ratingRepo,
+		feedback:       feedback,
+		listeners:      listeners,
+		logger:         logger,
+	}
+}
+
+// GetReview returns everything a teacher needs to review a submission
+func (u *ReviewUsecase) GetReview(ctx context.Context, submissionID string) (*dto.ReviewResponse, error) {
+	logger.FromContext(ctx, u.logger).Info("レビュー情報を取得中", zap.String("submission_id", submissionID))
+
+	submission, err := u.submissionRepo.GetByID(ctx, submissionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get submission: %w", err)
+	}
+	if submission == nil {
+		return nil, fmt.Errorf("submission not found")
+	}
+	if err := ensureRatingClosed(ctx, u.ratingRepo, submissionID); err != nil {
+		return nil, err
+	}
+
+	result, err := u.resultRepo.GetBySubmissionID(ctx, submissionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get result: %w", err)
+	}
+	if result == nil {
+		return nil, fmt.Errorf("result not found")
+	}
+
+	review, err := u.reviewRepo.GetByResultID(ctx, result.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get review: %w", err)
+	}
+
+	logs, err := u.reviewRepo.GetAuditLogs(ctx, result.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get audit logs: %w", err)
+	}
+
+	response := &dto.ReviewResponse{
+		SubmissionID: submission.ID,
+		TestID:       submission.TestID,
+		UserID:       submission.UserID,
+		Answers:      convertAnswersToDTO(submission.Answers),
+		Result:       convertResultToDTO(result),
+		AuditLogs:    convertAuditLogsToDTO(logs),
+	}
+	if review != nil {
+		response.Review = convertReviewToDTO(review)
+	}
+	return response, nil
+}
+
+// SaveDraft stores the teacher's adjustments without changing the published scores
+func (u *ReviewUsecase) SaveDraft(ctx context.Context, resultID, reviewerID string, req dto.ReviewRequest) (*dto.ScoreReviewResponse, error) {
+	logger.FromContext(ctx, u.logger).Info("レビューの下書きを保存中",
+		zap.String("result_id", resultID),
+		zap.String("reviewer_id", reviewerID),
+		zap.Int("adjustments", len(req.Adjustments)))
+
+	result, err := u.resultRepo.GetByID(ctx, resultID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get result: %w", err)
+	}
+	if result == nil {
+		return nil, fmt.Errorf("result not found")
+	}
+	if !result.IsCurrent {
+		return nil, fmt.Errorf("result superseded")
+	}
+	if err := ensureRatingClosed(ctx, u.ratingRepo, result.SubmissionID); err != nil {
+		return nil, err
+	}
+
+	if err := validateReviewRequest(result, req); err != nil {
+		return nil, err
+	}
+
+	review, err := u.reviewRepo.GetByResultID(ctx, resultID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get review: %w", err)
+	}
+	if review == nil {
+		review = &entities.ScoreReview{
+			ResultID:     result.ID,
+			SubmissionID: result.SubmissionID,
+		}
+	}
+
+	review.ReviewerID = reviewerID
+	review.Status = entities.ReviewStatusDraft
+	review.Adjustments = nil
+	for _, a := range req.Adjustments {
+		review.Adjustments = append(review.Adjustments, entities.CriteriaAdjustment{
+			CriteriaScoreID: a.CriteriaScoreID,
+			Score:           a.Score,
+			Comment:         a.Comment,
+		})
+	}
+	review.QuestionComments = nil
+	for _, c := range req.QuestionComments {
+		review.QuestionComments = append(review.QuestionComments, entities.QuestionCommentDraft{
+			QuestionScoreID: c.QuestionScoreID,
+			Comment:         c.Comment,
+		})
+	}
+	review.Feedback = req.Feedback
+
+	logs := []entities.ScoreAuditLog{{
+		ResultID: result.ID,
+		ActorID:  reviewerID,
+		Action:   "draft_saved",
+		Target:   result.ID,
+		NewValue: fmt.Sprintf("%d件の採点修正", len(review.Adjustments)),
+	}}
+	if err := u.reviewRepo.Save(ctx, review, logs); err != nil {
+		return nil, fmt.Errorf("failed to save review: %w", err)
+	}
+
+	return convertReviewToDTO(review), nil
+}
+
+// Publish applies the draft to the scoring result and records every change in the audit trail
+func (u *ReviewUsecase) Publish(ctx context.Context, resultID, reviewerID string) (*dto.ScoringResultResponse, error) {
+	logger.FromContext(ctx, u.logger).Info("レビューを公開中", zap.String("result_id", resultID), zap.String("reviewer_id", reviewerID))
+
+	result, err := u.resultRepo.GetByID(ctx, resultID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get result: %w", err)
+	}
+	if result == nil {
+		return nil, fmt.Errorf("result not found")
+	}
+	if !result.IsCurrent {
+		return nil, fmt.Errorf("result superseded")
+	}
+	if err := ensureRatingClosed(ctx, u.ratingRepo, result.SubmissionID); err != nil {
+		return nil, err
+	}
+
+	review, err := u.reviewRepo.GetByResultID(ctx, resultID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get review: %w", err)
+	}
+	if review == nil || review.Status != entities.ReviewStatusDraft {
+		return nil, fmt.Errorf("no draft review")
+	}
+
+	if err := publishReview(ctx, u.reviewRepo, u.feedback, result, review, reviewerID); err != nil {
+		return nil, err
+	}
+	notifyScoresChanged(ctx, u.listeners, result)
+
+	logger.FromContext(ctx, u.logger).Info("レビュー公開完了",
+		zap.String("result_id", result.ID),
+		zap.Int("auto_total_score", result.AutoTotalScore),
+		zap.Int("total_score", result.TotalScore),
+		zap.String("scored_by", result.ScoredBy))
+
+	return convertResultToDTO(result), nil
+}
+
+func (u *ReviewUsecase) GetAuditLogs(ctx context.Context, resultID string) ([]dto.AuditLogResponse, error) {
+	result, err := u.resultRepo.GetByID(ctx, resultID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get result: %w", err)
+	}
+	if result == nil {
+		return nil, fmt.Errorf("result not found")
+	}
+	if err := ensureRatingClosed(ctx, u.ratingRepo, result.SubmissionID); err != nil {
+		return nil, err
+	}
+
+	logs, err := u.reviewRepo.GetAuditLogs(ctx, resultID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get audit logs: %w", err)
+	}
+	return convertAuditLogsToDTO(logs), nil
+}
+
+// notifyScoresChanged tells the listeners that the scores of a stored result have changed
+func notifyScoresChanged(ctx context.Context, listeners []services.ScoreChangeListener, result *entities.ScoringResult) {
+	for _, listener := range listeners {
+		listener.OnScoresChanged(ctx, result)
+	}
+}
+
+// publishReview applies the review to the result and stores both together with the audit trail
+func publishReview(ctx context.Context, reviewRepo repositories.ScoreReviewRepository, feedback services.FeedbackGenerator, result *entities.ScoringResult, review *entities.ScoreReview, actorID string) error {
+	// 自動採点の点数が記録される前の結果は公開前に退避しておく
+	if result.ReviewedAt == nil && result.AutoTotalScore == 0 {
+		result.SnapshotAutoScores()
+	}
+
+	logs, refresh := applyReview(result, review, actorID)
+	// 修正後の点数に合わせて講評・良かった点・改善点を作り直す。教員が書いた文章はそのまま残す
+	feedback.RefreshFeedback(result, refresh)
+
+	now := time.Now()
+	result.ReviewedBy = actorID
+	result.ReviewedAt = &now
+	review.Status = entities.ReviewStatusPublished
+	review.PublishedAt = &now
+	logs = append(logs, entities.ScoreAuditLog{
+		ResultID: result.ID,
+		ActorID:  actorID,
+		Action:   "published",
+		Target:   result.ID,
+		NewValue: result.ScoredBy,
+	})
+
+	if err := reviewRepo.Publish(ctx, review, result, logs); err != nil {
+		if errors.Is(err, repositories.ErrResultSuperseded) {
+			return fmt.Errorf("result superseded")
+		}
+		return fmt.Errorf("failed to publish review: %w", err)
+	}
+	return nil
+}
+
+func validateReviewRequest(result *entities.ScoringResult, req dto.ReviewRequest) error {
+	criteria := make(map[string]entities.CriteriaScore)
+	questions := make(map[string]bool)
+	for _, detail := range result.Details {
+		questions[detail.ID] = true
+		for _, cs := range detail.CriteriaScores {
+			criteria[cs.ID] = cs
+		}
+	}
+
+	for _, a := range req.Adjustments {
+		cs, ok := criteria[a.CriteriaScoreID]
+		if !ok {
+			return fmt.Errorf("invalid criteria score")
+		}
+		if a.Score != nil && (*a.Score < 0 || *a.Score > cs.MaxScore) {
+			return fmt.Errorf("score out of range")
+		}
+	}
+	for _, c := range req.QuestionComments {
+		if !questions[c.QuestionScoreID] {
+			return fmt.Errorf("invalid question score")
+		}
+	}
+	return nil
+}
+
+// applyReview updates the result in place and returns an audit entry for every field that changed,
+// along with what the teacher changed so the generated feedback can follow the new scores
+func applyReview(result *entities.ScoringResult, review *entities.ScoreReview, actorID string) ([]entities.ScoreAuditLog, services.FeedbackRefresh) {
+	var logs []entities.ScoreAuditLog
+	refresh := services.FeedbackRefresh{KeepFeedback: review.Feedback != nil}
+	audit := func(action, target, oldValue, newValue string) {
+		logs = append(logs, entities.ScoreAuditLog{
+			ResultID: result.ID,
+			ActorID:  actorID,
+			Action:   action,
+			Target:   target,
+			OldValue: oldValue,
+			NewValue: newValue,
+		})
+	}
+
+	adjustments := make(map[string]entities.CriteriaAdjustment)
+	for _, a := range review.Adjustments {
+		adjustments[a.CriteriaScoreID] = a
+	}
+	comments := make(map[string]string)
+	for _, c := range review.QuestionComments {
+		comments[c.QuestionScoreID] = c.Comment
+	}
+
+	criteriaCount, editedCount := 0, 0
+	for i := range result.Details {
+		detail := &result.Details[i]
+
+		// 修正された採点基準の差分だけ問題の点数を動かし、端数処理の差を保つ
+		delta := 0
+		for j := range detail.CriteriaScores {
+			cs := &detail.CriteriaScores[j]
+			target := fmt.Sprintf("問%d %s", detail.QuestionNum, cs.CriteriaName)
+
+			a, ok := adjustments[cs.ID]
+			if ok && a.Score != nil {
+				if *a.Score != cs.Score {
+					audit("criteria_score", target, strconv.Itoa(cs.Score), strconv.Itoa(*a.Score))
+					delta += *a.Score - cs.Score
+					cs.Score = *a.Score
+					refresh.Rescored = append(refresh.Rescored, cs.ID)
+				}
+				cs.Overridden = true
+			}
+			if ok && a.Comment != nil {
+				if *a.Comment != cs.Comment {
+					audit("criteria_comment", target, cs.Comment, *a.Comment)
+					cs.Comment = *a.Comment
+				}
+				refresh.KeepComments = append(refresh.KeepComments, cs.ID)
+			}
+
+			criteriaCount++
+			if cs.Overridden || (ok && a.Comment != nil) {
+				editedCount++
+			}
+		}
+
+		if delta != 0 {
+			detail.Score = clampScore(detail.Score+delta, detail.MaxScore)
+			if detail.MaxScore > 0 {
+				detail.Percentage = float64(detail.Score) / float64(detail.MaxScore) * 100
+			}
+		}
+
+		if comment, ok := comments[detail.ID]; ok && comment != detail.Comment {
+			audit("question_comment", fmt.Sprintf("問%d", detail.QuestionNum), detail.Comment, comment)
+			detail.Comment = comment
+		}
+	}
+
+	if review.Feedback != nil && *review.Feedback != result.Feedback {
+		audit("feedback", result.ID, result.Feedback, *review.Feedback)
+		result.Feedback = *review.Feedback
+	}
+
+	// 合計点は遅延提出の減点を含めて計算し直す
+	previousTotal := result.TotalScore
+	result.ApplyLatePenalty(result.LatePenaltyPercent)
+	if result.TotalScore != previousTotal {
+		audit("total_score", result.ID, strconv.Itoa(previousTotal), strconv.Itoa(result.TotalScore))
+	}
+
+	// 点数だけでなくコメントの修正も人の手が入った結果として扱う
+	switch {
+	case editedCount > 0 && editedCount == criteriaCount:
+		result.ScoredBy = entities.ScoredByHuman
+	case editedCount > 0 || len(review.QuestionComments) > 0 || review.Feedback != nil:
+		result.ScoredBy = entities.ScoredByHybrid
+	}
+
+	return logs, refresh
+}
+
+func clampScore(score, maxScore int) int {
+	if score < 0 {
+		return 0
+	}
+	if score > maxScore {
+		return maxScore
+	}
+	return score
+}
+
+func convertAnswersToDTO(answers []entities.Answer) []dto.AnswerResponse {
+	result := make([]dto.AnswerResponse, 0, len(answers))
+	for _, a := range answers {
+		result = append(result, dto.AnswerResponse{
+			ID:              a.ID,
+			QuestionID:      a.QuestionID,
+			Content:         a.Content,
+			WordCount:       a.WordCount,
+			TranscriptionID: a.TranscriptionID,
+		})
+	}
+	return result
+}
+
+func convertReviewToDTO(review *entities.ScoreReview) *dto.ScoreReviewResponse {
+	response := &dto.ScoreReviewResponse{
+		ID:          review.ID,
+		ResultID:    review.ResultID,
+		ReviewerID:  review.ReviewerID,
+		Status:      review.Status,
+		Feedback:    review.Feedback,
+		PublishedAt: review.PublishedAt,
+		UpdatedAt:   review.UpdatedAt,
+	}
+	for _, a := range review.Adjustments {
+		response.Adjustments = append(response.Adjustments, dto.CriteriaAdjustmentRequest{
+			CriteriaScoreID: a.CriteriaScoreID,
+			Score:           a.Score,
+			Comment:         a.Comment,
+		})
+	}
+	for _, c := range review.QuestionComments {
+		response.QuestionComments = append(response.QuestionComments, dto.QuestionCommentRequest{
+			QuestionScoreID: c.QuestionScoreID,
+			Comment:         c.Comment,
+		})
+	}
+	return response
+}
+
+func convertAuditLogsToDTO(logs []entities.ScoreAuditLog) []dto.AuditLogResponse {
+	result := make([]dto.AuditLogResponse, 0, len(logs))
+	for _, l := range logs {
+		result = append(result, dto.AuditLogResponse{
+			ID:        l.ID,
+			ActorID:   l.ActorID,
+			Action:    l.Action,
+			Target:    l.Target,
+			OldValue:  l.OldValue,
+			NewValue:  l.NewValue,
+			CreatedAt: l.CreatedAt,
+		})
+	}
+	return result
+}
@@ -0,0 +1,220 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"essay-test-backend/internal/domain/repositories"
+	"essay-test-backend/pkg/config"
+	"essay-test-backend/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+// ワーカーが動いている間は採点中も含めて一定間隔で生存を記録し、その3倍の間記録がなければ停止とみなす
+const (
+	workerHeartbeatInterval = 10 * time.Second
+	workerHeartbeatTimeout  = 3 * workerHeartbeatInterval
+)
+
+// 停止期限を過ぎて中断した処理が、中断を記録し終えるまで待つ時間
+const interruptGracePeriod = 5 * time.Second
+
+// ScoringQueue scores queued submissions in the background with a fixed number of workers
+type ScoringQueue struct {
+	scorer         *EssayTestUsecase
+	submissionRepo repositories.SubmissionRepository
+	jobs           chan string
+	queued         sync.Map // キューに入っているか採点中の提出物のID
+	workers        int
+	claimTimeout   time.Duration // これより前から採点中の提出物は、採点したプロセスが停止したとみなして引き継ぐ
+	running        atomic.Int32  // 動いているワーカーの数
+	heartbeat      atomic.Int64  // ワーカーが最後に生存を記録した時刻（UnixNano）
+	stop           chan struct{}
+	stopOnce       sync.Once
+	cancel         context.CancelFunc
+	wg             sync.WaitGroup
+	logger         *zap.Logger
+}
+
+func NewScoringQueue(
+	scorer *EssayTestUsecase,
+	submissionRepo repositories.SubmissionRepository,
+	cfg config.IngestConfig,
+	logger *zap.Logger,
+) *ScoringQueue {
+	workers := cfg.Workers
+	if workers < 1 {
+		workers = 1
+	}
+	return &ScoringQueue{
+		scorer:         scorer,
+		submissionRepo: submissionRepo,
+		jobs:           make(chan string, cfg.QueueSize),
+		workers:        workers,
+		claimTimeout:   cfg.ClaimTimeout,
+		stop:           make(chan struct{}),
+		cancel:         func() {},
+		logger:         logger,
+	}
+}
+
+// Start launches the workers and queues the submissions left pending, or claimed by a process that
+// stopped without finishing them. Submissions another replica is still scoring are not queued
+func (q *ScoringQueue) Start(ctx context.Context) {
+	ctx, q.cancel = context.WithCancel(ctx)
+	q.running.Store(int32(q.workers))
+	for i := 0; i < q.workers; i++ {
+		q.wg.Add(1)
+		go func() {
+			defer q.wg.Done()
+			defer q.running.Add(-1)
+			q.work(ctx)
+		}()
+	}
+	q.beat()
+	go q.keepAlive(ctx)
+
+	go func() {
+		ids, err := q.submissionRepo.GetClaimableIDs(ctx, q.staleBefore())
+		if err != nil {
+			logger.FromContext(ctx, q.logger).Error("採点待ちの提出物の取得に失敗", zap.Error(err))
+			return
+		}
+		if len(ids) > 0 {
+			logger.FromContext(ctx, q.logger).Info("採点待ちの提出物を再登録", zap.Int("count", len(ids)))
+		}
+		for _, id := range ids {
+			if err := q.Enqueue(ctx, id); err != nil {
+				return
+			}
+		}
+	}()
+}
+
+// Enqueue adds a stored submission to the queue, waiting while the queue is full.
+// A submission already queued or being scored by a worker is not added again
+func (q *ScoringQueue) Enqueue(ctx context.Context, submissionID string) error {
+	select {
+	case <-q.stop:
+		return fmt.Errorf("scoring queue stopped")
+	default:
+	}
+
+	if _, queued := q.queued.LoadOrStore(submissionID, struct{}{}); queued {
+		return nil
+	}
+	select {
+	case q.jobs <- submissionID:
+		return nil
+	case <-q.stop:
+		q.queued.Delete(submissionID)
+		return fmt.Errorf("scoring queue stopped")
+	case <-ctx.Done():
+		q.queued.Delete(submissionID)
+		return ctx.Err()
+	}
+}
+
+// Shutdown stops the workers from taking submissions and waits for the ones being scored until ctx is done.
+// Scoring still running then is interrupted; those submissions are returned to pending and, like the ones
+// left in the queue, are scored again on the next Start.
+func (q *ScoringQueue) Shutdown(ctx context.Context) error {
+	q.stopOnce.Do(func() { close(q.stop) })
+	if waitGroupDone(ctx, &q.wg) {
+		return nil
+	}
+
+	q.cancel()
+	grace, cancel := context.WithTimeout(context.Background(), interruptGracePeriod)
+	defer cancel()
+	waitGroupDone(grace, &q.wg)
+	return ctx.Err()
+}
+
+func (q *ScoringQueue) work(ctx context.Context) {
+	for {
+		// 停止後はキューに残っていても新たに採点しない
+		select {
+		case <-q.stop:
+			return
+		default:
+		}
+
+		select {
+		case id := <-q.jobs:
+			if err := q.scorer.ScorePending(ctx, id, q.staleBefore()); err != nil {
+				logger.FromContext(ctx, q.logger).Error("キューの採点に失敗", zap.Error(err), zap.String("submission_id", id))
+			}
+			q.queued.Delete(id)
+		case <-q.stop:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// keepAlive records the heartbeat while any worker is running, however long a submission takes
+// to score, and stops once the workers have exited
+func (q *ScoringQueue) keepAlive(ctx context.Context) {
+	ticker := time.NewTicker(workerHeartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if q.running.Load() == 0 {
+				return
+			}
+			q.beat()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// staleBefore returns the time before which a scoring claim is considered abandoned
+func (q *ScoringQueue) staleBefore() time.Time {
+	return time.Now().Add(-q.claimTimeout)
+}
+
+func (q *ScoringQueue) beat() {
+	q.heartbeat.Store(time.Now().UnixNano())
+}
+
+// CheckHealth reports whether the workers have recorded a heartbeat recently
+func (q *ScoringQueue) CheckHealth(ctx context.Context) error {
+	last := q.heartbeat.Load()
+	if last == 0 {
+		return fmt.Errorf("scoring workers not started")
+	}
+	if since := time.Since(time.Unix(0, last)); since > workerHeartbeatTimeout {
+		return fmt.Errorf("no scoring worker heartbeat for %s", since.Round(time.Second))
+	}
+	return nil
+}
+
+// Len returns the number of submissions waiting for a worker
+func (q *ScoringQueue) Len() int {
+	return len(q.jobs)
+}
+
+// waitGroupDone waits for wg until ctx is done and reports whether everything finished
+func waitGroupDone(ctx context.Context, wg *sync.WaitGroup) bool {
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
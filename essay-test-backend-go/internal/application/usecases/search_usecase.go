@@ -0,0 +1,182 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"essay-test-backend/internal/application/dto"
+	"essay-test-backend/internal/domain/repositories"
+	"essay-test-backend/pkg/logger"
+	"essay-test-backend/pkg/snippet"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// 検索結果の1ページの件数
+	searchDefaultLimit = 20
+	searchMaxLimit     = 100
+
+	// スニペットの文字数と、1件の提出物から返すスニペットの最大数
+	searchSnippetWidth   = 120
+	searchSnippetsPerHit = 3
+)
+
+type SearchUsecase struct {
+	searchRepo repositories.EssaySearchRepository
+	testRepo   repositories.EssayTestRepository
+	classRepo  repositories.ClassRepository
+	logger     *zap.Logger
+}
+
+func NewSearchUsecase(
+	searchRepo repositories.EssaySearchRepository,
+	testRepo repositories.EssayTestRepository,
+	classRepo repositories.ClassRepository,
+	logger *zap.Logger,
+) *SearchUsecase {
+	return &SearchUsecase{
+		searchRepo: searchRepo,
+		testRepo:   testRepo,
+		classRepo:  classRepo,
+		logger:     logger,
+	}
+}
+
+// SearchEssays finds the scored submissions whose answers and feedback together contain every term of the query,
+// newest first, with snippets of the matching fields. Teachers only search the classes they teach
+func (u *SearchUsecase) SearchEssays(ctx context.Context, actorID string, isAdmin bool, req dto.EssaySearchRequest) (*dto.EssaySearchResponse, error) {
+	terms := snippet.Terms(req.Q)
+	if len(terms) == 0 {
+		return nil, fmt.Errorf("search query required")
+	}
+	if req.ScoreMin != nil && req.ScoreMax != nil && *req.ScoreMin > *req.ScoreMax {
+		return nil, fmt.Errorf("invalid score range")
+	}
+	if req.From != nil && req.To != nil && !req.From.Before(*req.To) {
+		return nil, fmt.Errorf("invalid date range")
+	}
+
+	query := repositories.EssaySearchQuery{
+		Terms:    terms,
+		TestID:   req.TestID,
+		MinScore: req.ScoreMin,
+		MaxScore: req.ScoreMax,
+		From:     req.From,
+		To:       req.To,
+		Limit:    req.Limit,
+	}
+	if query.Limit == 0 {
+		query.Limit = searchDefaultLimit
+	}
+	if query.Limit < 0 || query.Limit > searchMaxLimit {
+		return nil, fmt.Errorf("invalid limit")
+	}
+	if req.Cursor != "" {
+		after, err := decodeSearchCursor(req.Cursor)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cursor")
+		}
+		query.After = after
+	}
+	if req.TestID != "" {
+		test, err := u.testRepo.GetByID(ctx, req.TestID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get test: %w", err)
+		}
+		if test == nil {
+			return nil, fmt.Errorf("test not found")
+		}
+	}
+	if req.ClassID != "" {
+		class, err := authorizeClassTeacher(ctx, u.classRepo, req.ClassID, actorID, isAdmin)
+		if err != nil {
+			return nil, err
+		}
+		assignments, err := u.classRepo.ListAssignmentsByClass(ctx, class.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list assignments: %w", err)
+		}
+		query.AssignmentIDs = make([]string, 0, len(assignments))
+		for _, a := range assignments {
+			query.AssignmentIDs = append(query.AssignmentIDs, a.ID)
+		}
+	} else if !isAdmin {
+		// クラスを指定しない場合も、教員は担当するクラスの課題の提出物だけを検索する
+		ids, err := taughtAssignmentIDs(ctx, u.classRepo, actorID)
+		if err != nil {
+			return nil, err
+		}
+		query.AssignmentIDs = ids
+	}
+
+	// 1件多く取得して次のページの有無を判定する
+	limit := query.Limit
+	query.Limit++
+	hits, err := u.searchRepo.Search(ctx, query)
+	if err != nil {
+		logger.FromContext(ctx, u.logger).Error("回答の検索に失敗", zap.Error(err))
+		return nil, fmt.Errorf("failed to search essays: %w", err)
+	}
+
+	response := &dto.EssaySearchResponse{Hits: []dto.EssaySearchHitResponse{}}
+	if len(hits) > limit {
+		hits = hits[:limit]
+		last := hits[limit-1]
+		response.NextCursor = encodeSearchCursor(repositories.EssaySearchCursor{SubmittedAt: last.SubmittedAt, ResultID: last.ResultID})
+	}
+	for _, hit := range hits {
+		response.Hits = append(response.Hits, dto.EssaySearchHitResponse{
+			SubmissionID: hit.SubmissionID,
+			ResultID:     hit.ResultID,
+			TestID:       hit.TestID,
+			TestTitle:    hit.TestTitle,
+			UserID:       hit.UserID,
+			TotalScore:   hit.TotalScore,
+			MaxScore:     hit.MaxScore,
+			SubmittedAt:  hit.SubmittedAt,
+			Snippets:     searchSnippets(hit, terms),
+		})
+	}
+
+	logger.FromContext(ctx, u.logger).Info("回答を検索",
+		zap.String("actor_id", actorID),
+		zap.Strings("terms", terms),
+		zap.String("test_id", req.TestID),
+		zap.String("class_id", req.ClassID),
+		zap.Int("count", len(response.Hits)))
+	return response, nil
+}
+
+// searchSnippets takes a snippet of each field of the hit containing a term, answers first
+func searchSnippets(hit repositories.EssaySearchHit, terms []string) []dto.SearchSnippetResponse {
+	snippets := []dto.SearchSnippetResponse{}
+	add := func(field, questionID, text string) {
+		if len(snippets) >= searchSnippetsPerHit {
+			return
+		}
+		s, ok := snippet.Extract(text, terms, searchSnippetWidth)
+		if !ok {
+			return
+		}
+		highlights := make([]dto.HighlightResponse, len(s.Highlights))
+		for i, h := range s.Highlights {
+			highlights[i] = dto.HighlightResponse{Start: h.Start, End: h.End}
+		}
+		snippets = append(snippets, dto.SearchSnippetResponse{
+			Field:      field,
+			QuestionID: questionID,
+			Text:       s.Text,
+			Start:      s.Start,
+			End:        s.End,
+			Highlights: highlights,
+		})
+	}
+
+	for _, answer := range hit.Answers {
+		add("answer", answer.QuestionID, answer.Content)
+	}
+	add("feedback", "", hit.Feedback)
+	add("overall_assessment", "", hit.OverallAssessment)
+	return snippets
+}
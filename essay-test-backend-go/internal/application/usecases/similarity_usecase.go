@@ -0,0 +1,303 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"essay-test-backend/internal/application/dto"
+	"essay-test-backend/internal/domain/entities"
+	"essay-test-backend/internal/domain/repositories"
+	"essay-test-backend/pkg/config"
+	"essay-test-backend/pkg/logger"
+	"essay-test-backend/pkg/similarity"
+
+	"go.uber.org/zap"
+)
+
+type SimilarityUsecase struct {
+	testRepo       repositories.EssayTestRepository
+	submissionRepo repositories.SubmissionRepository
+	similarityRepo repositories.SimilarityRepository
+	config         config.SimilarityConfig
+	logger         *zap.Logger
+}
+
+func NewSimilarityUsecase(
+	testRepo repositories.EssayTestRepository,
+	submissionRepo repositories.SubmissionRepository,
+	similarityRepo repositories.SimilarityRepository,
+	config config.SimilarityConfig,
+	logger *zap.Logger,
+) *SimilarityUsecase {
+	return &SimilarityUsecase{
+		testRepo:       testRepo,
+		submissionRepo: submissionRepo,
+		similarityRepo: similarityRepo,
+		config:         config,
+		logger:         logger,
+	}
+}
+
+// OnSubmissionScored indexes every scored submission so that later submissions are compared against it
+func (u *SimilarityUsecase) OnSubmissionScored(ctx context.Context, submission *entities.Submission, test *entities.EssayTest, result *entities.ScoringResult) {
+	if err := u.IndexSubmission(ctx, submission, test); err != nil {
+		logger.FromContext(ctx, u.logger).Error("類似度インデックスの登録に失敗", zap.Error(err), zap.String("submission_id", submission.ID))
+	}
+}
+
+// IndexSubmission compares the submission with the essay text and earlier answers, then stores its fingerprints
+func (u *SimilarityUsecase) IndexSubmission(ctx context.Context, submission *entities.Submission, test *entities.EssayTest) error {
+	k := u.config.ShingleSize
+	essayText := similarity.Normalize(test.EssayText)
+	essayShingles := essayText.Shingles(k)
+
+	var fingerprints []entities.AnswerFingerprint
+	var matches []entities.SimilarityMatch
+	answerCache := make(map[string]*entities.Answer)
+
+	for _, answer := range submission.Answers {
+		text := similarity.Normalize(answer.Content)
+		shingles := text.Shingles(k)
+		if len(shingles) == 0 {
+			continue
+		}
+		signature := similarity.Signature(shingles, u.config.NumHashes)
+
+		// 課題文の丸写しチェック
+		if containment := similarity.Containment(shingles, essayShingles); containment >= u.config.SourceThreshold {
+			matches = append(matches, entities.SimilarityMatch{
+				TestID:       test.ID,
+				QuestionID:   answer.QuestionID,
+				SubmissionID: submission.ID,
+				AnswerID:     answer.ID,
+				UserID:       submission.UserID,
+				SourceType:   entities.SimilaritySourceEssayText,
+				Similarity:   containment,
+				Spans:        convertSpans(similarity.OverlapSpans(text, essayText, k)),
+			})
+		}
+
+		// 他の受験者の回答との比較
+		candidates, err := u.similarityRepo.GetFingerprintsByQuestion(ctx, test.ID, answer.QuestionID)
+		if err != nil {
+			return fmt.Errorf("failed to get fingerprints: %w", err)
+		}
+
+		for _, candidate := range candidates {
+			if candidate.SubmissionID == submission.ID {
+				continue
+			}
+			if submission.UserID != "" && candidate.UserID == submission.UserID {
+				continue
+			}
+			if similarity.EstimateJaccard(signature, candidate.Signature) < u.config.Threshold {
+				continue
+			}
+
+			matched, err := u.loadAnswer(ctx, answerCache, candidate.SubmissionID, candidate.AnswerID)
+			if err != nil {
+				return err
+			}
+			if matched == nil {
+				continue
+			}
+
+			matchedText := similarity.Normalize(matched.Content)
+			jaccard := similarity.Jaccard(shingles, matchedText.Shingles(k))
+			if jaccard < u.config.Threshold {
+				continue
+			}
+
+			matches = append(matches, entities.SimilarityMatch{
+				TestID:              test.ID,
+				QuestionID:          answer.QuestionID,
+				SubmissionID:        submission.ID,
+				AnswerID:            answer.ID,
+				UserID:              submission.UserID,
+				SourceType:          entities.SimilaritySourceSubmission,
+				MatchedSubmissionID: candidate.SubmissionID,
+				MatchedAnswerID:     candidate.AnswerID,
+				MatchedUserID:       candidate.UserID,
+				Similarity:          jaccard,
+				Spans:               convertSpans(similarity.OverlapSpans(text, matchedText, k)),
+			})
+		}
+
+		fingerprints = append(fingerprints, entities.AnswerFingerprint{
+			AnswerID:     answer.ID,
+			SubmissionID: submission.ID,
+			TestID:       test.ID,
+			QuestionID:   answer.QuestionID,
+			UserID:       submission.UserID,
+			Signature:    signature,
+		})
+	}
+
+	if err := u.similarityRepo.CreateMatches(ctx, matches); err != nil {
+		return fmt.Errorf("failed to save similarity matches: %w", err)
+	}
+	if err := u.similarityRepo.SaveFingerprints(ctx, fingerprints); err != nil {
+		return fmt.Errorf("failed to save fingerprints: %w", err)
+	}
+
+	if len(matches) > 0 {
+		logger.FromContext(ctx, u.logger).Warn("類似した回答を検出",
+			zap.String("submission_id", submission.ID),
+			zap.Int("matches", len(matches)))
+	}
+	return nil
+}
+
+// Reindex rebuilds the similarity index of a test from its stored submissions in submission order
+func (u *SimilarityUsecase) Reindex(ctx context.Context, testID string) (*dto.ReindexResponse, error) {
+	logger.FromContext(ctx, u.logger).Info("類似度インデックスを再構築中", zap.String("test_id", testID))
+
+	test, err := u.testRepo.GetByID(ctx, testID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get test: %w", err)
+	}
+	if test == nil {
+		return nil, fmt.Errorf("test not found")
+	}
+
+	submissions, err := u.submissionRepo.GetByTestID(ctx, testID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get submissions: %w", err)
+	}
+	sort.Slice(submissions, func(i, j int) bool {
+		return submissions[i].CreatedAt.Before(submissions[j].CreatedAt)
+	})
+
+	if err := u.similarityRepo.DeleteByTestID(ctx, testID); err != nil {
+		return nil, fmt.Errorf("failed to clear similarity index: %w", err)
+	}
+
+	for i := range submissions {
+		if err := u.IndexSubmission(ctx, &submissions[i], test); err != nil {
+			return nil, err
+		}
+	}
+
+	logger.FromContext(ctx, u.logger).Info("類似度インデックス再構築完了", zap.String("test_id", testID), zap.Int("indexed", len(submissions)))
+	return &dto.ReindexResponse{TestID: testID, Indexed: len(submissions)}, nil
+}
+
+func (u *SimilarityUsecase) GetMatchesByTest(ctx context.Context, testID string, minSimilarity float64) ([]dto.SimilarityMatchResponse, error) {
+	test, err := u.testRepo.GetByID(ctx, testID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get test: %w", err)
+	}
+	if test == nil {
+		return nil, fmt.Errorf("test not found")
+	}
+
+	matches, err := u.similarityRepo.GetMatchesByTestID(ctx, testID, minSimilarity)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get similarity matches: %w", err)
+	}
+
+	response := make([]dto.SimilarityMatchResponse, 0, len(matches))
+	for _, m := range matches {
+		response = append(response, convertSimilarityMatchToDTO(m, nil))
+	}
+	return response, nil
+}
+
+func (u *SimilarityUsecase) GetMatchesBySubmission(ctx context.Context, submissionID string) ([]dto.SimilarityMatchResponse, error) {
+	submission, err := u.submissionRepo.GetByID(ctx, submissionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get submission: %w", err)
+	}
+	if submission == nil {
+		return nil, fmt.Errorf("submission not found")
+	}
+
+	matches, err := u.similarityRepo.GetMatchesBySubmissionID(ctx, submissionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get similarity matches: %w", err)
+	}
+
+	contents := make(map[string]string)
+	for _, a := range submission.Answers {
+		contents[a.ID] = a.Content
+	}
+
+	response := make([]dto.SimilarityMatchResponse, 0, len(matches))
+	for _, m := range matches {
+		response = append(response, convertSimilarityMatchToDTO(m, contents))
+	}
+	return response, nil
+}
+
+func (u *SimilarityUsecase) loadAnswer(ctx context.Context, cache map[string]*entities.Answer, submissionID, answerID string) (*entities.Answer, error) {
+	if answer, ok := cache[answerID]; ok {
+		return answer, nil
+	}
+
+	submission, err := u.submissionRepo.GetByID(ctx, submissionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get submission: %w", err)
+	}
+	if submission == nil {
+		return nil, nil
+	}
+	for i := range submission.Answers {
+		cache[submission.Answers[i].ID] = &submission.Answers[i]
+	}
+	return cache[answerID], nil
+}
+
+func convertSpans(spans []similarity.Span) []entities.OverlapSpan {
+	result := make([]entities.OverlapSpan, 0, len(spans))
+	for _, s := range spans {
+		result = append(result, entities.OverlapSpan{
+			Start:        s.Start,
+			End:          s.End,
+			MatchedStart: s.MatchedStart,
+			MatchedEnd:   s.MatchedEnd,
+		})
+	}
+	return result
+}
+
+func convertSimilarityMatchToDTO(m entities.SimilarityMatch, contents map[string]string) dto.SimilarityMatchResponse {
+	// 照会した提出物の側から見た抜粋を付与する
+	content, own := contents[m.AnswerID]
+	if !own {
+		content = contents[m.MatchedAnswerID]
+	}
+	runes := []rune(content)
+
+	spans := make([]dto.OverlapSpanResponse, 0, len(m.Spans))
+	for _, s := range m.Spans {
+		span := dto.OverlapSpanResponse{
+			Start:        s.Start,
+			End:          s.End,
+			MatchedStart: s.MatchedStart,
+			MatchedEnd:   s.MatchedEnd,
+		}
+		start, end := s.Start, s.End
+		if !own {
+			start, end = s.MatchedStart, s.MatchedEnd
+		}
+		if len(runes) > 0 && end <= len(runes) {
+			span.Text = string(runes[start:end])
+		}
+		spans = append(spans, span)
+	}
+
+	return dto.SimilarityMatchResponse{
+		ID:                  m.ID,
+		TestID:              m.TestID,
+		QuestionID:          m.QuestionID,
+		SubmissionID:        m.SubmissionID,
+		UserID:              m.UserID,
+		SourceType:          m.SourceType,
+		MatchedSubmissionID: m.MatchedSubmissionID,
+		MatchedUserID:       m.MatchedUserID,
+		Similarity:          m.Similarity,
+		Spans:               spans,
+		CreatedAt:           m.CreatedAt,
+	}
+}
@@ -0,0 +1,218 @@
+package usecases
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"essay-test-backend/internal/application/dto"
+	"essay-test-backend/internal/domain/entities"
+	"essay-test-backend/internal/domain/repositories"
+	"essay-test-backend/internal/domain/services"
+	"essay-test-backend/pkg/config"
+	"essay-test-backend/pkg/logger"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// 受け付ける画像の形式と保存時の拡張子
+var transcriptionImageTypes = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/webp": ".webp",
+}
+
+type TranscriptionUsecase struct {
+	testRepo          repositories.EssayTestRepository
+	transcriptionRepo repositories.TranscriptionRepository
+	storage           services.FileStorage
+	ocr               services.OCRService
+	maxImageBytes     int64
+	maxAnswerChars    int
+	logger            *zap.Logger
+}
+
+func NewTranscriptionUsecase(
+	testRepo repositories.EssayTestRepository,
+	transcriptionRepo repositories.TranscriptionRepository,
+	storage services.FileStorage,
+	ocr services.OCRService,
+	cfg config.UploadConfig,
+	limits config.LimitsConfig,
+	logger *zap.Logger,
+) *TranscriptionUsecase {
+	return &TranscriptionUsecase{
+		testRepo:          testRepo,
+		transcriptionRepo: transcriptionRepo,
+		storage:           storage,
+		ocr:               ocr,
+		maxImageBytes:     cfg.MaxImageBytes,
+		maxAnswerChars:    limits.MaxAnswerChars,
+		logger:            logger,
+	}
+}
+
+// Upload stores the photo of a handwritten answer and transcribes it for the student to confirm
+func (u *TranscriptionUsecase) Upload(ctx context.Context, userID, testID, questionID string, image io.Reader) (*dto.TranscriptionResponse, error) {
+	test, err := u.testRepo.GetByID(ctx, testID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get test: %w", err)
+	}
+	if test == nil {
+		return nil, fmt.Errorf("test not found")
+	}
+	if findQuestion(test, questionID) == nil {
+		return nil, fmt.Errorf("question not found")
+	}
+
+	data, err := io.ReadAll(io.LimitReader(image, u.maxImageBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image: %w", err)
+	}
+	if int64(len(data)) > u.maxImageBytes {
+		return nil, fmt.Errorf("image too large")
+	}
+	// 申告された形式ではなく中身から判定する
+	contentType := http.DetectContentType(data)
+	ext, ok := transcriptionImageTypes[contentType]
+	if !ok {
+		return nil, fmt.Errorf("invalid image")
+	}
+
+	transcription := &entities.Transcription{
+		ID:          uuid.New().String(),
+		UserID:      userID,
+		TestID:      test.ID,
+		QuestionID:  questionID,
+		ContentType: contentType,
+		Size:        int64(len(data)),
+		Engine:      u.ocr.Engine(),
+		Status:      entities.TranscriptionStatusTranscribed,
+	}
+	transcription.StorageKey = "transcriptions/" + transcription.ID + ext
+
+	if err := u.storage.Save(ctx, transcription.StorageKey, bytes.NewReader(data)); err != nil {
+		return nil, fmt.Errorf("failed to save image: %w", err)
+	}
+
+	// 読み取りに失敗しても写真は残し、生徒が写真を見ながら入力できるようにする
+	result, err := u.ocr.Transcribe(ctx, data, contentType)
+	if err != nil {
+		logger.FromContext(ctx, u.logger).Error("手書き答案の読み取りに失敗", zap.Error(err), zap.String("transcription_id", transcription.ID))
+	} else {
+		transcription.OCRText = result.Text
+		transcription.Confidence = result.Confidence
+		transcription.Content = result.Text
+	}
+
+	if err := u.transcriptionRepo.Create(ctx, transcription); err != nil {
+		if delErr := u.storage.Delete(ctx, transcription.StorageKey); delErr != nil {
+			logger.FromContext(ctx, u.logger).Warn("画像の削除に失敗", zap.Error(delErr), zap.String("key", transcription.StorageKey))
+		}
+		return nil, fmt.Errorf("failed to create transcription: %w", err)
+	}
+
+	logger.FromContext(ctx, u.logger).Info("手書き答案を読み取り",
+		zap.String("transcription_id", transcription.ID),
+		zap.String("user_id", userID),
+		zap.String("engine", transcription.Engine),
+		zap.Float64("confidence", transcription.Confidence))
+
+	return convertTranscriptionToDTO(transcription), nil
+}
+
+// GetTranscription returns a transcription to its student or to a teacher
+func (u *TranscriptionUsecase) GetTranscription(ctx context.Context, transcriptionID, userID string, isTeacher bool) (*dto.TranscriptionResponse, error) {
+	transcription, err := u.getTranscription(ctx, transcriptionID, userID, isTeacher)
+	if err != nil {
+		return nil, err
+	}
+	return convertTranscriptionToDTO(transcription), nil
+}
+
+// OpenImage opens the uploaded photo; the caller closes it
+func (u *TranscriptionUsecase) OpenImage(ctx context.Context, transcriptionID, userID string, isTeacher bool) (io.ReadCloser, string, error) {
+	transcription, err := u.getTranscription(ctx, transcriptionID, userID, isTeacher)
+	if err != nil {
+		return nil, "", err
+	}
+
+	image, err := u.storage.Open(ctx, transcription.StorageKey)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to open image: %w", err)
+	}
+	return image, transcription.ContentType, nil
+}
+
+// Confirm stores the text the student checked against their photo; only confirmed text can be submitted
+func (u *TranscriptionUsecase) Confirm(ctx context.Context, transcriptionID, userID string, req dto.TranscriptionConfirmRequest) (*dto.TranscriptionResponse, error) {
+	transcription, err := u.getTranscription(ctx, transcriptionID, userID, false)
+	if err != nil {
+		return nil, err
+	}
+	if transcription.SubmissionID != "" {
+		return nil, fmt.Errorf("transcription already submitted")
+	}
+
+	content := strings.TrimSpace(req.Content)
+	if content == "" {
+		return nil, fmt.Errorf("empty transcription")
+	}
+	if answerTooLong(content, u.maxAnswerChars) {
+		return nil, fmt.Errorf("answer too long")
+	}
+
+	now := time.Now()
+	transcription.Content = content
+	transcription.Status = entities.TranscriptionStatusConfirmed
+	transcription.ConfirmedAt = &now
+	if err := u.transcriptionRepo.Update(ctx, transcription); err != nil {
+		if errors.Is(err, repositories.ErrTranscriptionUsed) {
+			return nil, fmt.Errorf("transcription already submitted")
+		}
+		return nil, fmt.Errorf("failed to update transcription: %w", err)
+	}
+
+	logger.FromContext(ctx, u.logger).Info("手書き答案の読み取り結果を確認",
+		zap.String("transcription_id", transcription.ID),
+		zap.Bool("edited", content != transcription.OCRText))
+
+	return convertTranscriptionToDTO(transcription), nil
+}
+
+// getTranscription hides other students' transcriptions as not found
+func (u *TranscriptionUsecase) getTranscription(ctx context.Context, transcriptionID, userID string, isTeacher bool) (*entities.Transcription, error) {
+	transcription, err := u.transcriptionRepo.GetByID(ctx, transcriptionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transcription: %w", err)
+	}
+	if transcription == nil || (!isTeacher && transcription.UserID != userID) {
+		return nil, fmt.Errorf("transcription not found")
+	}
+	return transcription, nil
+}
+
+func convertTranscriptionToDTO(t *entities.Transcription) *dto.TranscriptionResponse {
+	return &dto.TranscriptionResponse{
+		ID:           t.ID,
+		TestID:       t.TestID,
+		QuestionID:   t.QuestionID,
+		ImageURL:     "/api/v1/transcriptions/" + t.ID + "/image",
+		ContentType:  t.ContentType,
+		Size:         t.Size,
+		Engine:       t.Engine,
+		OCRText:      t.OCRText,
+		Confidence:   t.Confidence,
+		Content:      t.Content,
+		Status:       t.Status,
+		ConfirmedAt:  t.ConfirmedAt,
+		SubmissionID: t.SubmissionID,
+		CreatedAt:    t.CreatedAt,
+	}
+}
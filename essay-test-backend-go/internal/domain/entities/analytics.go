@@ -0,0 +1,112 @@
+package entities
+
+import (
+	"time"
+	"unicode/utf8"
+)
+
+// TestAnalytics holds running aggregates of the current results of a test, updated as results arrive
+type TestAnalytics struct {
+	TestID       string              `json:"test_id" gorm:"primaryKey;type:varchar(191)"`
+	Participants int                 `json:"participants"`
+	Submissions  int                 `json:"submissions"`
+	Total        ScoreDistribution   `json:"total" gorm:"type:text;serializer:json"`
+	Questions    []QuestionAnalytics `json:"questions" gorm:"type:text;serializer:json"`
+	Stale        bool                `json:"stale"` // 採点後に点数が変わり、次の取得時に再集計が必要
+	RebuiltAt    *time.Time          `json:"rebuilt_at,omitempty"`
+	CreatedAt    time.Time           `json:"created_at"`
+	UpdatedAt    time.Time           `json:"updated_at"`
+}
+
+// ScoreDistribution counts the results per integer score, which keeps the median exact
+type ScoreDistribution struct {
+	MaxScore int         `json:"max_score"`
+	Counts   map[int]int `json:"counts"` // 点数 -> 件数
+}
+
+// QuestionAnalytics aggregates the scores of one question and of its criteria
+type QuestionAnalytics struct {
+	QuestionNum int                  `json:"question_num"`
+	Score       ScoreDistribution    `json:"score"`
+	Length      LengthScoreSums      `json:"length"`
+	Criteria    []CriterionAnalytics `json:"criteria"`
+}
+
+// CriterionAnalytics aggregates the scores of one criteria of a question
+type CriterionAnalytics struct {
+	CriteriaName string            `json:"criteria_name"`
+	Score        ScoreDistribution `json:"score"`
+}
+
+// LengthScoreSums keeps the running sums needed for the correlation of answer length and question score
+type LengthScoreSums struct {
+	N     float64 `json:"n"`
+	SumX  float64 `json:"sum_x"` // 文字数
+	SumY  float64 `json:"sum_y"` // 点数
+	SumXX float64 `json:"sum_xx"`
+	SumYY float64 `json:"sum_yy"`
+	SumXY float64 `json:"sum_xy"`
+}
+
+// Add folds a scored submission and its result into the aggregates
+func (a *TestAnalytics) Add(submission *Submission, test *EssayTest, result *ScoringResult) {
+	a.Submissions++
+	a.Total.add(result.TotalScore, result.MaxScore)
+
+	lengths := make(map[int]int)
+	for _, q := range test.Questions {
+		for _, answer := range submission.Answers {
+			if answer.QuestionID == q.ID {
+				lengths[q.Number] = utf8.RuneCountInString(answer.Content)
+			}
+		}
+	}
+
+	for _, detail := range result.Details {
+		qa := a.question(detail.QuestionNum)
+		qa.Score.add(detail.Score, detail.MaxScore)
+		if length, ok := lengths[detail.QuestionNum]; ok {
+			qa.Length.add(float64(length), float64(detail.Score))
+		}
+		for _, cs := range detail.CriteriaScores {
+			qa.criterion(cs.CriteriaName).Score.add(cs.Score, cs.MaxScore)
+		}
+	}
+}
+
+func (a *TestAnalytics) question(number int) *QuestionAnalytics {
+	for i := range a.Questions {
+		if a.Questions[i].QuestionNum == number {
+			return &a.Questions[i]
+		}
+	}
+	a.Questions = append(a.Questions, QuestionAnalytics{QuestionNum: number})
+	return &a.Questions[len(a.Questions)-1]
+}
+
+func (q *QuestionAnalytics) criterion(name string) *CriterionAnalytics {
+	for i := range q.Criteria {
+		if q.Criteria[i].CriteriaName == name {
+			return &q.Criteria[i]
+		}
+	}
+	q.Criteria = append(q.Criteria, CriterionAnalytics{CriteriaName: name})
+	return &q.Criteria[len(q.Criteria)-1]
+}
+
+func (d *ScoreDistribution) add(score, maxScore int) {
+	if d.Counts == nil {
+		d.Counts = make(map[int]int)
+	}
+	d.Counts[score]++
+	d.MaxScore = max(d.MaxScore, maxScore)
+}
+
+func (s *LengthScoreSums) add(x, y float64) {
+	s.N++
+	s.SumX += x
+	s.SumY += y
+	s.SumXX += x * x
+	s.SumYY += y * y
+	s.SumXY += x * y
+}
@@ -0,0 +1,50 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Annotation represents a comment anchored to a range of an answer's text
+type Annotation struct {
+	ID               string    `json:"id" gorm:"primaryKey;type:varchar(191)"`
+	SubmissionID     string    `json:"submission_id" gorm:"type:varchar(191);index"`
+	AnswerID         string    `json:"answer_id" gorm:"type:varchar(191);index"`
+	Start            int       `json:"start"` // 回答本文での開始位置（文字単位）
+	End              int       `json:"end"`
+	NormalizedStart  int       `json:"normalized_start"` // 空白を除いた本文での位置
+	NormalizedEnd    int       `json:"normalized_end"`
+	Quote            string    `json:"quote" gorm:"type:text"`
+	Category         string    `json:"category"` // structure, argument, evidence, expression
+	Severity         string    `json:"severity"` // info, warning, error
+	Comment          string    `json:"comment" gorm:"type:text"`
+	SuggestedRewrite *string   `json:"suggested_rewrite,omitempty" gorm:"type:text"`
+	Source           string    `json:"source"` // auto, human
+	Rule             string    `json:"rule,omitempty"`
+	AuthorID         string    `json:"author_id,omitempty" gorm:"type:varchar(191)"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+const (
+	AnnotationCategoryStructure  = "structure"
+	AnnotationCategoryArgument   = "argument"
+	AnnotationCategoryEvidence   = "evidence"
+	AnnotationCategoryExpression = "expression"
+
+	AnnotationSeverityInfo    = "info"
+	AnnotationSeverityWarning = "warning"
+	AnnotationSeverityError   = "error"
+
+	AnnotationSourceAuto  = "auto"
+	AnnotationSourceHuman = "human"
+)
+
+func (a *Annotation) BeforeCreate(tx *gorm.DB) error {
+	if a.ID == "" {
+		a.ID = uuid.New().String()
+	}
+	return nil
+}
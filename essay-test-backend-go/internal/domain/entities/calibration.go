@@ -0,0 +1,128 @@
+package entities
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// AnchorEssay represents an essay with authoritative criteria scores used to calibrate automated scoring
+type AnchorEssay struct {
+	ID        string                `json:"id" gorm:"primaryKey;type:varchar(191)"`
+	TestID    string                `json:"test_id" gorm:"type:varchar(191);index"`
+	Title     string                `json:"title"`
+	Answers   []AnchorAnswer        `json:"answers" gorm:"type:text;serializer:json"`
+	Scores    []AnchorCriteriaScore `json:"scores" gorm:"type:text;serializer:json"`
+	CreatedBy string                `json:"created_by" gorm:"type:varchar(191)"`
+	CreatedAt time.Time             `json:"created_at"`
+	UpdatedAt time.Time             `json:"updated_at"`
+}
+
+// AnchorAnswer represents the anchor essay's answer to a question
+type AnchorAnswer struct {
+	QuestionID string `json:"question_id"`
+	Content    string `json:"content"`
+}
+
+// AnchorCriteriaScore represents the authoritative score for a criteria of a question
+type AnchorCriteriaScore struct {
+	QuestionNum  int    `json:"question_num"`
+	CriteriaName string `json:"criteria_name"`
+	Score        int    `json:"score"`
+}
+
+// CalibrationRun represents the comparison of the automated scorer with the anchor essays of a test
+type CalibrationRun struct {
+	ID          string                 `json:"id" gorm:"primaryKey;type:varchar(191)"`
+	TestID      string                 `json:"test_id" gorm:"type:varchar(191);index"`
+	ScoredBy    string                 `json:"scored_by"`
+	AnchorCount int                    `json:"anchor_count"`
+	Criteria    []CriterionCalibration `json:"criteria" gorm:"type:text;serializer:json"`
+	CreatedBy   string                 `json:"created_by" gorm:"type:varchar(191)"`
+	CreatedAt   time.Time              `json:"created_at"`
+}
+
+// CriterionCalibration represents the error of the automated scorer on one criteria and the line that corrects it
+type CriterionCalibration struct {
+	QuestionNum       int     `json:"question_num"`
+	CriteriaName      string  `json:"criteria_name"`
+	MaxScore          int     `json:"max_score"`
+	N                 int     `json:"n"`
+	Bias              float64 `json:"bias"` // 自動採点 - 基準点 の平均
+	MeanAbsoluteError float64 `json:"mean_absolute_error"`
+	RMSE              float64 `json:"rmse"`
+	Slope             float64 `json:"slope"`
+	Intercept         float64 `json:"intercept"`
+}
+
+// TestCalibration represents the linear calibration applied to future automated scores of a test
+type TestCalibration struct {
+	TestID    string                 `json:"test_id" gorm:"primaryKey;type:varchar(191)"`
+	RunID     string                 `json:"run_id" gorm:"type:varchar(191)"`
+	Criteria  []CriterionCalibration `json:"criteria" gorm:"type:text;serializer:json"`
+	AppliedBy string                 `json:"applied_by" gorm:"type:varchar(191)"`
+	CreatedAt time.Time              `json:"created_at"`
+	UpdatedAt time.Time              `json:"updated_at"`
+}
+
+// Apply corrects the criteria scores of an automated result and recomputes its totals
+func (c *TestCalibration) Apply(result *ScoringResult) {
+	lines := make(map[string]CriterionCalibration)
+	for _, cc := range c.Criteria {
+		lines[criterionKey(cc.QuestionNum, cc.CriteriaName)] = cc
+	}
+
+	total := 0
+	for i := range result.Details {
+		detail := &result.Details[i]
+
+		// 基準ごとの補正量だけ問題の点数を動かす
+		delta := 0
+		for j := range detail.CriteriaScores {
+			cs := &detail.CriteriaScores[j]
+			line, ok := lines[criterionKey(detail.QuestionNum, cs.CriteriaName)]
+			if !ok {
+				continue
+			}
+			calibrated := int(math.Round(line.Slope*float64(cs.Score) + line.Intercept))
+			calibrated = max(0, min(calibrated, cs.MaxScore))
+			delta += calibrated - cs.Score
+			cs.Score = calibrated
+		}
+
+		if delta != 0 {
+			detail.Score = max(0, min(detail.Score+delta, detail.MaxScore))
+			if detail.MaxScore > 0 {
+				detail.Percentage = float64(detail.Score) / float64(detail.MaxScore) * 100
+			}
+		}
+		total += detail.Score
+	}
+
+	result.TotalScore = total
+	if result.MaxScore > 0 {
+		result.Percentage = float64(total) / float64(result.MaxScore) * 100
+	}
+	result.CalibrationRunID = c.RunID
+}
+
+func criterionKey(questionNum int, criteriaName string) string {
+	return fmt.Sprintf("%d|%s", questionNum, criteriaName)
+}
+
+func (a *AnchorEssay) BeforeCreate(tx *gorm.DB) error {
+	if a.ID == "" {
+		a.ID = uuid.New().String()
+	}
+	return nil
+}
+
+func (r *CalibrationRun) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == "" {
+		r.ID = uuid.New().String()
+	}
+	return nil
+}
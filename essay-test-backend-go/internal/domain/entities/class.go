@@ -0,0 +1,118 @@
+package entities
+
+import (
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Organization represents a school or cram school that owns classes
+type Organization struct {
+	ID        string    `json:"id" gorm:"primaryKey;type:varchar(191)"`
+	Name      string    `json:"name" gorm:"not null"`
+	Type      string    `json:"type"` // school, juku
+	CreatedBy string    `json:"created_by" gorm:"type:varchar(191)"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Class represents a group of students taught by one or more teachers
+type Class struct {
+	ID             string        `json:"id" gorm:"primaryKey;type:varchar(191)"`
+	OrganizationID string        `json:"organization_id" gorm:"type:varchar(191);index"`
+	Name           string        `json:"name" gorm:"not null"`
+	InviteCode     string        `json:"invite_code" gorm:"type:varchar(32);uniqueIndex"`
+	Members        []ClassMember `json:"members,omitempty" gorm:"foreignKey:ClassID"`
+	CreatedBy      string        `json:"created_by" gorm:"type:varchar(191)"`
+	CreatedAt      time.Time     `json:"created_at"`
+	UpdatedAt      time.Time     `json:"updated_at"`
+}
+
+// ClassMember represents the membership of a teacher or student in a class
+type ClassMember struct {
+	ID        string    `json:"id" gorm:"primaryKey;type:varchar(191)"`
+	ClassID   string    `json:"class_id" gorm:"type:varchar(191);uniqueIndex:idx_class_member"`
+	UserID    string    `json:"user_id" gorm:"type:varchar(191);uniqueIndex:idx_class_member;index"`
+	Role      string    `json:"role"` // teacher, student
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Assignment represents a test assigned to a class with its submission rules
+type Assignment struct {
+	ID                string     `json:"id" gorm:"primaryKey;type:varchar(191)"`
+	ClassID           string     `json:"class_id" gorm:"type:varchar(191);index"`
+	TestID            string     `json:"test_id" gorm:"type:varchar(191);index"`
+	Title             string     `json:"title"`
+	OpensAt           *time.Time `json:"opens_at,omitempty"`
+	DueAt             *time.Time `json:"due_at,omitempty"`
+	ClosesAt          *time.Time `json:"closes_at,omitempty"`
+	MaxAttempts       int        `json:"max_attempts"`                         // 0 は無制限
+	AttemptPolicy     string     `json:"attempt_policy" gorm:"default:latest"` // best, latest
+	LatePenaltyPerDay float64    `json:"late_penalty_per_day"`                 // 期限超過1日ごとに減点する割合（%）
+	MaxLatePenalty    float64    `json:"max_late_penalty"`                     // 減点の上限（%）、0 は100%
+	CreatedBy         string     `json:"created_by" gorm:"type:varchar(191)"`
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+}
+
+const (
+	MemberRoleTeacher = "teacher"
+	MemberRoleStudent = "student"
+)
+
+const (
+	AttemptPolicyBest   = "best"
+	AttemptPolicyLatest = "latest"
+)
+
+// IsOpen reports whether submissions are accepted at t
+func (a *Assignment) IsOpen(t time.Time) bool {
+	if a.OpensAt != nil && t.Before(*a.OpensAt) {
+		return false
+	}
+	return a.ClosesAt == nil || !t.After(*a.ClosesAt)
+}
+
+// LatePenaltyAt returns the percentage deducted from a submission made at t, counting every started day after the due date
+func (a *Assignment) LatePenaltyAt(t time.Time) float64 {
+	if a.DueAt == nil || !t.After(*a.DueAt) || a.LatePenaltyPerDay <= 0 {
+		return 0
+	}
+
+	days := math.Ceil(t.Sub(*a.DueAt).Hours() / 24)
+	limit := a.MaxLatePenalty
+	if limit <= 0 || limit > 100 {
+		limit = 100
+	}
+	return math.Min(days*a.LatePenaltyPerDay, limit)
+}
+
+func (o *Organization) BeforeCreate(tx *gorm.DB) error {
+	if o.ID == "" {
+		o.ID = uuid.New().String()
+	}
+	return nil
+}
+
+func (c *Class) BeforeCreate(tx *gorm.DB) error {
+	if c.ID == "" {
+		c.ID = uuid.New().String()
+	}
+	return nil
+}
+
+func (m *ClassMember) BeforeCreate(tx *gorm.DB) error {
+	if m.ID == "" {
+		m.ID = uuid.New().String()
+	}
+	return nil
+}
+
+func (a *Assignment) BeforeCreate(tx *gorm.DB) error {
+	if a.ID == "" {
+		a.ID = uuid.New().String()
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package entities
 
 import (
+	"math"
 	"time"
 
 	"github.com/google/uuid"
@@ -10,18 +11,18 @@ import (
 // EssayTest represents a test configuration
 type EssayTest struct {
 	ID           string    `json:"id" gorm:"primaryKey;type:varchar(191)"`
-	Title        string    `json:"title" gorm:"not null"`
-	Description  string    `json:"description"`
+	Title        string    `json:"title" gorm:"not null;type:varchar(255);index:idx_essay_tests_search,class:FULLTEXT,option:WITH PARSER ngram"`
+	Description  string    `json:"description" gorm:"type:text;index:idx_essay_tests_search,class:FULLTEXT,option:WITH PARSER ngram"`
 	ReadingTime  string    `json:"reading_time"`
 	WritingTime  string    `json:"writing_time"`
 	TotalPoints  int       `json:"total_points"`
-	Difficulty   string    `json:"difficulty"`
-	Category     string    `json:"category"`
-	Participants int       `json:"participants"`
+	Difficulty   string    `json:"difficulty" gorm:"type:varchar(191);index"`
+	Category     string    `json:"category" gorm:"type:varchar(191);index"`
+	Participants int       `json:"participants" gorm:"index:idx_essay_tests_popularity"`
 	EssayText    string    `json:"essay_text" gorm:"type:text"`
 	Questions    []Question `json:"questions" gorm:"foreignKey:TestID"`
 	ScoringCriteria ScoringCriteria `json:"scoring_criteria" gorm:"embedded"`
-	CreatedAt    time.Time `json:"created_at"`
+	CreatedAt    time.Time `json:"created_at" gorm:"index"`
 	UpdatedAt    time.Time `json:"updated_at"`
 }
 
@@ -48,10 +49,18 @@ type ScoringCriteria struct {
 // Submission represents a user's essay submission
 type Submission struct {
 	ID        string    `json:"id" gorm:"primaryKey;type:varchar(191)"`
-	TestID    string    `json:"test_id" gorm:"type:varchar(191);index"`
+	TestID    string    `json:"test_id" gorm:"type:varchar(191);index;uniqueIndex:idx_submissions_ingest_key,priority:2"`
 	UserID    string    `json:"user_id,omitempty" gorm:"type:varchar(191);index"`
 	Answers   []Answer  `json:"answers" gorm:"foreignKey:SubmissionID"`
-	Status    string    `json:"status"` // pending, scored, failed
+	Status    string    `json:"status"` // pending, scoring, scored, failed
+	ClaimedBy string    `json:"-" gorm:"type:varchar(191)"` // 採点中のプロセス
+	ClaimedAt *time.Time `json:"-"` // 採点を始めた時刻。古いものは停止したプロセスの採点として別のプロセスが引き継ぐ
+	AssignmentID string `json:"assignment_id,omitempty" gorm:"type:varchar(191);index"`
+	Attempt   int       `json:"attempt,omitempty"`
+	Late      bool      `json:"late"`
+	LatePenaltyPercent float64 `json:"late_penalty_percent"`
+	IngestedBy string      `json:"ingested_by,omitempty" gorm:"type:varchar(191);uniqueIndex:idx_submissions_ingest_key,priority:1"` // 一括登録した教員
+	IdempotencyKey *string `json:"idempotency_key,omitempty" gorm:"type:varchar(191);uniqueIndex:idx_submissions_ingest_key,priority:3"` // 一括登録で同じ行を二重に登録しないためのキー（登録者・テストごと）
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
@@ -61,22 +70,35 @@ type Answer struct {
 	ID           string `json:"id" gorm:"primaryKey;type:varchar(191)"`
 	SubmissionID string `json:"submission_id" gorm:"type:varchar(191);index"`
 	QuestionID   string `json:"question_id" gorm:"type:varchar(191);index"`
-	Content      string `json:"content" gorm:"type:text"`
+	Content      string `json:"content" gorm:"type:text;index:idx_answers_search,class:FULLTEXT,option:WITH PARSER ngram"`
 	WordCount    int    `json:"word_count"`
+	TranscriptionID string `json:"transcription_id,omitempty" gorm:"type:varchar(191)"` // 手書き答案の写真から入力した場合
 }
 
 // ScoringResult represents the scoring result
 type ScoringResult struct {
 	ID           string           `json:"id" gorm:"primaryKey;type:varchar(191)"`
-	SubmissionID string           `json:"submission_id" gorm:"type:varchar(191);index"`
+	SubmissionID string           `json:"submission_id" gorm:"type:varchar(191);index;uniqueIndex:idx_scoring_results_submission_version,priority:1"`
 	TestID       string           `json:"test_id" gorm:"type:varchar(191);index"`
 	TestTitle    string           `json:"test_title"`
 	TotalScore   int              `json:"total_score"`
 	MaxScore     int              `json:"max_score"`
 	Percentage   float64          `json:"percentage"`
 	Details      []QuestionScore  `json:"details" gorm:"foreignKey:ResultID"`
-	Feedback     string           `json:"feedback" gorm:"type:text"`
-	ScoredBy     string           `json:"scored_by"` // ai, fallback
+	Feedback     string           `json:"feedback" gorm:"type:text;index:idx_scoring_results_search,class:FULLTEXT,option:WITH PARSER ngram"`
+	Strengths    []string         `json:"strengths" gorm:"type:text;serializer:json"`
+	Improvements []string         `json:"improvements" gorm:"type:text;serializer:json"`
+	OverallAssessment string      `json:"overall_assessment" gorm:"type:text;index:idx_scoring_results_search,class:FULLTEXT,option:WITH PARSER ngram"`
+	ScoredBy     string           `json:"scored_by"` // ai, fallback, human, hybrid
+	AutoTotalScore int            `json:"auto_total_score"`
+	LatePenalty  int              `json:"late_penalty"`
+	LatePenaltyPercent float64    `json:"late_penalty_percent"`
+	ReviewedBy   string           `json:"reviewed_by,omitempty" gorm:"type:varchar(191)"`
+	ReviewedAt   *time.Time       `json:"reviewed_at,omitempty"`
+	CalibrationRunID string       `json:"calibration_run_id,omitempty" gorm:"type:varchar(191)"`
+	Version      int              `json:"version" gorm:"default:1;uniqueIndex:idx_scoring_results_submission_version,priority:2"`
+	IsCurrent    bool             `json:"is_current" gorm:"default:true;index"`
+	ScorerVersion string          `json:"scorer_version"`
 	ExpiresAt    time.Time        `json:"expires_at"`
 	CreatedAt    time.Time        `json:"created_at"`
 	UpdatedAt    time.Time        `json:"updated_at"`
@@ -89,6 +111,7 @@ type QuestionScore struct {
 	QuestionID   string          `json:"question_id" gorm:"type:varchar(191);index"`
 	QuestionNum  int             `json:"question_num"`
 	Score        int             `json:"score"`
+	AutoScore    int             `json:"auto_score"`
 	MaxScore     int             `json:"max_score"`
 	Percentage   float64         `json:"percentage"`
 	CriteriaScores []CriteriaScore `json:"criteria_scores" gorm:"foreignKey:QuestionScoreID"`
@@ -105,6 +128,47 @@ type CriteriaScore struct {
 	MaxScore         int     `json:"max_score"`
 	Comment          string  `json:"comment"`
 	Reasoning        string  `json:"reasoning"`
+	Evidence         []string `json:"evidence" gorm:"type:text;serializer:json"`
+	Suggestions      []string `json:"suggestions" gorm:"type:text;serializer:json"`
+	AutoScore        int     `json:"auto_score"`
+	AutoComment      string  `json:"auto_comment"`
+	Overridden       bool    `json:"overridden"`
+}
+
+const (
+	ScoredByAI       = "ai"
+	ScoredByFallback = "fallback"
+	ScoredByHuman    = "human"
+	ScoredByHybrid   = "hybrid"
+)
+
+// SnapshotAutoScores keeps the automated scores so that they survive later human overrides
+func (sr *ScoringResult) SnapshotAutoScores() {
+	sr.AutoTotalScore = sr.TotalScore
+	for i := range sr.Details {
+		detail := &sr.Details[i]
+		detail.AutoScore = detail.Score
+		for j := range detail.CriteriaScores {
+			cs := &detail.CriteriaScores[j]
+			cs.AutoScore = cs.Score
+			cs.AutoComment = cs.Comment
+		}
+	}
+}
+
+// ApplyLatePenalty recomputes the total from the question scores and deducts the late penalty
+func (sr *ScoringResult) ApplyLatePenalty(percent float64) {
+	raw := 0
+	for _, detail := range sr.Details {
+		raw += detail.Score
+	}
+
+	sr.LatePenaltyPercent = percent
+	sr.LatePenalty = int(math.Round(float64(raw) * percent / 100))
+	sr.TotalScore = raw - sr.LatePenalty
+	if sr.MaxScore > 0 {
+		sr.Percentage = float64(sr.TotalScore) / float64(sr.MaxScore) * 100
+	}
 }
 
 // BeforeCreate hooks for UUID generation
@@ -0,0 +1,29 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ModelAnswer represents a teacher-authored exemplar answer at a given score level
+type ModelAnswer struct {
+	ID         string    `json:"id" gorm:"primaryKey;type:varchar(191)"`
+	TestID     string    `json:"test_id" gorm:"type:varchar(191);index"`
+	QuestionID string    `json:"question_id" gorm:"type:varchar(191);index"`
+	Title      string    `json:"title"`
+	Content    string    `json:"content" gorm:"type:text"`
+	Score      int       `json:"score"`
+	Commentary string    `json:"commentary" gorm:"type:text"`
+	CreatedBy  string    `json:"created_by" gorm:"type:varchar(191)"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+func (m *ModelAnswer) BeforeCreate(tx *gorm.DB) error {
+	if m.ID == "" {
+		m.ID = uuid.New().String()
+	}
+	return nil
+}
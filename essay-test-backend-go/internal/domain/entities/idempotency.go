@@ -0,0 +1,30 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// IdempotencyRecord claims an Idempotency-Key for a user's request and, once the request is done, keeps its response for retries
+type IdempotencyRecord struct {
+	ID          string     `json:"id" gorm:"primaryKey;type:varchar(191)"`
+	UserID      string     `json:"user_id" gorm:"type:varchar(191);uniqueIndex:idx_idempotency_user_key"`
+	Key         string     `json:"key" gorm:"type:varchar(191);uniqueIndex:idx_idempotency_user_key"`
+	Fingerprint string     `json:"fingerprint" gorm:"type:varchar(64)"` // メソッド・パス・ボディのSHA-256
+	StatusCode  int        `json:"status_code"`
+	ContentType string     `json:"content_type"`
+	Response    string     `json:"-" gorm:"type:mediumtext"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"` // 未設定なら処理中
+	ExpiresAt   time.Time  `json:"expires_at" gorm:"index"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+func (r *IdempotencyRecord) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == "" {
+		r.ID = uuid.New().String()
+	}
+	return nil
+}
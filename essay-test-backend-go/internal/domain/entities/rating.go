@@ -0,0 +1,71 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RatingSession represents the double-blind grading of a submission by several teachers
+type RatingSession struct {
+	ID            string     `json:"id" gorm:"primaryKey;type:varchar(191)"`
+	SubmissionID  string     `json:"submission_id" gorm:"type:varchar(191);uniqueIndex"`
+	TestID        string     `json:"test_id" gorm:"type:varchar(191);index"`
+	ResultID      string     `json:"result_id" gorm:"type:varchar(191)"`
+	Threshold     int        `json:"threshold"`
+	Status        string     `json:"status"`                                         // in_progress, needs_adjudication, finalized
+	Discrepancies []string   `json:"discrepancies" gorm:"type:text;serializer:json"` // 採点基準ID
+	CreatedBy     string     `json:"created_by" gorm:"type:varchar(191)"`
+	FinalizedAt   *time.Time `json:"finalized_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+// RaterAssignment represents a teacher assigned to a rating session
+type RaterAssignment struct {
+	ID           string               `json:"id" gorm:"primaryKey;type:varchar(191)"`
+	SessionID    string               `json:"session_id" gorm:"type:varchar(191);index"`
+	SubmissionID string               `json:"submission_id" gorm:"type:varchar(191);index"`
+	TestID       string               `json:"test_id" gorm:"type:varchar(191);index"`
+	RaterID      string               `json:"rater_id" gorm:"type:varchar(191);index"`
+	Role         string               `json:"role"`   // rater, adjudicator
+	Status       string               `json:"status"` // assigned, submitted
+	Scores       []RaterCriteriaScore `json:"scores" gorm:"type:text;serializer:json"`
+	SubmittedAt  *time.Time           `json:"submitted_at,omitempty"`
+	CreatedAt    time.Time            `json:"created_at"`
+	UpdatedAt    time.Time            `json:"updated_at"`
+}
+
+// RaterCriteriaScore represents one rater's score for a criteria score of the result
+type RaterCriteriaScore struct {
+	CriteriaScoreID string `json:"criteria_score_id"`
+	Score           int    `json:"score"`
+	Comment         string `json:"comment,omitempty"`
+}
+
+const (
+	RatingStatusInProgress        = "in_progress"
+	RatingStatusNeedsAdjudication = "needs_adjudication"
+	RatingStatusFinalized         = "finalized"
+
+	RaterRoleRater       = "rater"
+	RaterRoleAdjudicator = "adjudicator"
+
+	AssignmentStatusAssigned  = "assigned"
+	AssignmentStatusSubmitted = "submitted"
+)
+
+func (s *RatingSession) BeforeCreate(tx *gorm.DB) error {
+	if s.ID == "" {
+		s.ID = uuid.New().String()
+	}
+	return nil
+}
+
+func (a *RaterAssignment) BeforeCreate(tx *gorm.DB) error {
+	if a.ID == "" {
+		a.ID = uuid.New().String()
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RescoreJob represents a background re-scoring of stored submissions
+type RescoreJob struct {
+	ID               string     `json:"id" gorm:"primaryKey;type:varchar(191)"`
+	TestID           string     `json:"test_id,omitempty" gorm:"type:varchar(191);index"`
+	From             *time.Time `json:"from,omitempty"`
+	To               *time.Time `json:"to,omitempty"`
+	IncludeReviewed  bool       `json:"include_reviewed"`
+	ScorerVersion    string     `json:"scorer_version"`
+	Status           string     `json:"status"` // queued, running, completed, failed
+	Total            int        `json:"total"`
+	Processed        int        `json:"processed"`
+	Changed          int        `json:"changed"`
+	ReviewsDiscarded int        `json:"reviews_discarded"` // include_reviewedで教員の修正を破棄した件数
+	Skipped          int        `json:"skipped"`
+	Failed           int        `json:"failed"`
+	Error            string     `json:"error,omitempty" gorm:"type:text"`
+	CreatedBy        string     `json:"created_by" gorm:"type:varchar(191)"`
+	StartedAt        *time.Time `json:"started_at,omitempty"`
+	FinishedAt       *time.Time `json:"finished_at,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
+
+// RescoreDiff represents the score change of one submission caused by a re-scoring job
+type RescoreDiff struct {
+	ID               string                `json:"id" gorm:"primaryKey;type:varchar(191)"`
+	JobID            string                `json:"job_id" gorm:"type:varchar(191);index"`
+	SubmissionID     string                `json:"submission_id" gorm:"type:varchar(191)"`
+	OldResultID      string                `json:"old_result_id,omitempty" gorm:"type:varchar(191)"`
+	NewResultID      string                `json:"new_result_id" gorm:"type:varchar(191)"`
+	OldScorerVersion string                `json:"old_scorer_version"`
+	OldTotalScore    int                   `json:"old_total_score"`
+	NewTotalScore    int                   `json:"new_total_score"`
+	Delta            int                   `json:"delta"`
+	ReviewDiscarded  bool                  `json:"review_discarded"`
+	CriteriaChanges  []CriteriaScoreChange `json:"criteria_changes" gorm:"type:text;serializer:json"`
+	CreatedAt        time.Time             `json:"created_at"`
+}
+
+// CriteriaScoreChange represents the change of a criteria score between two result versions
+type CriteriaScoreChange struct {
+	QuestionNum  int    `json:"question_num"`
+	CriteriaName string `json:"criteria_name"`
+	OldScore     int    `json:"old_score"`
+	NewScore     int    `json:"new_score"`
+}
+
+const (
+	JobStatusQueued    = "queued"
+	JobStatusRunning   = "running"
+	JobStatusCompleted = "completed"
+	JobStatusFailed    = "failed"
+)
+
+func (j *RescoreJob) BeforeCreate(tx *gorm.DB) error {
+	if j.ID == "" {
+		j.ID = uuid.New().String()
+	}
+	return nil
+}
+
+func (d *RescoreDiff) BeforeCreate(tx *gorm.DB) error {
+	if d.ID == "" {
+		d.ID = uuid.New().String()
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ScoreReview represents a teacher's adjustments to a scoring result, kept as a draft until published
+type ScoreReview struct {
+	ID               string                 `json:"id" gorm:"primaryKey;type:varchar(191)"`
+	ResultID         string                 `json:"result_id" gorm:"type:varchar(191);uniqueIndex"`
+	SubmissionID     string                 `json:"submission_id" gorm:"type:varchar(191);index"`
+	ReviewerID       string                 `json:"reviewer_id" gorm:"type:varchar(191)"`
+	Status           string                 `json:"status"` // draft, published
+	Adjustments      []CriteriaAdjustment   `json:"adjustments" gorm:"type:text;serializer:json"`
+	QuestionComments []QuestionCommentDraft `json:"question_comments" gorm:"type:text;serializer:json"`
+	Feedback         *string                `json:"feedback,omitempty" gorm:"type:text"`
+	PublishedAt      *time.Time             `json:"published_at,omitempty"`
+	CreatedAt        time.Time              `json:"created_at"`
+	UpdatedAt        time.Time              `json:"updated_at"`
+}
+
+// CriteriaAdjustment represents the human score and comment for a criteria score
+type CriteriaAdjustment struct {
+	CriteriaScoreID string  `json:"criteria_score_id"`
+	Score           *int    `json:"score,omitempty"`
+	Comment         *string `json:"comment,omitempty"`
+}
+
+// QuestionCommentDraft represents a human comment for a question score
+type QuestionCommentDraft struct {
+	QuestionScoreID string `json:"question_score_id"`
+	Comment         string `json:"comment"`
+}
+
+// ScoreAuditLog represents a single change made to a scoring result by a person
+type ScoreAuditLog struct {
+	ID        string    `json:"id" gorm:"primaryKey;type:varchar(191)"`
+	ResultID  string    `json:"result_id" gorm:"type:varchar(191);index"`
+	ActorID   string    `json:"actor_id" gorm:"type:varchar(191);index"`
+	Action    string    `json:"action"` // draft_saved, criteria_score, criteria_comment, question_comment, feedback, published
+	Target    string    `json:"target"`
+	OldValue  string    `json:"old_value" gorm:"type:text"`
+	NewValue  string    `json:"new_value" gorm:"type:text"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+const (
+	ReviewStatusDraft     = "draft"
+	ReviewStatusPublished = "published"
+)
+
+func (r *ScoreReview) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == "" {
+		r.ID = uuid.New().String()
+	}
+	return nil
+}
+
+func (l *ScoreAuditLog) BeforeCreate(tx *gorm.DB) error {
+	if l.ID == "" {
+		l.ID = uuid.New().String()
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// AnswerFingerprint represents the MinHash signature of a stored answer
+type AnswerFingerprint struct {
+	AnswerID     string    `json:"answer_id" gorm:"primaryKey;type:varchar(191)"`
+	SubmissionID string    `json:"submission_id" gorm:"type:varchar(191);index"`
+	TestID       string    `json:"test_id" gorm:"type:varchar(191);index:idx_fingerprint_test_question"`
+	QuestionID   string    `json:"question_id" gorm:"type:varchar(191);index:idx_fingerprint_test_question"`
+	UserID       string    `json:"user_id" gorm:"type:varchar(191)"`
+	Signature    []uint64  `json:"signature" gorm:"type:text;serializer:json"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// SimilarityMatch represents an answer that is highly similar to another answer or to the essay text
+type SimilarityMatch struct {
+	ID                  string        `json:"id" gorm:"primaryKey;type:varchar(191)"`
+	TestID              string        `json:"test_id" gorm:"type:varchar(191);index"`
+	QuestionID          string        `json:"question_id" gorm:"type:varchar(191)"`
+	SubmissionID        string        `json:"submission_id" gorm:"type:varchar(191);index"`
+	AnswerID            string        `json:"answer_id" gorm:"type:varchar(191)"`
+	UserID              string        `json:"user_id" gorm:"type:varchar(191)"`
+	SourceType          string        `json:"source_type"` // submission, essay_text
+	MatchedSubmissionID string        `json:"matched_submission_id,omitempty" gorm:"type:varchar(191);index"`
+	MatchedAnswerID     string        `json:"matched_answer_id,omitempty" gorm:"type:varchar(191)"`
+	MatchedUserID       string        `json:"matched_user_id,omitempty" gorm:"type:varchar(191)"`
+	Similarity          float64       `json:"similarity"`
+	Spans               []OverlapSpan `json:"spans" gorm:"type:text;serializer:json"`
+	CreatedAt           time.Time     `json:"created_at"`
+}
+
+// OverlapSpan represents a copied range in rune offsets of the answer and of the matched text
+type OverlapSpan struct {
+	Start        int `json:"start"`
+	End          int `json:"end"`
+	MatchedStart int `json:"matched_start"`
+	MatchedEnd   int `json:"matched_end"`
+}
+
+const (
+	SimilaritySourceSubmission = "submission"
+	SimilaritySourceEssayText  = "essay_text"
+)
+
+func (m *SimilarityMatch) BeforeCreate(tx *gorm.DB) error {
+	if m.ID == "" {
+		m.ID = uuid.New().String()
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Transcription is a photographed answer read by OCR; its text becomes the answer only after the student confirms it
+type Transcription struct {
+	ID           string     `json:"id" gorm:"primaryKey;type:varchar(191)"`
+	UserID       string     `json:"user_id" gorm:"type:varchar(191);index"`
+	TestID       string     `json:"test_id" gorm:"type:varchar(191);index"`
+	QuestionID   string     `json:"question_id" gorm:"type:varchar(191)"`
+	StorageKey   string     `json:"-"`
+	ContentType  string     `json:"content_type"`
+	Size         int64      `json:"size"`
+	Engine       string     `json:"engine"`
+	OCRText      string     `json:"ocr_text" gorm:"type:text"`
+	Confidence   float64    `json:"confidence"`
+	Content      string     `json:"content" gorm:"type:text"` // 生徒が確認・修正した本文
+	Status       string     `json:"status"`                   // transcribed, confirmed
+	ConfirmedAt  *time.Time `json:"confirmed_at,omitempty"`
+	SubmissionID string     `json:"submission_id,omitempty" gorm:"type:varchar(191);index"` // 提出に使われた後は変更できない
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+const (
+	TranscriptionStatusTranscribed = "transcribed"
+	TranscriptionStatusConfirmed   = "confirmed"
+)
+
+func (t *Transcription) BeforeCreate(tx *gorm.DB) error {
+	if t.ID == "" {
+		t.ID = uuid.New().String()
+	}
+	return nil
+}
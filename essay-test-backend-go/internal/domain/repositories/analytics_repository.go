@@ -0,0 +1,15 @@
+package repositories
+
+import (
+	"context"
+	"essay-test-backend/internal/domain/entities"
+)
+
+type AnalyticsRepository interface {
+	Get(ctx context.Context, testID string) (*entities.TestAnalytics, error)
+	// Update applies fn to the locked analytics of a test, starting from empty analytics for a new test
+	Update(ctx context.Context, testID string, fn func(analytics *entities.TestAnalytics)) error
+	// Replace overwrites the analytics of a test with those returned by build, e.g. rebuilt from every current result.
+	// build runs while the analytics are locked as in Update, so no update is lost between the rebuild and the write.
+	Replace(ctx context.Context, testID string, build func() (*entities.TestAnalytics, error)) error
+}
@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"context"
+	"essay-test-backend/internal/domain/entities"
+)
+
+type AnnotationRepository interface {
+	Create(ctx context.Context, annotation *entities.Annotation) error
+	Update(ctx context.Context, annotation *entities.Annotation) error
+	Delete(ctx context.Context, id string) error
+	GetByID(ctx context.Context, id string) (*entities.Annotation, error)
+	GetBySubmissionID(ctx context.Context, submissionID string) ([]entities.Annotation, error)
+	// ReplaceAuto swaps the automated annotations of a submission, keeping those written by graders
+	ReplaceAuto(ctx context.Context, submissionID string, annotations []entities.Annotation) error
+}
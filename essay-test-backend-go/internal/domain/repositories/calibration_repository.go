@@ -0,0 +1,18 @@
+package repositories
+
+import (
+	"context"
+	"essay-test-backend/internal/domain/entities"
+)
+
+type CalibrationRepository interface {
+	CreateAnchor(ctx context.Context, anchor *entities.AnchorEssay) error
+	GetAnchorsByTestID(ctx context.Context, testID string) ([]entities.AnchorEssay, error)
+	DeleteAnchor(ctx context.Context, testID, id string) (bool, error)
+	CreateRun(ctx context.Context, run *entities.CalibrationRun) error
+	GetRunByID(ctx context.Context, id string) (*entities.CalibrationRun, error)
+	GetRunsByTestID(ctx context.Context, testID string) ([]entities.CalibrationRun, error)
+	GetCalibration(ctx context.Context, testID string) (*entities.TestCalibration, error)
+	SaveCalibration(ctx context.Context, calibration *entities.TestCalibration) error
+	DeleteCalibration(ctx context.Context, testID string) error
+}
@@ -0,0 +1,28 @@
+package repositories
+
+import (
+	"context"
+	"essay-test-backend/internal/domain/entities"
+)
+
+type ClassRepository interface {
+	CreateOrganization(ctx context.Context, organization *entities.Organization) error
+	GetOrganization(ctx context.Context, id string) (*entities.Organization, error)
+	ListOrganizations(ctx context.Context) ([]entities.Organization, error)
+
+	// CreateClass stores the class together with its initial members
+	CreateClass(ctx context.Context, class *entities.Class) error
+	UpdateClass(ctx context.Context, class *entities.Class) error
+	GetClass(ctx context.Context, id string) (*entities.Class, error)
+	GetClassByInviteCode(ctx context.Context, code string) (*entities.Class, error)
+	ListClassesByMember(ctx context.Context, userID, role string) ([]entities.Class, error)
+
+	AddMember(ctx context.Context, member *entities.ClassMember) error
+	GetMember(ctx context.Context, classID, userID string) (*entities.ClassMember, error)
+	ListMembers(ctx context.Context, classID string) ([]entities.ClassMember, error)
+
+	CreateAssignment(ctx context.Context, assignment *entities.Assignment) error
+	GetAssignment(ctx context.Context, id string) (*entities.Assignment, error)
+	ListAssignmentsByClass(ctx context.Context, classID string) ([]entities.Assignment, error)
+	ListAssignmentsByClasses(ctx context.Context, classIDs []string) ([]entities.Assignment, error)
+}
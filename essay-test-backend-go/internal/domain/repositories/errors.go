@@ -0,0 +1,15 @@
+package repositories
+
+import "errors"
+
+// ErrDuplicate is returned when a record violates a unique constraint
+var ErrDuplicate = errors.New("duplicate")
+
+// ErrAttemptLimitReached is returned when a student has no attempts left on an assignment
+var ErrAttemptLimitReached = errors.New("attempt limit reached")
+
+// ErrTranscriptionUsed is returned when a transcription given as an answer already backs another submission
+var ErrTranscriptionUsed = errors.New("transcription already used")
+
+// ErrResultSuperseded is returned when a change targets a scoring result that is no longer the current version
+var ErrResultSuperseded = errors.New("result superseded")
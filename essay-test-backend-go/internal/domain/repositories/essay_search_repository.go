@@ -0,0 +1,48 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"essay-test-backend/internal/domain/entities"
+)
+
+// EssaySearchQuery selects the submissions whose answers or feedback contain every term;
+// only the current results of scored submissions are searched
+type EssaySearchQuery struct {
+	Terms         []string
+	TestID        string
+	AssignmentIDs []string // nilなら絞り込まない。空なら何も一致しない
+	MinScore      *int
+	MaxScore      *int
+	From          *time.Time
+	To            *time.Time
+	After         *EssaySearchCursor // 前のページの最後のヒット
+	Limit         int
+}
+
+// EssaySearchCursor is the sort key of the last hit of a page; hits are ordered from the newest submission
+type EssaySearchCursor struct {
+	SubmittedAt time.Time
+	ResultID    string
+}
+
+// EssaySearchHit is a matching submission with the texts to take snippets from
+type EssaySearchHit struct {
+	SubmissionID      string
+	ResultID          string
+	TestID            string
+	TestTitle         string
+	UserID            string
+	TotalScore        int
+	MaxScore          int
+	SubmittedAt       time.Time
+	Feedback          string
+	OverallAssessment string
+	Answers           []entities.Answer
+}
+
+type EssaySearchRepository interface {
+	// Search leaves out the submissions whose multi-rater grading is not finalized
+	Search(ctx context.Context, query EssaySearchQuery) ([]EssaySearchHit, error)
+}
@@ -2,21 +2,66 @@ package repositories
 
 import (
 	"context"
+	"time"
 	"essay-test-backend/internal/domain/entities"
 )
 
 type EssayTestRepository interface {
-	GetAll(ctx context.Context) ([]entities.EssayTest, error)
+	// List returns a page of the catalog, with the questions of each test
+	List(ctx context.Context, query TestCatalogQuery) ([]entities.EssayTest, error)
 	GetByID(ctx context.Context, id string) (*entities.EssayTest, error)
 	Create(ctx context.Context, test *entities.EssayTest) error
 	Update(ctx context.Context, test *entities.EssayTest) error
 	Delete(ctx context.Context, id string) error
 }
 
+// Orders of the test catalog; both break ties by ID
+const (
+	TestSortNewest  = "newest"
+	TestSortPopular = "popular"
+)
+
+// TestCatalogQuery selects a page of the test catalog; empty filters match every test
+type TestCatalogQuery struct {
+	Category   string
+	Difficulty string
+	Search     string // タイトル・説明の全文検索
+	Sort       string
+	After      *TestCursor // 前のページの最後のテスト
+	Limit      int
+}
+
+// TestCursor is the sort key of the last test of a page
+type TestCursor struct {
+	ID           string
+	CreatedAt    time.Time
+	Participants int
+}
+
 type SubmissionRepository interface {
+	// Create returns ErrDuplicate when the submission violates a unique constraint. The transcriptions its answers use are
+	// linked to it in the same transaction, and ErrTranscriptionUsed is returned when one already backs another submission.
 	Create(ctx context.Context, submission *entities.Submission) error
+	// CreateAttempt numbers and stores an assignment submission, returning ErrAttemptLimitReached once the student
+	// has maxAttempts attempts (0 means unlimited); attempts by the same student are serialized so none exceeds the limit
+	CreateAttempt(ctx context.Context, submission *entities.Submission, maxAttempts int) error
 	GetByID(ctx context.Context, id string) (*entities.Submission, error)
+	// GetByIdempotencyKey finds a batch-ingested submission by the key its uploader gave for the test
+	GetByIdempotencyKey(ctx context.Context, ingestedBy, testID, key string) (*entities.Submission, error)
+	// GetClaimableIDs returns the submissions waiting for scoring and those claimed before staleBefore, oldest first
+	GetClaimableIDs(ctx context.Context, staleBefore time.Time) ([]string, error)
+	// Claim marks a pending submission, or one claimed before staleBefore, as being scored by owner and reports
+	// whether it succeeded; at most one process across the replicas holds the claim
+	Claim(ctx context.Context, id, owner string, staleBefore time.Time) (bool, error)
+	// Release returns a submission still claimed by owner to pending so that it is scored again
+	Release(ctx context.Context, id, owner string) error
 	GetByTestID(ctx context.Context, testID string) ([]entities.Submission, error)
+	GetIDs(ctx context.Context, testID string, from, to *time.Time) ([]string, error)
+	HasScoredSubmission(ctx context.Context, testID, userID string) (bool, error)
+	GetByIDs(ctx context.Context, ids []string) ([]entities.Submission, error)
+	GetScoredByAssignment(ctx context.Context, assignmentID string, userIDs []string) ([]entities.Submission, error)
+	CountAttempts(ctx context.Context, assignmentID, userID string) (int, error)
+	CountParticipants(ctx context.Context, testID string) (int, error)
 	Update(ctx context.Context, submission *entities.Submission) error
 }
 
@@ -24,6 +69,31 @@ type ScoringResultRepository interface {
 	Create(ctx context.Context, result *entities.ScoringResult) error
 	GetByID(ctx context.Context, id string) (*entities.ScoringResult, error)
 	GetBySubmissionID(ctx context.Context, submissionID string) (*entities.ScoringResult, error)
+	GetVersionsBySubmissionID(ctx context.Context, submissionID string) ([]entities.ScoringResult, error)
+	// GetCurrentBySubmissionIDs leaves out the submissions whose multi-rater grading is not finalized
+	GetCurrentBySubmissionIDs(ctx context.Context, submissionIDs []string) ([]entities.ScoringResult, error)
+	GetCurrentByTestID(ctx context.Context, testID string) ([]entities.ScoringResult, error)
+	// CountRank counts the current results of a test scoring above totalScore and all of them
+	CountRank(ctx context.Context, testID string, totalScore int) (higher int, total int, err error)
+	// StreamCurrent passes the current results in scope to fn in batches, ordered by submission time;
+	// like GetCriteriaColumns it leaves out the submissions whose multi-rater grading is not finalized
+	StreamCurrent(ctx context.Context, filter ResultExportFilter, batchSize int, fn func(results []entities.ScoringResult) error) error
+	GetCriteriaColumns(ctx context.Context, filter ResultExportFilter) ([]CriteriaColumn, error)
+	CreateVersion(ctx context.Context, result *entities.ScoringResult) error
 	GetAll(ctx context.Context) ([]entities.ScoringResult, error)
 	DeleteExpired(ctx context.Context) error
-} 
\ No newline at end of file
+}
+
+// ResultExportFilter selects the results of an export; a non-nil empty AssignmentIDs matches nothing
+type ResultExportFilter struct {
+	TestID        string
+	AssignmentIDs []string
+	From          *time.Time
+	To            *time.Time
+}
+
+// CriteriaColumn identifies a criteria of a question that appears in the results
+type CriteriaColumn struct {
+	QuestionNum  int
+	CriteriaName string
+}
@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"context"
+	"essay-test-backend/internal/domain/entities"
+	"time"
+)
+
+type IdempotencyRepository interface {
+	// Claim stores the record unless the user already has one with the same key, and reports whether it did
+	Claim(ctx context.Context, record *entities.IdempotencyRecord) (bool, error)
+	Get(ctx context.Context, userID, key string) (*entities.IdempotencyRecord, error)
+	Update(ctx context.Context, record *entities.IdempotencyRecord) error
+	Delete(ctx context.Context, id string) error
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+}
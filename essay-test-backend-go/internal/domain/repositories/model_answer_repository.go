@@ -0,0 +1,14 @@
+package repositories
+
+import (
+	"context"
+	"essay-test-backend/internal/domain/entities"
+)
+
+type ModelAnswerRepository interface {
+	Create(ctx context.Context, answer *entities.ModelAnswer) error
+	Update(ctx context.Context, answer *entities.ModelAnswer) error
+	Delete(ctx context.Context, id string) error
+	GetByID(ctx context.Context, id string) (*entities.ModelAnswer, error)
+	GetByTestID(ctx context.Context, testID string) ([]entities.ModelAnswer, error)
+}
@@ -0,0 +1,20 @@
+package repositories
+
+import (
+	"context"
+	"essay-test-backend/internal/domain/entities"
+)
+
+type RatingRepository interface {
+	CreateSession(ctx context.Context, session *entities.RatingSession, assignments []entities.RaterAssignment) error
+	GetSessionBySubmissionID(ctx context.Context, submissionID string) (*entities.RatingSession, error)
+	GetSessionsByTestID(ctx context.Context, testID string) ([]entities.RatingSession, error)
+	UpdateSession(ctx context.Context, session *entities.RatingSession) error
+	// FinalizeSession marks the session finalized unless it already is, and reports whether this call did so
+	FinalizeSession(ctx context.Context, session *entities.RatingSession) (bool, error)
+	CreateAssignment(ctx context.Context, assignment *entities.RaterAssignment) error
+	GetAssignmentByID(ctx context.Context, id string) (*entities.RaterAssignment, error)
+	GetAssignmentsBySessionID(ctx context.Context, sessionID string) ([]entities.RaterAssignment, error)
+	GetAssignmentsByRaterID(ctx context.Context, raterID string) ([]entities.RaterAssignment, error)
+	UpdateAssignment(ctx context.Context, assignment *entities.RaterAssignment) error
+}
@@ -0,0 +1,17 @@
+package repositories
+
+import (
+	"context"
+	"essay-test-backend/internal/domain/entities"
+)
+
+type RescoreJobRepository interface {
+	Create(ctx context.Context, job *entities.RescoreJob) error
+	Update(ctx context.Context, job *entities.RescoreJob) error
+	GetByID(ctx context.Context, id string) (*entities.RescoreJob, error)
+	List(ctx context.Context, limit int) ([]entities.RescoreJob, error)
+	// GetUnfinished returns the queued and running jobs, oldest first
+	GetUnfinished(ctx context.Context) ([]entities.RescoreJob, error)
+	CreateDiff(ctx context.Context, diff *entities.RescoreDiff) error
+	GetDiffsByJobID(ctx context.Context, jobID string) ([]entities.RescoreDiff, error)
+}
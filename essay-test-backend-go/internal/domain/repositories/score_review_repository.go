@@ -0,0 +1,14 @@
+package repositories
+
+import (
+	"context"
+	"essay-test-backend/internal/domain/entities"
+)
+
+type ScoreReviewRepository interface {
+	GetByResultID(ctx context.Context, resultID string) (*entities.ScoreReview, error)
+	Save(ctx context.Context, review *entities.ScoreReview, logs []entities.ScoreAuditLog) error
+	// Publish returns ErrResultSuperseded when a newer version of the result has been stored
+	Publish(ctx context.Context, review *entities.ScoreReview, result *entities.ScoringResult, logs []entities.ScoreAuditLog) error
+	GetAuditLogs(ctx context.Context, resultID string) ([]entities.ScoreAuditLog, error)
+}
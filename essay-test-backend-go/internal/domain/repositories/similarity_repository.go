@@ -0,0 +1,15 @@
+package repositories
+
+import (
+	"context"
+	"essay-test-backend/internal/domain/entities"
+)
+
+type SimilarityRepository interface {
+	SaveFingerprints(ctx context.Context, fingerprints []entities.AnswerFingerprint) error
+	GetFingerprintsByQuestion(ctx context.Context, testID, questionID string) ([]entities.AnswerFingerprint, error)
+	CreateMatches(ctx context.Context, matches []entities.SimilarityMatch) error
+	GetMatchesByTestID(ctx context.Context, testID string, minSimilarity float64) ([]entities.SimilarityMatch, error)
+	GetMatchesBySubmissionID(ctx context.Context, submissionID string) ([]entities.SimilarityMatch, error)
+	DeleteByTestID(ctx context.Context, testID string) error
+}
@@ -0,0 +1,13 @@
+package repositories
+
+import (
+	"context"
+	"essay-test-backend/internal/domain/entities"
+)
+
+type TranscriptionRepository interface {
+	Create(ctx context.Context, transcription *entities.Transcription) error
+	GetByID(ctx context.Context, id string) (*entities.Transcription, error)
+	// Update stores the confirmed text, returning ErrTranscriptionUsed once the transcription backs a submission
+	Update(ctx context.Context, transcription *entities.Transcription) error
+}
@@ -0,0 +1,15 @@
+package services
+
+import "essay-test-backend/internal/domain/entities"
+
+// FeedbackRefresh tells FeedbackGenerator which parts of a result a person changed
+type FeedbackRefresh struct {
+	Rescored     []string // 点数が変わった採点基準のID
+	KeepComments []string // 教員がコメントを書いた採点基準のID。講評を差し替えない
+	KeepFeedback bool     // 教員が全体の講評を書いた場合は差し替えない
+}
+
+// FeedbackGenerator rebuilds the score-dependent feedback of a result after its scores were changed by hand
+type FeedbackGenerator interface {
+	RefreshFeedback(result *entities.ScoringResult, refresh FeedbackRefresh)
+}
@@ -0,0 +1,13 @@
+package services
+
+import (
+	"context"
+	"io"
+)
+
+// FileStorage keeps uploaded files under keys chosen by the application, such as "transcriptions/<id>.jpg"
+type FileStorage interface {
+	Save(ctx context.Context, key string, r io.Reader) error
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
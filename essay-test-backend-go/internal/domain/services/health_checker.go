@@ -0,0 +1,8 @@
+package services
+
+import "context"
+
+// HealthChecker is implemented by dependencies that can tell whether they are currently usable
+type HealthChecker interface {
+	CheckHealth(ctx context.Context) error
+}
@@ -0,0 +1,16 @@
+package services
+
+import "context"
+
+// OCRResult is the text read from an image; Confidence ranges from 0 to 1
+type OCRResult struct {
+	Text       string
+	Confidence float64
+}
+
+// OCRService transcribes photographed handwriting
+type OCRService interface {
+	Transcribe(ctx context.Context, image []byte, contentType string) (*OCRResult, error)
+	// Engine names the implementation recorded with each transcription
+	Engine() string
+}
@@ -0,0 +1,21 @@
+package services
+
+import (
+	"context"
+	"essay-test-backend/internal/domain/entities"
+)
+
+// ResultReport holds everything printed on a student's score report
+type ResultReport struct {
+	Result     *entities.ScoringResult
+	Test       *entities.EssayTest
+	Submission *entities.Submission
+	Rank       int     // 同じテストの現在の結果の中での順位
+	Takers     int     // 同じテストの現在の結果の件数
+	Percentile float64 // 自分以下の得点の割合（%）
+}
+
+// ReportRenderer renders documents for download
+type ReportRenderer interface {
+	RenderResultReport(ctx context.Context, report *ResultReport) ([]byte, error)
+}
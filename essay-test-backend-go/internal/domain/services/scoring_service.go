@@ -7,4 +7,6 @@ import (
 
 type ScoringService interface {
 	ScoreSubmission(ctx context.Context, submission *entities.Submission, test *entities.EssayTest) (*entities.ScoringResult, error)
+	// Version identifies the scoring logic so that stored results can be traced and re-scored
+	Version() string
 } 
\ No newline at end of file
@@ -0,0 +1,17 @@
+package services
+
+import (
+	"context"
+	"essay-test-backend/internal/domain/entities"
+)
+
+// SubmissionListener is notified after a submission has been scored and its result stored
+type SubmissionListener interface {
+	OnSubmissionScored(ctx context.Context, submission *entities.Submission, test *entities.EssayTest, result *entities.ScoringResult)
+}
+
+// ScoreChangeListener is notified after the scores of an already scored submission have changed,
+// e.g. by a published review, a finalized rating or a re-score
+type ScoreChangeListener interface {
+	OnScoresChanged(ctx context.Context, result *entities.ScoringResult)
+}
@@ -4,32 +4,72 @@ import (
 	"essay-test-backend/internal/domain/entities"
 	"essay-test-backend/pkg/config"
 
+	"go.uber.org/zap"
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
-	"gorm.io/gorm/logger"
+	otelgorm "gorm.io/plugin/opentelemetry/tracing"
 )
 
-func NewMySQLConnection(cfg config.DatabaseConfig) (*gorm.DB, error) {
+func NewMySQLConnection(cfg config.DatabaseConfig, logger *zap.Logger) (*gorm.DB, error) {
 	dsn := cfg.GetDSN()
 	
 	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{
-		Logger: logger.Default.LogMode(logger.Info),
+		Logger: NewGormLogger(logger),
+		// 一意制約の違反をgorm.ErrDuplicatedKeyとして返す
+		TranslateError: true,
 	})
 	if err != nil {
 		return nil, err
 	}
 
+	// クエリごとのスパンを記録する（回答本文がスパンに残らないよう、パラメータは含めない）
+	if err := db.Use(otelgorm.NewPlugin(otelgorm.WithoutQueryVariables(), otelgorm.WithoutMetrics())); err != nil {
+		return nil, err
+	}
+
 	return db, nil
 }
 
+// models are the tables managed by Migrate
+var models = []interface{}{
+	&entities.EssayTest{},
+	&entities.Question{},
+	&entities.Submission{},
+	&entities.Answer{},
+	&entities.ScoringResult{},
+	&entities.QuestionScore{},
+	&entities.CriteriaScore{},
+	&entities.AnswerFingerprint{},
+	&entities.SimilarityMatch{},
+	&entities.ScoreReview{},
+	&entities.ScoreAuditLog{},
+	&entities.RatingSession{},
+	&entities.RaterAssignment{},
+	&entities.AnchorEssay{},
+	&entities.CalibrationRun{},
+	&entities.TestCalibration{},
+	&entities.RescoreJob{},
+	&entities.RescoreDiff{},
+	&entities.ModelAnswer{},
+	&entities.Annotation{},
+	&entities.Organization{},
+	&entities.Class{},
+	&entities.ClassMember{},
+	&entities.Assignment{},
+	&entities.TestAnalytics{},
+	&entities.Transcription{},
+	&entities.IdempotencyRecord{},
+}
+
+// 一括登録のキーを登録者・テストごとの一意制約に移す前の、キー単独の一意インデックス
+const legacyIdempotencyKeyIndex = "idx_submissions_idempotency_key"
+
 func Migrate(db *gorm.DB) error {
-	return db.AutoMigrate(
-		&entities.EssayTest{},
-		&entities.Question{},
-		&entities.Submission{},
-		&entities.Answer{},
-		&entities.ScoringResult{},
-		&entities.QuestionScore{},
-		&entities.CriteriaScore{},
-	)
+	if err := db.AutoMigrate(models...); err != nil {
+		return err
+	}
+	if db.Migrator().HasIndex(&entities.Submission{}, legacyIdempotencyKeyIndex) {
+		return db.Migrator().DropIndex(&entities.Submission{}, legacyIdempotencyKeyIndex)
+	}
+	return nil
 } 
\ No newline at end of file
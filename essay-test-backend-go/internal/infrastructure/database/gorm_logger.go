@@ -0,0 +1,77 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"essay-test-backend/pkg/logger"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+const slowQueryThreshold = 200 * time.Millisecond
+
+// gormLogger writes gorm's logs through zap with the request fields of the query's context.
+// Bound values are never logged, since they include essay answers.
+type gormLogger struct {
+	logger *zap.Logger
+	level  gormlogger.LogLevel
+}
+
+// NewGormLogger returns a gorm logger that reports failed and slow queries, and every query at debug level
+func NewGormLogger(l *zap.Logger) gormlogger.Interface {
+	return &gormLogger{logger: l.Named("gorm"), level: gormlogger.Info}
+}
+
+func (g *gormLogger) LogMode(level gormlogger.LogLevel) gormlogger.Interface {
+	clone := *g
+	clone.level = level
+	return &clone
+}
+
+func (g *gormLogger) Info(ctx context.Context, msg string, data ...interface{}) {
+	if g.level >= gormlogger.Info {
+		logger.FromContext(ctx, g.logger).Info(fmt.Sprintf(msg, data...))
+	}
+}
+
+func (g *gormLogger) Warn(ctx context.Context, msg string, data ...interface{}) {
+	if g.level >= gormlogger.Warn {
+		logger.FromContext(ctx, g.logger).Warn(fmt.Sprintf(msg, data...))
+	}
+}
+
+func (g *gormLogger) Error(ctx context.Context, msg string, data ...interface{}) {
+	if g.level >= gormlogger.Error {
+		logger.FromContext(ctx, g.logger).Error(fmt.Sprintf(msg, data...))
+	}
+}
+
+func (g *gormLogger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	if g.level <= gormlogger.Silent {
+		return
+	}
+	elapsed := time.Since(begin)
+	log := logger.FromContext(ctx, g.logger)
+
+	switch {
+	case err != nil && !errors.Is(err, gorm.ErrRecordNotFound) && g.level >= gormlogger.Error:
+		sql, rows := fc()
+		log.Error("クエリ失敗", zap.Error(err), zap.String("sql", sql), zap.Int64("rows", rows), zap.Duration("elapsed", elapsed))
+	case elapsed > slowQueryThreshold && g.level >= gormlogger.Warn:
+		sql, rows := fc()
+		log.Warn("遅いクエリ", zap.String("sql", sql), zap.Int64("rows", rows), zap.Duration("elapsed", elapsed))
+	case g.level >= gormlogger.Info && log.Core().Enabled(zap.DebugLevel):
+		sql, rows := fc()
+		log.Debug("クエリ実行", zap.String("sql", sql), zap.Int64("rows", rows), zap.Duration("elapsed", elapsed))
+	}
+}
+
+// ParamsFilter drops the bound values so logged SQL keeps its placeholders
+func (g *gormLogger) ParamsFilter(ctx context.Context, sql string, params ...interface{}) (string, []interface{}) {
+	return sql, nil
+}
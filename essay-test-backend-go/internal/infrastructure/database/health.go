@@ -0,0 +1,63 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"essay-test-backend/internal/domain/services"
+
+	"gorm.io/gorm"
+)
+
+type connectionHealthChecker struct {
+	db *gorm.DB
+}
+
+// NewConnectionHealthChecker reports whether the database answers a ping
+func NewConnectionHealthChecker(db *gorm.DB) services.HealthChecker {
+	return &connectionHealthChecker{db: db}
+}
+
+func (h *connectionHealthChecker) CheckHealth(ctx context.Context) error {
+	sqlDB, err := h.db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
+}
+
+type migrationHealthChecker struct {
+	db *gorm.DB
+}
+
+// NewMigrationHealthChecker reports whether every table created by Migrate exists
+func NewMigrationHealthChecker(db *gorm.DB) services.HealthChecker {
+	return &migrationHealthChecker{db: db}
+}
+
+func (h *migrationHealthChecker) CheckHealth(ctx context.Context) error {
+	tables, err := h.db.WithContext(ctx).Migrator().GetTables()
+	if err != nil {
+		return err
+	}
+	existing := make(map[string]bool, len(tables))
+	for _, table := range tables {
+		existing[table] = true
+	}
+
+	var missing []string
+	for _, model := range models {
+		stmt := &gorm.Statement{DB: h.db}
+		if err := stmt.Parse(model); err != nil {
+			return err
+		}
+		if !existing[stmt.Schema.Table] {
+			missing = append(missing, stmt.Schema.Table)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("missing tables: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package database
+
+import (
+	"context"
+
+	"essay-test-backend/internal/domain/entities"
+	"essay-test-backend/internal/domain/repositories"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type mysqlAnalyticsRepository struct {
+	db *gorm.DB
+}
+
+func NewMySQLAnalyticsRepository(db *gorm.DB) repositories.AnalyticsRepository {
+	return &mysqlAnalyticsRepository{db: db}
+}
+
+func (r *mysqlAnalyticsRepository) Get(ctx context.Context, testID string) (*entities.TestAnalytics, error) {
+	var analytics entities.TestAnalytics
+	err := r.db.WithContext(ctx).First(&analytics, "test_id = ?", testID).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &analytics, nil
+}
+
+func (r *mysqlAnalyticsRepository) Update(ctx context.Context, testID string, fn func(analytics *entities.TestAnalytics)) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 同じテストへの同時提出で集計が失われないよう行をロックする
+		var analytics entities.TestAnalytics
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&analytics, "test_id = ?", testID).Error
+		if err == gorm.ErrRecordNotFound {
+			analytics = entities.TestAnalytics{TestID: testID}
+		} else if err != nil {
+			return err
+		}
+
+		fn(&analytics)
+		return saveAnalytics(tx, &analytics)
+	})
+}
+
+func (r *mysqlAnalyticsRepository) Replace(ctx context.Context, testID string, build func() (*entities.TestAnalytics, error)) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Updateと同じ行ロックを取り、再集計の間に加わった提出が上書きで失われないようにする
+		var existing entities.TestAnalytics
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&existing, "test_id = ?", testID).Error
+		if err != nil && err != gorm.ErrRecordNotFound {
+			return err
+		}
+
+		analytics, err := build()
+		if err != nil {
+			return err
+		}
+		if !existing.CreatedAt.IsZero() {
+			analytics.CreatedAt = existing.CreatedAt
+		}
+		return saveAnalytics(tx, analytics)
+	})
+}
+
+// saveAnalytics also copies the participant count to the test so that the catalog shows real numbers
+func saveAnalytics(tx *gorm.DB, analytics *entities.TestAnalytics) error {
+	if err := tx.Save(analytics).Error; err != nil {
+		return err
+	}
+	return tx.Model(&entities.EssayTest{}).
+		Where("id = ?", analytics.TestID).
+		Update("participants", analytics.Participants).Error
+}
@@ -0,0 +1,63 @@
+package database
+
+import (
+	"context"
+	"essay-test-backend/internal/domain/entities"
+	"essay-test-backend/internal/domain/repositories"
+
+	"gorm.io/gorm"
+)
+
+type mysqlAnnotationRepository struct {
+	db *gorm.DB
+}
+
+func NewMySQLAnnotationRepository(db *gorm.DB) repositories.AnnotationRepository {
+	return &mysqlAnnotationRepository{db: db}
+}
+
+func (r *mysqlAnnotationRepository) Create(ctx context.Context, annotation *entities.Annotation) error {
+	return r.db.WithContext(ctx).Create(annotation).Error
+}
+
+func (r *mysqlAnnotationRepository) Update(ctx context.Context, annotation *entities.Annotation) error {
+	return r.db.WithContext(ctx).Save(annotation).Error
+}
+
+func (r *mysqlAnnotationRepository) Delete(ctx context.Context, id string) error {
+	return r.db.WithContext(ctx).Delete(&entities.Annotation{}, "id = ?", id).Error
+}
+
+func (r *mysqlAnnotationRepository) GetByID(ctx context.Context, id string) (*entities.Annotation, error) {
+	var annotation entities.Annotation
+	err := r.db.WithContext(ctx).First(&annotation, "id = ?", id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &annotation, nil
+}
+
+func (r *mysqlAnnotationRepository) GetBySubmissionID(ctx context.Context, submissionID string) ([]entities.Annotation, error) {
+	var annotations []entities.Annotation
+	err := r.db.WithContext(ctx).
+		Where("submission_id = ?", submissionID).
+		Order("answer_id ASC, start ASC").
+		Find(&annotations).Error
+	return annotations, err
+}
+
+func (r *mysqlAnnotationRepository) ReplaceAuto(ctx context.Context, submissionID string, annotations []entities.Annotation) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("submission_id = ? AND source = ?", submissionID, entities.AnnotationSourceAuto).
+			Delete(&entities.Annotation{}).Error; err != nil {
+			return err
+		}
+		if len(annotations) == 0 {
+			return nil
+		}
+		return tx.Create(&annotations).Error
+	})
+}
@@ -0,0 +1,80 @@
+package database
+
+import (
+	"context"
+	"essay-test-backend/internal/domain/entities"
+	"essay-test-backend/internal/domain/repositories"
+
+	"gorm.io/gorm"
+)
+
+type mysqlCalibrationRepository struct {
+	db *gorm.DB
+}
+
+func NewMySQLCalibrationRepository(db *gorm.DB) repositories.CalibrationRepository {
+	return &mysqlCalibrationRepository{db: db}
+}
+
+func (r *mysqlCalibrationRepository) CreateAnchor(ctx context.Context, anchor *entities.AnchorEssay) error {
+	return r.db.WithContext(ctx).Create(anchor).Error
+}
+
+func (r *mysqlCalibrationRepository) GetAnchorsByTestID(ctx context.Context, testID string) ([]entities.AnchorEssay, error) {
+	var anchors []entities.AnchorEssay
+	err := r.db.WithContext(ctx).
+		Where("test_id = ?", testID).
+		Order("created_at ASC").
+		Find(&anchors).Error
+	return anchors, err
+}
+
+func (r *mysqlCalibrationRepository) DeleteAnchor(ctx context.Context, testID, id string) (bool, error) {
+	tx := r.db.WithContext(ctx).Delete(&entities.AnchorEssay{}, "test_id = ? AND id = ?", testID, id)
+	return tx.RowsAffected > 0, tx.Error
+}
+
+func (r *mysqlCalibrationRepository) CreateRun(ctx context.Context, run *entities.CalibrationRun) error {
+	return r.db.WithContext(ctx).Create(run).Error
+}
+
+func (r *mysqlCalibrationRepository) GetRunByID(ctx context.Context, id string) (*entities.CalibrationRun, error) {
+	var run entities.CalibrationRun
+	err := r.db.WithContext(ctx).First(&run, "id = ?", id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &run, nil
+}
+
+func (r *mysqlCalibrationRepository) GetRunsByTestID(ctx context.Context, testID string) ([]entities.CalibrationRun, error) {
+	var runs []entities.CalibrationRun
+	err := r.db.WithContext(ctx).
+		Where("test_id = ?", testID).
+		Order("created_at DESC").
+		Find(&runs).Error
+	return runs, err
+}
+
+func (r *mysqlCalibrationRepository) GetCalibration(ctx context.Context, testID string) (*entities.TestCalibration, error) {
+	var calibration entities.TestCalibration
+	err := r.db.WithContext(ctx).First(&calibration, "test_id = ?", testID).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &calibration, nil
+}
+
+func (r *mysqlCalibrationRepository) SaveCalibration(ctx context.Context, calibration *entities.TestCalibration) error {
+	return r.db.WithContext(ctx).Save(calibration).Error
+}
+
+func (r *mysqlCalibrationRepository) DeleteCalibration(ctx context.Context, testID string) error {
+	return r.db.WithContext(ctx).Delete(&entities.TestCalibration{}, "test_id = ?", testID).Error
+}
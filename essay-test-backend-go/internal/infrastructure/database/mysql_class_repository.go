@@ -0,0 +1,140 @@
+package database
+
+import (
+	"context"
+	"essay-test-backend/internal/domain/entities"
+	"essay-test-backend/internal/domain/repositories"
+
+	"gorm.io/gorm"
+)
+
+type mysqlClassRepository struct {
+	db *gorm.DB
+}
+
+func NewMySQLClassRepository(db *gorm.DB) repositories.ClassRepository {
+	return &mysqlClassRepository{db: db}
+}
+
+func (r *mysqlClassRepository) CreateOrganization(ctx context.Context, organization *entities.Organization) error {
+	return r.db.WithContext(ctx).Create(organization).Error
+}
+
+func (r *mysqlClassRepository) GetOrganization(ctx context.Context, id string) (*entities.Organization, error) {
+	var organization entities.Organization
+	err := r.db.WithContext(ctx).First(&organization, "id = ?", id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &organization, nil
+}
+
+func (r *mysqlClassRepository) ListOrganizations(ctx context.Context) ([]entities.Organization, error) {
+	var organizations []entities.Organization
+	err := r.db.WithContext(ctx).Order("name ASC").Find(&organizations).Error
+	return organizations, err
+}
+
+func (r *mysqlClassRepository) CreateClass(ctx context.Context, class *entities.Class) error {
+	return r.db.WithContext(ctx).Create(class).Error
+}
+
+func (r *mysqlClassRepository) UpdateClass(ctx context.Context, class *entities.Class) error {
+	return r.db.WithContext(ctx).Omit("Members").Save(class).Error
+}
+
+func (r *mysqlClassRepository) GetClass(ctx context.Context, id string) (*entities.Class, error) {
+	var class entities.Class
+	err := r.db.WithContext(ctx).First(&class, "id = ?", id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &class, nil
+}
+
+func (r *mysqlClassRepository) GetClassByInviteCode(ctx context.Context, code string) (*entities.Class, error) {
+	var class entities.Class
+	err := r.db.WithContext(ctx).First(&class, "invite_code = ?", code).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &class, nil
+}
+
+func (r *mysqlClassRepository) ListClassesByMember(ctx context.Context, userID, role string) ([]entities.Class, error) {
+	var classes []entities.Class
+	query := r.db.WithContext(ctx).
+		Joins("JOIN class_members ON class_members.class_id = classes.id").
+		Where("class_members.user_id = ?", userID)
+	if role != "" {
+		query = query.Where("class_members.role = ?", role)
+	}
+	err := query.Order("classes.name ASC").Find(&classes).Error
+	return classes, err
+}
+
+func (r *mysqlClassRepository) AddMember(ctx context.Context, member *entities.ClassMember) error {
+	return r.db.WithContext(ctx).Create(member).Error
+}
+
+func (r *mysqlClassRepository) GetMember(ctx context.Context, classID, userID string) (*entities.ClassMember, error) {
+	var member entities.ClassMember
+	err := r.db.WithContext(ctx).First(&member, "class_id = ? AND user_id = ?", classID, userID).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &member, nil
+}
+
+func (r *mysqlClassRepository) ListMembers(ctx context.Context, classID string) ([]entities.ClassMember, error) {
+	var members []entities.ClassMember
+	err := r.db.WithContext(ctx).
+		Where("class_id = ?", classID).
+		Order("role ASC, user_id ASC").
+		Find(&members).Error
+	return members, err
+}
+
+func (r *mysqlClassRepository) CreateAssignment(ctx context.Context, assignment *entities.Assignment) error {
+	return r.db.WithContext(ctx).Create(assignment).Error
+}
+
+func (r *mysqlClassRepository) GetAssignment(ctx context.Context, id string) (*entities.Assignment, error) {
+	var assignment entities.Assignment
+	err := r.db.WithContext(ctx).First(&assignment, "id = ?", id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &assignment, nil
+}
+
+func (r *mysqlClassRepository) ListAssignmentsByClass(ctx context.Context, classID string) ([]entities.Assignment, error) {
+	return r.ListAssignmentsByClasses(ctx, []string{classID})
+}
+
+func (r *mysqlClassRepository) ListAssignmentsByClasses(ctx context.Context, classIDs []string) ([]entities.Assignment, error) {
+	var assignments []entities.Assignment
+	if len(classIDs) == 0 {
+		return assignments, nil
+	}
+	err := r.db.WithContext(ctx).
+		Where("class_id IN ?", classIDs).
+		Order("created_at ASC").
+		Find(&assignments).Error
+	return assignments, err
+}
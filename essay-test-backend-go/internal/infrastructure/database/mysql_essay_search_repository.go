@@ -0,0 +1,124 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"essay-test-backend/internal/domain/entities"
+	"essay-test-backend/internal/domain/repositories"
+
+	"gorm.io/gorm"
+)
+
+type mysqlEssaySearchRepository struct {
+	db *gorm.DB
+}
+
+// NewMySQLEssaySearchRepository searches with the ngram FULLTEXT indexes of answers and scoring_results
+func NewMySQLEssaySearchRepository(db *gorm.DB) repositories.EssaySearchRepository {
+	return &mysqlEssaySearchRepository{db: db}
+}
+
+type essaySearchRow struct {
+	SubmissionID      string
+	ResultID          string
+	TestID            string
+	TestTitle         string
+	UserID            string
+	TotalScore        int
+	MaxScore          int
+	SubmittedAt       time.Time
+	Feedback          string
+	OverallAssessment string
+}
+
+func (r *mysqlEssaySearchRepository) Search(ctx context.Context, q repositories.EssaySearchQuery) ([]repositories.EssaySearchHit, error) {
+	var terms []string
+	for _, term := range q.Terms {
+		if t := booleanModeTerms(term); t != "" {
+			terms = append(terms, t)
+		}
+	}
+	if len(terms) == 0 || (q.AssignmentIDs != nil && len(q.AssignmentIDs) == 0) {
+		return nil, nil
+	}
+
+	query := withoutOpenRating(r.db.WithContext(ctx).
+		Table("scoring_results").
+		Select(`submissions.id AS submission_id, scoring_results.id AS result_id, scoring_results.test_id, scoring_results.test_title,
+			submissions.user_id, scoring_results.total_score, scoring_results.max_score, submissions.created_at AS submitted_at,
+			scoring_results.feedback, scoring_results.overall_assessment`).
+		Joins("JOIN submissions ON submissions.id = scoring_results.submission_id").
+		Where("scoring_results.is_current = ?", true))
+	// 語ごとに回答のいずれかか講評に含まれればよく、すべての語を満たす提出物を返す（語は別々の欄にあってもよい）
+	for _, term := range terms {
+		query = query.Where(`(MATCH(scoring_results.feedback, scoring_results.overall_assessment) AGAINST (? IN BOOLEAN MODE)
+			OR EXISTS (SELECT 1 FROM answers WHERE answers.submission_id = submissions.id AND MATCH(answers.content) AGAINST (? IN BOOLEAN MODE)))`, term, term)
+	}
+	if q.TestID != "" {
+		query = query.Where("scoring_results.test_id = ?", q.TestID)
+	}
+	if q.AssignmentIDs != nil {
+		query = query.Where("submissions.assignment_id IN ?", q.AssignmentIDs)
+	}
+	if q.MinScore != nil {
+		query = query.Where("scoring_results.total_score >= ?", *q.MinScore)
+	}
+	if q.MaxScore != nil {
+		query = query.Where("scoring_results.total_score <= ?", *q.MaxScore)
+	}
+	if q.From != nil {
+		query = query.Where("submissions.created_at >= ?", *q.From)
+	}
+	if q.To != nil {
+		query = query.Where("submissions.created_at < ?", *q.To)
+	}
+	if q.After != nil {
+		query = query.Where("(submissions.created_at < ? OR (submissions.created_at = ? AND scoring_results.id < ?))", q.After.SubmittedAt, q.After.SubmittedAt, q.After.ResultID)
+	}
+
+	var rows []essaySearchRow
+	if err := query.
+		Order("submissions.created_at DESC, scoring_results.id DESC").
+		Limit(q.Limit).
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	// スニペットを作るために回答本文を読み込む
+	submissionIDs := make([]string, len(rows))
+	for i, row := range rows {
+		submissionIDs[i] = row.SubmissionID
+	}
+	var answers []entities.Answer
+	if err := r.db.WithContext(ctx).
+		Where("submission_id IN ?", submissionIDs).
+		Find(&answers).Error; err != nil {
+		return nil, err
+	}
+	answersBySubmission := make(map[string][]entities.Answer)
+	for _, a := range answers {
+		answersBySubmission[a.SubmissionID] = append(answersBySubmission[a.SubmissionID], a)
+	}
+
+	hits := make([]repositories.EssaySearchHit, len(rows))
+	for i, row := range rows {
+		hits[i] = repositories.EssaySearchHit{
+			SubmissionID:      row.SubmissionID,
+			ResultID:          row.ResultID,
+			TestID:            row.TestID,
+			TestTitle:         row.TestTitle,
+			UserID:            row.UserID,
+			TotalScore:        row.TotalScore,
+			MaxScore:          row.MaxScore,
+			SubmittedAt:       row.SubmittedAt,
+			Feedback:          row.Feedback,
+			OverallAssessment: row.OverallAssessment,
+			Answers:           answersBySubmission[row.SubmissionID],
+		}
+	}
+	return hits, nil
+}
@@ -2,8 +2,11 @@ package database
 
 import (
 	"context"
+	"strings"
+
 	"essay-test-backend/internal/domain/entities"
 	"essay-test-backend/internal/domain/repositories"
+	"essay-test-backend/pkg/snippet"
 
 	"gorm.io/gorm"
 )
@@ -16,12 +19,47 @@ func NewMySQLEssayTestRepository(db *gorm.DB) repositories.EssayTestRepository {
 	return &mysqlEssayTestRepository{db: db}
 }
 
-func (r *mysqlEssayTestRepository) GetAll(ctx context.Context) ([]entities.EssayTest, error) {
+func (r *mysqlEssayTestRepository) List(ctx context.Context, q repositories.TestCatalogQuery) ([]entities.EssayTest, error) {
+	query := r.db.WithContext(ctx).Preload("Questions")
+	if q.Category != "" {
+		query = query.Where("category = ?", q.Category)
+	}
+	if q.Difficulty != "" {
+		query = query.Where("difficulty = ?", q.Difficulty)
+	}
+	if terms := booleanModeTerms(q.Search); terms != "" {
+		query = query.Where("MATCH(title, description) AGAINST (? IN BOOLEAN MODE)", terms)
+	}
+
+	// 並び順のキーとIDによるキーセットページング
+	switch q.Sort {
+	case repositories.TestSortPopular:
+		if q.After != nil {
+			query = query.Where("(participants < ? OR (participants = ? AND id < ?))", q.After.Participants, q.After.Participants, q.After.ID)
+		}
+		query = query.Order("participants DESC, id DESC")
+	default:
+		if q.After != nil {
+			query = query.Where("(created_at < ? OR (created_at = ? AND id < ?))", q.After.CreatedAt, q.After.CreatedAt, q.After.ID)
+		}
+		query = query.Order("created_at DESC, id DESC")
+	}
+
 	var tests []entities.EssayTest
-	err := r.db.WithContext(ctx).Preload("Questions").Find(&tests).Error
+	err := query.Limit(q.Limit).Find(&tests).Error
 	return tests, err
 }
 
+// booleanModeTerms turns a search into a FULLTEXT boolean-mode query requiring every word as a phrase,
+// so that the ngram parser matches the words rather than any of their bigrams
+func booleanModeTerms(search string) string {
+	var terms []string
+	for _, word := range snippet.Terms(search) {
+		terms = append(terms, `+"`+word+`"`)
+	}
+	return strings.Join(terms, " ")
+}
+
 func (r *mysqlEssayTestRepository) GetByID(ctx context.Context, id string) (*entities.EssayTest, error) {
 	var test entities.EssayTest
 	err := r.db.WithContext(ctx).Preload("Questions").First(&test, "id = ?", id).Error
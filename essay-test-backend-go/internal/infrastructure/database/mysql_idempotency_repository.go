@@ -0,0 +1,53 @@
+package database
+
+import (
+	"context"
+	"essay-test-backend/internal/domain/entities"
+	"essay-test-backend/internal/domain/repositories"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type mysqlIdempotencyRepository struct {
+	db *gorm.DB
+}
+
+func NewMySQLIdempotencyRepository(db *gorm.DB) repositories.IdempotencyRepository {
+	return &mysqlIdempotencyRepository{db: db}
+}
+
+func (r *mysqlIdempotencyRepository) Claim(ctx context.Context, record *entities.IdempotencyRecord) (bool, error) {
+	// 同時に届いた再送のうち、一意制約で先に挿入できたものだけが処理する
+	result := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(record)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *mysqlIdempotencyRepository) Get(ctx context.Context, userID, key string) (*entities.IdempotencyRecord, error) {
+	var record entities.IdempotencyRecord
+	err := r.db.WithContext(ctx).First(&record, "user_id = ? AND `key` = ?", userID, key).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &record, nil
+}
+
+func (r *mysqlIdempotencyRepository) Update(ctx context.Context, record *entities.IdempotencyRecord) error {
+	return r.db.WithContext(ctx).Save(record).Error
+}
+
+func (r *mysqlIdempotencyRepository) Delete(ctx context.Context, id string) error {
+	return r.db.WithContext(ctx).Delete(&entities.IdempotencyRecord{}, "id = ?", id).Error
+}
+
+func (r *mysqlIdempotencyRepository) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Where("expires_at < ?", now).Delete(&entities.IdempotencyRecord{})
+	return result.RowsAffected, result.Error
+}
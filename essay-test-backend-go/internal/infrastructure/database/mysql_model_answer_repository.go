@@ -0,0 +1,50 @@
+package database
+
+import (
+	"context"
+	"essay-test-backend/internal/domain/entities"
+	"essay-test-backend/internal/domain/repositories"
+
+	"gorm.io/gorm"
+)
+
+type mysqlModelAnswerRepository struct {
+	db *gorm.DB
+}
+
+func NewMySQLModelAnswerRepository(db *gorm.DB) repositories.ModelAnswerRepository {
+	return &mysqlModelAnswerRepository{db: db}
+}
+
+func (r *mysqlModelAnswerRepository) Create(ctx context.Context, answer *entities.ModelAnswer) error {
+	return r.db.WithContext(ctx).Create(answer).Error
+}
+
+func (r *mysqlModelAnswerRepository) Update(ctx context.Context, answer *entities.ModelAnswer) error {
+	return r.db.WithContext(ctx).Save(answer).Error
+}
+
+func (r *mysqlModelAnswerRepository) Delete(ctx context.Context, id string) error {
+	return r.db.WithContext(ctx).Delete(&entities.ModelAnswer{}, "id = ?", id).Error
+}
+
+func (r *mysqlModelAnswerRepository) GetByID(ctx context.Context, id string) (*entities.ModelAnswer, error) {
+	var answer entities.ModelAnswer
+	err := r.db.WithContext(ctx).First(&answer, "id = ?", id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &answer, nil
+}
+
+func (r *mysqlModelAnswerRepository) GetByTestID(ctx context.Context, testID string) ([]entities.ModelAnswer, error) {
+	var answers []entities.ModelAnswer
+	err := r.db.WithContext(ctx).
+		Where("test_id = ?", testID).
+		Order("question_id ASC, score ASC").
+		Find(&answers).Error
+	return answers, err
+}
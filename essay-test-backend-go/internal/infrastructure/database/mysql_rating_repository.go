@@ -0,0 +1,104 @@
+package database
+
+import (
+	"context"
+	"essay-test-backend/internal/domain/entities"
+	"essay-test-backend/internal/domain/repositories"
+
+	"gorm.io/gorm"
+)
+
+type mysqlRatingRepository struct {
+	db *gorm.DB
+}
+
+func NewMySQLRatingRepository(db *gorm.DB) repositories.RatingRepository {
+	return &mysqlRatingRepository{db: db}
+}
+
+// withoutOpenRating leaves out the results of submissions still being graded blind by several raters,
+// so their interim scores are not shown before the rating is finalized
+func withoutOpenRating(query *gorm.DB) *gorm.DB {
+	return query.Where(`NOT EXISTS (SELECT 1 FROM rating_sessions
+		WHERE rating_sessions.submission_id = scoring_results.submission_id AND rating_sessions.status <> ?)`, entities.RatingStatusFinalized)
+}
+
+func (r *mysqlRatingRepository) CreateSession(ctx context.Context, session *entities.RatingSession, assignments []entities.RaterAssignment) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(session).Error; err != nil {
+			return err
+		}
+		for i := range assignments {
+			assignments[i].SessionID = session.ID
+		}
+		return tx.Create(&assignments).Error
+	})
+}
+
+func (r *mysqlRatingRepository) GetSessionBySubmissionID(ctx context.Context, submissionID string) (*entities.RatingSession, error) {
+	var session entities.RatingSession
+	err := r.db.WithContext(ctx).First(&session, "submission_id = ?", submissionID).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &session, nil
+}
+
+func (r *mysqlRatingRepository) GetSessionsByTestID(ctx context.Context, testID string) ([]entities.RatingSession, error) {
+	var sessions []entities.RatingSession
+	err := r.db.WithContext(ctx).Where("test_id = ?", testID).Find(&sessions).Error
+	return sessions, err
+}
+
+func (r *mysqlRatingRepository) UpdateSession(ctx context.Context, session *entities.RatingSession) error {
+	return r.db.WithContext(ctx).Save(session).Error
+}
+
+func (r *mysqlRatingRepository) FinalizeSession(ctx context.Context, session *entities.RatingSession) (bool, error) {
+	result := r.db.WithContext(ctx).Model(session).
+		Where("status <> ?", entities.RatingStatusFinalized).
+		Select("status", "discrepancies", "finalized_at").
+		Updates(session)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *mysqlRatingRepository) CreateAssignment(ctx context.Context, assignment *entities.RaterAssignment) error {
+	return r.db.WithContext(ctx).Create(assignment).Error
+}
+
+func (r *mysqlRatingRepository) GetAssignmentByID(ctx context.Context, id string) (*entities.RaterAssignment, error) {
+	var assignment entities.RaterAssignment
+	err := r.db.WithContext(ctx).First(&assignment, "id = ?", id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &assignment, nil
+}
+
+func (r *mysqlRatingRepository) GetAssignmentsBySessionID(ctx context.Context, sessionID string) ([]entities.RaterAssignment, error) {
+	var assignments []entities.RaterAssignment
+	err := r.db.WithContext(ctx).
+		Where("session_id = ?", sessionID).
+		Order("created_at ASC").
+		Find(&assignments).Error
+	return assignments, err
+}
+
+func (r *mysqlRatingRepository) GetAssignmentsByRaterID(ctx context.Context, raterID string) ([]entities.RaterAssignment, error) {
+	var assignments []entities.RaterAssignment
+	err := r.db.WithContext(ctx).
+		Where("rater_id = ?", raterID).
+		Order("created_at DESC").
+		Find(&assignments).Error
+	return assignments, err
+}
+
+func (r *mysqlRatingRepository) UpdateAssignment(ctx context.Context, assignment *entities.RaterAssignment) error {
+	return r.db.WithContext(ctx).Save(assignment).Error
+}
@@ -0,0 +1,68 @@
+package database
+
+import (
+	"context"
+	"essay-test-backend/internal/domain/entities"
+	"essay-test-backend/internal/domain/repositories"
+
+	"gorm.io/gorm"
+)
+
+type mysqlRescoreJobRepository struct {
+	db *gorm.DB
+}
+
+func NewMySQLRescoreJobRepository(db *gorm.DB) repositories.RescoreJobRepository {
+	return &mysqlRescoreJobRepository{db: db}
+}
+
+func (r *mysqlRescoreJobRepository) Create(ctx context.Context, job *entities.RescoreJob) error {
+	return r.db.WithContext(ctx).Create(job).Error
+}
+
+func (r *mysqlRescoreJobRepository) Update(ctx context.Context, job *entities.RescoreJob) error {
+	return r.db.WithContext(ctx).Save(job).Error
+}
+
+func (r *mysqlRescoreJobRepository) GetByID(ctx context.Context, id string) (*entities.RescoreJob, error) {
+	var job entities.RescoreJob
+	err := r.db.WithContext(ctx).First(&job, "id = ?", id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &job, nil
+}
+
+func (r *mysqlRescoreJobRepository) List(ctx context.Context, limit int) ([]entities.RescoreJob, error) {
+	var jobs []entities.RescoreJob
+	err := r.db.WithContext(ctx).
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&jobs).Error
+	return jobs, err
+}
+
+func (r *mysqlRescoreJobRepository) GetUnfinished(ctx context.Context) ([]entities.RescoreJob, error) {
+	var jobs []entities.RescoreJob
+	err := r.db.WithContext(ctx).
+		Where("status IN ?", []string{entities.JobStatusQueued, entities.JobStatusRunning}).
+		Order("created_at ASC").
+		Find(&jobs).Error
+	return jobs, err
+}
+
+func (r *mysqlRescoreJobRepository) CreateDiff(ctx context.Context, diff *entities.RescoreDiff) error {
+	return r.db.WithContext(ctx).Create(diff).Error
+}
+
+func (r *mysqlRescoreJobRepository) GetDiffsByJobID(ctx context.Context, jobID string) ([]entities.RescoreDiff, error) {
+	var diffs []entities.RescoreDiff
+	err := r.db.WithContext(ctx).
+		Where("job_id = ?", jobID).
+		Order("created_at ASC").
+		Find(&diffs).Error
+	return diffs, err
+}
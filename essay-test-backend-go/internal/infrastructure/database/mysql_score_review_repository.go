@@ -0,0 +1,81 @@
+package database
+
+import (
+	"context"
+	"essay-test-backend/internal/domain/entities"
+	"essay-test-backend/internal/domain/repositories"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type mysqlScoreReviewRepository struct {
+	db *gorm.DB
+}
+
+func NewMySQLScoreReviewRepository(db *gorm.DB) repositories.ScoreReviewRepository {
+	return &mysqlScoreReviewRepository{db: db}
+}
+
+func (r *mysqlScoreReviewRepository) GetByResultID(ctx context.Context, resultID string) (*entities.ScoreReview, error) {
+	var review entities.ScoreReview
+	err := r.db.WithContext(ctx).First(&review, "result_id = ?", resultID).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &review, nil
+}
+
+func (r *mysqlScoreReviewRepository) Save(ctx context.Context, review *entities.ScoreReview, logs []entities.ScoreAuditLog) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(review).Error; err != nil {
+			return err
+		}
+		return createAuditLogs(tx, logs)
+	})
+}
+
+func (r *mysqlScoreReviewRepository) Publish(ctx context.Context, review *entities.ScoreReview, result *entities.ScoringResult, logs []entities.ScoreAuditLog) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 再採点で新しい版ができた結果には公開しない。行を固定してCreateVersionと順番に処理する
+		var current bool
+		if err := tx.Model(&entities.ScoringResult{}).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", result.ID).
+			Select("is_current").
+			Scan(&current).Error; err != nil {
+			return err
+		}
+		if !current {
+			return repositories.ErrResultSuperseded
+		}
+
+		// 問題別・採点基準別の結果もまとめて更新する
+		if err := tx.Session(&gorm.Session{FullSaveAssociations: true}).Save(result).Error; err != nil {
+			return err
+		}
+		if err := tx.Save(review).Error; err != nil {
+			return err
+		}
+		return createAuditLogs(tx, logs)
+	})
+}
+
+func (r *mysqlScoreReviewRepository) GetAuditLogs(ctx context.Context, resultID string) ([]entities.ScoreAuditLog, error) {
+	var logs []entities.ScoreAuditLog
+	err := r.db.WithContext(ctx).
+		Where("result_id = ?", resultID).
+		Order("created_at ASC").
+		Find(&logs).Error
+	return logs, err
+}
+
+func createAuditLogs(tx *gorm.DB, logs []entities.ScoreAuditLog) error {
+	if len(logs) == 0 {
+		return nil
+	}
+	return tx.Create(&logs).Error
+}
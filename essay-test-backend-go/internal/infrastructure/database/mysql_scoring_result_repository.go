@@ -2,6 +2,7 @@ package database
 
 import (
 	"context"
+	"errors"
 	"time"
 	"essay-test-backend/internal/domain/entities"
 	"essay-test-backend/internal/domain/repositories"
@@ -45,6 +46,7 @@ func (r *mysqlScoringResultRepository) GetBySubmissionID(ctx context.Context, su
 	var result entities.ScoringResult
 	err := r.db.WithContext(ctx).
 		Preload("Details.CriteriaScores").
+		Where("is_current = ?", true).
 		First(&result, "submission_id = ?", submissionID).Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
@@ -61,11 +63,187 @@ func (r *mysqlScoringResultRepository) GetBySubmissionID(ctx context.Context, su
 	return &result, nil
 }
 
+func (r *mysqlScoringResultRepository) GetVersionsBySubmissionID(ctx context.Context, submissionID string) ([]entities.ScoringResult, error) {
+	var results []entities.ScoringResult
+	err := r.db.WithContext(ctx).
+		Preload("Details.CriteriaScores").
+		Where("submission_id = ?", submissionID).
+		Order("version ASC").
+		Find(&results).Error
+	return results, err
+}
+
+func (r *mysqlScoringResultRepository) GetCurrentBySubmissionIDs(ctx context.Context, submissionIDs []string) ([]entities.ScoringResult, error) {
+	var results []entities.ScoringResult
+	if len(submissionIDs) == 0 {
+		return results, nil
+	}
+	err := withoutOpenRating(r.db.WithContext(ctx)).
+		Where("submission_id IN ? AND is_current = ?", submissionIDs, true).
+		Find(&results).Error
+	return results, err
+}
+
+func (r *mysqlScoringResultRepository) GetCurrentByTestID(ctx context.Context, testID string) ([]entities.ScoringResult, error) {
+	var results []entities.ScoringResult
+	err := r.db.WithContext(ctx).
+		Preload("Details.CriteriaScores").
+		Where("test_id = ? AND is_current = ?", testID, true).
+		Find(&results).Error
+	return results, err
+}
+
+func (r *mysqlScoringResultRepository) CountRank(ctx context.Context, testID string, totalScore int) (int, int, error) {
+	var higher, total int64
+	current := r.db.WithContext(ctx).
+		Model(&entities.ScoringResult{}).
+		Where("test_id = ? AND is_current = ?", testID, true)
+	if err := current.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return 0, 0, err
+	}
+	if err := current.Session(&gorm.Session{}).Where("total_score > ?", totalScore).Count(&higher).Error; err != nil {
+		return 0, 0, err
+	}
+	return int(higher), int(total), nil
+}
+
+func (r *mysqlScoringResultRepository) StreamCurrent(ctx context.Context, filter repositories.ResultExportFilter, batchSize int, fn func(results []entities.ScoringResult) error) error {
+	if filter.AssignmentIDs != nil && len(filter.AssignmentIDs) == 0 {
+		return nil
+	}
+
+	// 提出日時とIDによるキーセットページングで、件数が多くても一定のメモリで読み出す
+	var last *exportKey
+	for {
+		query := r.exportScope(ctx, filter).
+			Select("scoring_results.id, submissions.created_at AS submitted_at").
+			Order("submissions.created_at ASC, scoring_results.id ASC").
+			Limit(batchSize)
+		if last != nil {
+			query = query.Where("(submissions.created_at > ? OR (submissions.created_at = ? AND scoring_results.id > ?))", last.SubmittedAt, last.SubmittedAt, last.ID)
+		}
+
+		var keys []exportKey
+		if err := query.Scan(&keys).Error; err != nil {
+			return err
+		}
+		if len(keys) == 0 {
+			return nil
+		}
+
+		ids := make([]string, len(keys))
+		for i, k := range keys {
+			ids[i] = k.ID
+		}
+		var loaded []entities.ScoringResult
+		if err := r.db.WithContext(ctx).
+			Preload("Details.CriteriaScores").
+			Where("id IN ?", ids).
+			Find(&loaded).Error; err != nil {
+			return err
+		}
+
+		// IN 句では順序が保証されないのでページの順に並べ直す
+		byID := make(map[string]entities.ScoringResult, len(loaded))
+		for _, result := range loaded {
+			byID[result.ID] = result
+		}
+		results := make([]entities.ScoringResult, 0, len(keys))
+		for _, k := range keys {
+			if result, ok := byID[k.ID]; ok {
+				results = append(results, result)
+			}
+		}
+		if err := fn(results); err != nil {
+			return err
+		}
+
+		if len(keys) < batchSize {
+			return nil
+		}
+		last = &keys[len(keys)-1]
+	}
+}
+
+func (r *mysqlScoringResultRepository) GetCriteriaColumns(ctx context.Context, filter repositories.ResultExportFilter) ([]repositories.CriteriaColumn, error) {
+	var columns []repositories.CriteriaColumn
+	if filter.AssignmentIDs != nil && len(filter.AssignmentIDs) == 0 {
+		return columns, nil
+	}
+	err := r.exportScope(ctx, filter).
+		Joins("JOIN question_scores ON question_scores.result_id = scoring_results.id").
+		Joins("JOIN criteria_scores ON criteria_scores.question_score_id = question_scores.id").
+		Distinct("question_scores.question_num", "criteria_scores.criteria_name").
+		Order("question_scores.question_num ASC, criteria_scores.criteria_name ASC").
+		Scan(&columns).Error
+	return columns, err
+}
+
+// exportKey is the paging key of a result in an export
+type exportKey struct {
+	ID          string
+	SubmittedAt time.Time
+}
+
+func (r *mysqlScoringResultRepository) exportScope(ctx context.Context, filter repositories.ResultExportFilter) *gorm.DB {
+	query := withoutOpenRating(r.db.WithContext(ctx).
+		Model(&entities.ScoringResult{}).
+		Joins("JOIN submissions ON submissions.id = scoring_results.submission_id").
+		Where("scoring_results.is_current = ?", true))
+	if filter.TestID != "" {
+		query = query.Where("scoring_results.test_id = ?", filter.TestID)
+	}
+	if filter.AssignmentIDs != nil {
+		query = query.Where("submissions.assignment_id IN ?", filter.AssignmentIDs)
+	}
+	if filter.From != nil {
+		query = query.Where("submissions.created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("submissions.created_at < ?", *filter.To)
+	}
+	return query
+}
+
+// 同じ提出物の版が同時に作られたときに版番号を取り直す回数
+const createVersionAttempts = 3
+
+func (r *mysqlScoringResultRepository) CreateVersion(ctx context.Context, result *entities.ScoringResult) error {
+	var err error
+	for attempt := 0; attempt < createVersionAttempts; attempt++ {
+		err = r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			var latest int
+			if err := tx.Model(&entities.ScoringResult{}).
+				Where("submission_id = ?", result.SubmissionID).
+				Select("COALESCE(MAX(version), 0)").
+				Scan(&latest).Error; err != nil {
+				return err
+			}
+
+			// 新しい版だけを現在の結果として扱う
+			if err := tx.Model(&entities.ScoringResult{}).
+				Where("submission_id = ? AND is_current = ?", result.SubmissionID, true).
+				Update("is_current", false).Error; err != nil {
+				return err
+			}
+
+			result.Version = latest + 1
+			result.IsCurrent = true
+			return tx.Create(result).Error
+		})
+		// (submission_id, version)の一意制約に当たったら、先に保存された版の次の番号でやり直す
+		if !errors.Is(err, gorm.ErrDuplicatedKey) {
+			return err
+		}
+	}
+	return err
+}
+
 func (r *mysqlScoringResultRepository) GetAll(ctx context.Context) ([]entities.ScoringResult, error) {
 	var results []entities.ScoringResult
 	err := r.db.WithContext(ctx).
 		Preload("Details.CriteriaScores").
-		Where("expires_at > ?", time.Now()).
+		Where("expires_at > ? AND is_current = ?", time.Now(), true).
 		Find(&results).Error
 	return results, err
 }
@@ -0,0 +1,69 @@
+package database
+
+import (
+	"context"
+	"essay-test-backend/internal/domain/entities"
+	"essay-test-backend/internal/domain/repositories"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type mysqlSimilarityRepository struct {
+	db *gorm.DB
+}
+
+func NewMySQLSimilarityRepository(db *gorm.DB) repositories.SimilarityRepository {
+	return &mysqlSimilarityRepository{db: db}
+}
+
+func (r *mysqlSimilarityRepository) SaveFingerprints(ctx context.Context, fingerprints []entities.AnswerFingerprint) error {
+	if len(fingerprints) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{UpdateAll: true}).
+		Create(&fingerprints).Error
+}
+
+func (r *mysqlSimilarityRepository) GetFingerprintsByQuestion(ctx context.Context, testID, questionID string) ([]entities.AnswerFingerprint, error) {
+	var fingerprints []entities.AnswerFingerprint
+	err := r.db.WithContext(ctx).
+		Where("test_id = ? AND question_id = ?", testID, questionID).
+		Find(&fingerprints).Error
+	return fingerprints, err
+}
+
+func (r *mysqlSimilarityRepository) CreateMatches(ctx context.Context, matches []entities.SimilarityMatch) error {
+	if len(matches) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Create(&matches).Error
+}
+
+func (r *mysqlSimilarityRepository) GetMatchesByTestID(ctx context.Context, testID string, minSimilarity float64) ([]entities.SimilarityMatch, error) {
+	var matches []entities.SimilarityMatch
+	err := r.db.WithContext(ctx).
+		Where("test_id = ? AND similarity >= ?", testID, minSimilarity).
+		Order("similarity DESC").
+		Find(&matches).Error
+	return matches, err
+}
+
+func (r *mysqlSimilarityRepository) GetMatchesBySubmissionID(ctx context.Context, submissionID string) ([]entities.SimilarityMatch, error) {
+	var matches []entities.SimilarityMatch
+	err := r.db.WithContext(ctx).
+		Where("submission_id = ? OR matched_submission_id = ?", submissionID, submissionID).
+		Order("similarity DESC").
+		Find(&matches).Error
+	return matches, err
+}
+
+func (r *mysqlSimilarityRepository) DeleteByTestID(ctx context.Context, testID string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("test_id = ?", testID).Delete(&entities.SimilarityMatch{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("test_id = ?", testID).Delete(&entities.AnswerFingerprint{}).Error
+	})
+}
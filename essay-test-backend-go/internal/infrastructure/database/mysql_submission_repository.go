@@ -2,10 +2,13 @@ package database
 
 import (
 	"context"
+	"errors"
+	"time"
 	"essay-test-backend/internal/domain/entities"
 	"essay-test-backend/internal/domain/repositories"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type mysqlSubmissionRepository struct {
@@ -17,7 +20,66 @@ func NewMySQLSubmissionRepository(db *gorm.DB) repositories.SubmissionRepository
 }
 
 func (r *mysqlSubmissionRepository) Create(ctx context.Context, submission *entities.Submission) error {
-	return r.db.WithContext(ctx).Create(submission).Error
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return createSubmission(tx, submission)
+	})
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return repositories.ErrDuplicate
+	}
+	return err
+}
+
+func (r *mysqlSubmissionRepository) CreateAttempt(ctx context.Context, submission *entities.Submission, maxAttempts int) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 課題の行を固定し、同時に届いた提出の回数の確認と保存を順番に行う
+		var assignmentID string
+		if err := tx.Model(&entities.Assignment{}).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", submission.AssignmentID).
+			Select("id").
+			Scan(&assignmentID).Error; err != nil {
+			return err
+		}
+
+		attempts, err := countAttempts(tx, submission.AssignmentID, submission.UserID)
+		if err != nil {
+			return err
+		}
+		if maxAttempts > 0 && attempts >= maxAttempts {
+			return repositories.ErrAttemptLimitReached
+		}
+
+		submission.Attempt = attempts + 1
+		return createSubmission(tx, submission)
+	})
+}
+
+// createSubmission stores the submission and links the transcriptions used as its answers,
+// so a transcription backs at most one submission even when two submits race
+func createSubmission(tx *gorm.DB, submission *entities.Submission) error {
+	if err := tx.Create(submission).Error; err != nil {
+		return err
+	}
+
+	var ids []string
+	for _, a := range submission.Answers {
+		if a.TranscriptionID != "" {
+			ids = append(ids, a.TranscriptionID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	result := tx.Model(&entities.Transcription{}).
+		Where("id IN ? AND submission_id = ?", ids, "").
+		Update("submission_id", submission.ID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected < int64(len(ids)) {
+		return repositories.ErrTranscriptionUsed
+	}
+	return nil
 }
 
 func (r *mysqlSubmissionRepository) GetByID(ctx context.Context, id string) (*entities.Submission, error) {
@@ -32,12 +94,141 @@ func (r *mysqlSubmissionRepository) GetByID(ctx context.Context, id string) (*en
 	return &submission, nil
 }
 
+func (r *mysqlSubmissionRepository) GetByIdempotencyKey(ctx context.Context, ingestedBy, testID, key string) (*entities.Submission, error) {
+	var submission entities.Submission
+	err := r.db.WithContext(ctx).First(&submission, "ingested_by = ? AND test_id = ? AND idempotency_key = ?", ingestedBy, testID, key).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &submission, nil
+}
+
+// GetClaimableIDs returns the submissions still waiting for scoring or whose claim went stale, oldest first
+func (r *mysqlSubmissionRepository) GetClaimableIDs(ctx context.Context, staleBefore time.Time) ([]string, error) {
+	var ids []string
+	err := r.db.WithContext(ctx).
+		Model(&entities.Submission{}).
+		Where("status = ? OR (status = ? AND claimed_at < ?)", "pending", "scoring", staleBefore).
+		Order("created_at ASC").
+		Pluck("id", &ids).Error
+	return ids, err
+}
+
+// Claim takes the submission with a single conditional update, so two replicas never both succeed
+func (r *mysqlSubmissionRepository) Claim(ctx context.Context, id, owner string, staleBefore time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&entities.Submission{}).
+		Where("id = ? AND (status = ? OR (status = ? AND claimed_at < ?))", id, "pending", "scoring", staleBefore).
+		Updates(map[string]interface{}{
+			"status":     "scoring",
+			"claimed_by": owner,
+			"claimed_at": time.Now(),
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *mysqlSubmissionRepository) Release(ctx context.Context, id, owner string) error {
+	return r.db.WithContext(ctx).
+		Model(&entities.Submission{}).
+		Where("id = ? AND status = ? AND claimed_by = ?", id, "scoring", owner).
+		Updates(map[string]interface{}{
+			"status":     "pending",
+			"claimed_by": "",
+			"claimed_at": nil,
+		}).Error
+}
+
+// GetByIDs loads the submissions without their answers
+func (r *mysqlSubmissionRepository) GetByIDs(ctx context.Context, ids []string) ([]entities.Submission, error) {
+	var submissions []entities.Submission
+	if len(ids) == 0 {
+		return submissions, nil
+	}
+	err := r.db.WithContext(ctx).Where("id IN ?", ids).Find(&submissions).Error
+	return submissions, err
+}
+
 func (r *mysqlSubmissionRepository) GetByTestID(ctx context.Context, testID string) ([]entities.Submission, error) {
 	var submissions []entities.Submission
 	err := r.db.WithContext(ctx).Preload("Answers").Where("test_id = ?", testID).Find(&submissions).Error
 	return submissions, err
 }
 
+func (r *mysqlSubmissionRepository) GetIDs(ctx context.Context, testID string, from, to *time.Time) ([]string, error) {
+	query := r.db.WithContext(ctx).Model(&entities.Submission{})
+	if testID != "" {
+		query = query.Where("test_id = ?", testID)
+	}
+	if from != nil {
+		query = query.Where("created_at >= ?", *from)
+	}
+	if to != nil {
+		query = query.Where("created_at < ?", *to)
+	}
+
+	var ids []string
+	err := query.Order("created_at ASC").Pluck("id", &ids).Error
+	return ids, err
+}
+
+func (r *mysqlSubmissionRepository) HasScoredSubmission(ctx context.Context, testID, userID string) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&entities.Submission{}).
+		Where("test_id = ? AND user_id = ? AND status = ?", testID, userID, "scored").
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *mysqlSubmissionRepository) GetScoredByAssignment(ctx context.Context, assignmentID string, userIDs []string) ([]entities.Submission, error) {
+	var submissions []entities.Submission
+	if len(userIDs) == 0 {
+		return submissions, nil
+	}
+	err := r.db.WithContext(ctx).
+		Where("assignment_id = ? AND user_id IN ? AND status = ?", assignmentID, userIDs, "scored").
+		Order("created_at ASC").
+		Find(&submissions).Error
+	return submissions, err
+}
+
+// CountAttempts counts every attempt except those whose scoring failed
+func (r *mysqlSubmissionRepository) CountAttempts(ctx context.Context, assignmentID, userID string) (int, error) {
+	return countAttempts(r.db.WithContext(ctx), assignmentID, userID)
+}
+
+func countAttempts(db *gorm.DB, assignmentID, userID string) (int, error) {
+	var count int64
+	err := db.
+		Model(&entities.Submission{}).
+		Where("assignment_id = ? AND user_id = ? AND status <> ?", assignmentID, userID, "failed").
+		Count(&count).Error
+	return int(count), err
+}
+
+// CountParticipants counts the distinct users with a scored submission; anonymous submissions count one each
+func (r *mysqlSubmissionRepository) CountParticipants(ctx context.Context, testID string) (int, error) {
+	var users, anonymous int64
+	if err := r.db.WithContext(ctx).
+		Model(&entities.Submission{}).
+		Where("test_id = ? AND status = ? AND user_id <> ?", testID, "scored", "").
+		Distinct("user_id").
+		Count(&users).Error; err != nil {
+		return 0, err
+	}
+	err := r.db.WithContext(ctx).
+		Model(&entities.Submission{}).
+		Where("test_id = ? AND status = ? AND (user_id = ? OR user_id IS NULL)", testID, "scored", "").
+		Count(&anonymous).Error
+	return int(users + anonymous), err
+}
+
 func (r *mysqlSubmissionRepository) Update(ctx context.Context, submission *entities.Submission) error {
 	return r.db.WithContext(ctx).Save(submission).Error
 } 
\ No newline at end of file
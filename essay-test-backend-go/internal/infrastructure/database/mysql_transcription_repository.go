@@ -0,0 +1,48 @@
+package database
+
+import (
+	"context"
+	"essay-test-backend/internal/domain/entities"
+	"essay-test-backend/internal/domain/repositories"
+
+	"gorm.io/gorm"
+)
+
+type mysqlTranscriptionRepository struct {
+	db *gorm.DB
+}
+
+func NewMySQLTranscriptionRepository(db *gorm.DB) repositories.TranscriptionRepository {
+	return &mysqlTranscriptionRepository{db: db}
+}
+
+func (r *mysqlTranscriptionRepository) Create(ctx context.Context, transcription *entities.Transcription) error {
+	return r.db.WithContext(ctx).Create(transcription).Error
+}
+
+func (r *mysqlTranscriptionRepository) GetByID(ctx context.Context, id string) (*entities.Transcription, error) {
+	var transcription entities.Transcription
+	err := r.db.WithContext(ctx).First(&transcription, "id = ?", id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &transcription, nil
+}
+
+func (r *mysqlTranscriptionRepository) Update(ctx context.Context, transcription *entities.Transcription) error {
+	// 提出に使われた読み取り結果は、同時に届いた更新でも書き換えない
+	result := r.db.WithContext(ctx).Model(transcription).
+		Where("submission_id = ?", "").
+		Select("content", "status", "confirmed_at").
+		Updates(transcription)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return repositories.ErrTranscriptionUsed
+	}
+	return nil
+}
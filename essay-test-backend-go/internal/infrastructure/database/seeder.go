@@ -24,7 +24,7 @@ func SeedTestData(db *gorm.DB) error {
 			TotalPoints:  100,
 			Difficulty:   "標準",
 			Category:     "社会問題",
-			Participants: 1247,
+			Participants: 0,
 			EssayText: `SNSの匿名性について
 
 SNS（ソーシャルネットワーキングサービス）は私たちの生活に欠かせないコミュニケーションツールとなっている。しかし、その匿名性をめぐって様々な議論が展開されている。
@@ -75,7 +75,7 @@ SNSは今や社会インフラの一部となっている。その健全な発
 			TotalPoints:  100,
 			Difficulty:   "やや難",
 			Category:     "科学技術",
-			Participants: 892,
+			Participants: 0,
 			EssayText: `AI技術と社会の未来
 
 21世紀に入り、人工知能（AI）技術は急速な発展を遂げ、私たちの社会に大きな変革をもたらしている。機械学習、深層学習、自然言語処理などの技術革新により、AIは人間の知的活動の多くの領域で人間を上回る性能を示すようになった。
@@ -126,7 +126,7 @@ AI技術は確実に私たちの未来を形作る重要な要素となる。そ
 			TotalPoints:  100,
 			Difficulty:   "標準",
 			Category:     "環境",
-			Participants: 1156,
+			Participants: 0,
 			EssayText: `環境問題と持続可能な社会
 
 地球環境問題は、21世紀の人類が直面する最も深刻な課題の一つである。気候変動、生物多様性の喪失、海洋汚染、森林破壊など、様々な環境問題が相互に関連し合いながら、地球全体の生態系に深刻な影響を与えている。
@@ -0,0 +1,65 @@
+package services
+
+import (
+	"context"
+
+	"essay-test-backend/internal/domain/entities"
+	"essay-test-backend/internal/domain/repositories"
+	"essay-test-backend/internal/domain/services"
+	"essay-test-backend/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+// calibratedScoringService applies the per-test calibration to the results of another scorer
+type calibratedScoringService struct {
+	next            services.ScoringService
+	calibrationRepo repositories.CalibrationRepository
+	logger          *zap.Logger
+}
+
+func NewCalibratedScoringService(next services.ScoringService, calibrationRepo repositories.CalibrationRepository, logger *zap.Logger) services.ScoringService {
+	return &calibratedScoringService{
+		next:            next,
+		calibrationRepo: calibrationRepo,
+		logger:          logger,
+	}
+}
+
+func (s *calibratedScoringService) ScoreSubmission(ctx context.Context, submission *entities.Submission, test *entities.EssayTest) (*entities.ScoringResult, error) {
+	result, err := s.next.ScoreSubmission(ctx, submission, test)
+	if err != nil {
+		return nil, err
+	}
+
+	calibration, err := s.calibrationRepo.GetCalibration(ctx, test.ID)
+	if err != nil {
+		// 較正値を読めなくても採点自体は止めない
+		logger.FromContext(ctx, s.logger).Error("採点較正の取得に失敗", zap.Error(err), zap.String("test_id", test.ID))
+		return result, nil
+	}
+	if calibration == nil {
+		return result, nil
+	}
+
+	rawTotal := result.TotalScore
+	calibration.Apply(result)
+	// 補正後の点数に合わせて講評を作り直す
+	refreshFeedback(result)
+
+	logger.FromContext(ctx, s.logger).Info("採点較正を適用",
+		zap.String("result_id", result.ID),
+		zap.String("run_id", calibration.RunID),
+		zap.Int("raw_total_score", rawTotal),
+		zap.Int("total_score", result.TotalScore))
+
+	return result, nil
+}
+
+func (s *calibratedScoringService) Version() string {
+	return s.next.Version()
+}
+
+func (s *calibratedScoringService) CheckHealth(ctx context.Context) error {
+	return checkScorerHealth(ctx, s.next)
+}
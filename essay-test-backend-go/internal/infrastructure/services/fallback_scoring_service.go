@@ -11,11 +11,16 @@ import (
 	"essay-test-backend/internal/domain/entities"
 	"essay-test-backend/internal/domain/services"
 	"essay-test-backend/pkg/config"
+	"essay-test-backend/pkg/logger"
+	"essay-test-backend/pkg/textfeatures"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
+// 採点ロジックを変更したら更新する
+const fallbackScorerVersion = "fallback-1.1.0"
+
 type fallbackScoringService struct {
 	config *config.Config
 	logger *zap.Logger
@@ -29,7 +34,7 @@ func NewFallbackScoringService(config *config.Config, logger *zap.Logger) servic
 }
 
 func (s *fallbackScoringService) ScoreSubmission(ctx context.Context, submission *entities.Submission, test *entities.EssayTest) (*entities.ScoringResult, error) {
-	s.logger.Info("フォールバック採点を開始", 
+	logger.FromContext(ctx, s.logger).Info("フォールバック採点を開始", 
 		zap.String("submission_id", submission.ID),
 		zap.String("test_id", test.ID))
 
@@ -41,6 +46,11 @@ func (s *fallbackScoringService) ScoreSubmission(ctx context.Context, submission
 	answer1Length := utf8.RuneCountInString(submission.Answers[0].Content)
 	answer2Length := utf8.RuneCountInString(submission.Answers[1].Content)
 
+	// 文章の構成要素を検出（講評の根拠に使う）
+	features1 := textfeatures.Analyze(submission.Answers[0].Content)
+	features2 := textfeatures.Analyze(submission.Answers[1].Content)
+	keywords := summaryKeywords(test)
+
 	// 問1の採点（要約問題）
 	q1Score := s.scoreQuestion1(answer1Length, submission.Answers[0].Content)
 	
@@ -61,7 +71,7 @@ func (s *fallbackScoringService) ScoreSubmission(ctx context.Context, submission
 			Percentage:  float64(q1Score) / 30 * 100,
 			Comment:     s.getQuestion1Comment(answer1Length),
 			Reasoning:   s.getQuestion1Reasoning(answer1Length),
-			CriteriaScores: s.getQuestion1CriteriaScores(q1Score),
+			CriteriaScores: s.getQuestion1CriteriaScores(q1Score, features1, keywords),
 		},
 		{
 			ID:          uuid.New().String(),
@@ -71,7 +81,7 @@ func (s *fallbackScoringService) ScoreSubmission(ctx context.Context, submission
 			Percentage:  float64(q2Score) / 70 * 100,
 			Comment:     s.getQuestion2Comment(answer2Length),
 			Reasoning:   s.getQuestion2Reasoning(answer2Length),
-			CriteriaScores: s.getQuestion2CriteriaScores(q2Score),
+			CriteriaScores: s.getQuestion2CriteriaScores(q2Score, features2, keywords),
 		},
 	}
 
@@ -84,12 +94,13 @@ func (s *fallbackScoringService) ScoreSubmission(ctx context.Context, submission
 		MaxScore:     maxScore,
 		Percentage:   percentage,
 		Details:      details,
-		Feedback:     s.generateFeedback(totalScore, answer1Length, answer2Length),
 		ScoredBy:     "fallback",
+		ScorerVersion: fallbackScorerVersion,
 		ExpiresAt:    time.Now().Add(30 * 24 * time.Hour), // 30日後に期限切れ
 	}
+	refreshFeedback(result)
 
-	s.logger.Info("フォールバック採点完了",
+	logger.FromContext(ctx, s.logger).Info("フォールバック採点完了",
 		zap.String("result_id", result.ID),
 		zap.Int("total_score", totalScore),
 		zap.Float64("percentage", percentage))
@@ -97,6 +108,10 @@ func (s *fallbackScoringService) ScoreSubmission(ctx context.Context, submission
 	return result, nil
 }
 
+func (s *fallbackScoringService) Version() string {
+	return fallbackScorerVersion
+}
+
 func (s *fallbackScoringService) scoreQuestion1(length int, content string) int {
 	// 問1: 要約問題（30点満点）
 	// 文字数による基本点数
@@ -221,14 +236,13 @@ func (s *fallbackScoringService) getQuestion2Reasoning(length int) string {
 	return fmt.Sprintf("文字数: %d字。意見記述問題では600-800字程度が適切です。", length)
 }
 
-func (s *fallbackScoringService) getQuestion1CriteriaScores(totalScore int) []entities.CriteriaScore {
-	return []entities.CriteriaScore{
+func (s *fallbackScoringService) getQuestion1CriteriaScores(totalScore int, features textfeatures.Features, keywords []string) []entities.CriteriaScore {
+	scores := []entities.CriteriaScore{
 		{
 			ID:           uuid.New().String(),
 			CriteriaName: "要点把握",
 			Score:        int(math.Round(float64(totalScore) * 0.4)),
 			MaxScore:     12,
-			Comment:      "課題文の主要な論点を理解できています。",
 			Reasoning:    "文字数と内容から判定しました。",
 		},
 		{
@@ -236,7 +250,6 @@ func (s *fallbackScoringService) getQuestion1CriteriaScores(totalScore int) []en
 			CriteriaName: "要点の整理・取捨選択",
 			Score:        int(math.Round(float64(totalScore) * 0.35)),
 			MaxScore:     10,
-			Comment:      "重要な論点を適切に選択できています。",
 			Reasoning:    "要約の構成から判定しました。",
 		},
 		{
@@ -244,20 +257,22 @@ func (s *fallbackScoringService) getQuestion1CriteriaScores(totalScore int) []en
 			CriteriaName: "文章表現",
 			Score:        int(math.Round(float64(totalScore) * 0.25)),
 			MaxScore:     8,
-			Comment:      "文章表現は概ね適切です。",
 			Reasoning:    "文字数と構成から判定しました。",
 		},
 	}
+	for i := range scores {
+		criterionFeedback(&scores[i], features, keywords)
+	}
+	return scores
 }
 
-func (s *fallbackScoringService) getQuestion2CriteriaScores(totalScore int) []entities.CriteriaScore {
-	return []entities.CriteriaScore{
+func (s *fallbackScoringService) getQuestion2CriteriaScores(totalScore int, features textfeatures.Features, keywords []string) []entities.CriteriaScore {
+	scores := []entities.CriteriaScore{
 		{
 			ID:           uuid.New().String(),
 			CriteriaName: "課題文の理解",
 			Score:        int(math.Round(float64(totalScore) * 0.2)),
 			MaxScore:     14,
-			Comment:      "課題文の内容を適切に理解しています。",
 			Reasoning:    "論述の内容から判定しました。",
 		},
 		{
@@ -265,7 +280,6 @@ func (s *fallbackScoringService) getQuestion2CriteriaScores(totalScore int) []en
 			CriteriaName: "自分自身の明確な意見・立場",
 			Score:        int(math.Round(float64(totalScore) * 0.25)),
 			MaxScore:     17,
-			Comment:      "自分の立場が明確に示されています。",
 			Reasoning:    "意見の明確性から判定しました。",
 		},
 		{
@@ -273,7 +287,6 @@ func (s *fallbackScoringService) getQuestion2CriteriaScores(totalScore int) []en
 			CriteriaName: "論理的思考力",
 			Score:        int(math.Round(float64(totalScore) * 0.3)),
 			MaxScore:     21,
-			Comment:      "論理的な構成で論述されています。",
 			Reasoning:    "論理的構成から判定しました。",
 		},
 		{
@@ -281,7 +294,6 @@ func (s *fallbackScoringService) getQuestion2CriteriaScores(totalScore int) []en
 			CriteriaName: "独創性",
 			Score:        int(math.Round(float64(totalScore) * 0.15)),
 			MaxScore:     10,
-			Comment:      "独自の視点が含まれています。",
 			Reasoning:    "内容の独創性から判定しました。",
 		},
 		{
@@ -289,50 +301,11 @@ func (s *fallbackScoringService) getQuestion2CriteriaScores(totalScore int) []en
 			CriteriaName: "適合性",
 			Score:        int(math.Round(float64(totalScore) * 0.1)),
 			MaxScore:     8,
-			Comment:      "課題に適合した内容です。",
 			Reasoning:    "課題への適合性から判定しました。",
 		},
 	}
-}
-
-func (s *fallbackScoringService) generateFeedback(totalScore, answer1Length, answer2Length int) string {
-	var feedback strings.Builder
-	
-	feedback.WriteString("【総合評価】\n")
-	switch {
-	case totalScore >= 80:
-		feedback.WriteString("優秀な答案です。論理的構成と内容の両面で高い水準に達しています。\n\n")
-	case totalScore >= 60:
-		feedback.WriteString("良好な答案です。基本的な論点は押さえられていますが、さらなる向上の余地があります。\n\n")
-	case totalScore >= 40:
-		feedback.WriteString("標準的な答案です。基本的な理解は示されていますが、論述の深化が必要です。\n\n")
-	default:
-		feedback.WriteString("改善が必要な答案です。課題文の理解と論述の構成を見直してください。\n\n")
-	}
-
-	feedback.WriteString("【問1について】\n")
-	feedback.WriteString(fmt.Sprintf("文字数: %d字\n", answer1Length))
-	if answer1Length >= 150 && answer1Length <= 250 {
-		feedback.WriteString("適切な文字数で要約されています。\n")
-	} else {
-		feedback.WriteString("要約問題では150-250字程度が適切です。\n")
-	}
-	feedback.WriteString("\n")
-
-	feedback.WriteString("【問2について】\n")
-	feedback.WriteString(fmt.Sprintf("文字数: %d字\n", answer2Length))
-	if answer2Length >= 600 && answer2Length <= 800 {
-		feedback.WriteString("適切な文字数で論述されています。\n")
-	} else {
-		feedback.WriteString("意見記述問題では600-800字程度が適切です。\n")
+	for i := range scores {
+		criterionFeedback(&scores[i], features, keywords)
 	}
-	feedback.WriteString("\n")
-
-	feedback.WriteString("【改善のポイント】\n")
-	feedback.WriteString("・論理的な構成を意識してください（序論・本論・結論）\n")
-	feedback.WriteString("・具体例を用いて論述を補強してください\n")
-	feedback.WriteString("・自分の意見を明確に示してください\n")
-	feedback.WriteString("・課題文の内容を踏まえた論述を心がけてください\n")
-
-	return feedback.String()
-} 
\ No newline at end of file
+	return scores
+}
\ No newline at end of file
@@ -0,0 +1,286 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+
+	"essay-test-backend/internal/domain/entities"
+	"essay-test-backend/internal/domain/services"
+	"essay-test-backend/pkg/textfeatures"
+)
+
+// 引用する抜粋の最大文字数
+const excerptMaxRunes = 40
+
+// 一文がこれより長いと読みにくいと判定する
+const longSentenceRunes = 80
+
+// 採点基準ごとの講評（高・中・低の得点帯）
+var criterionComments = map[string][3]string{
+	"要点把握": {
+		"課題文の主要な論点を的確に捉えています。",
+		"主要な論点の一部は捉えられていますが、抜けている論点があります。",
+		"課題文の主要な論点が十分に捉えられていません。",
+	},
+	"要点の整理・取捨選択": {
+		"重要な論点を適切に選び、簡潔に整理できています。",
+		"論点の整理はできていますが、取捨選択に改善の余地があります。",
+		"論点の取捨選択と整理が不十分です。",
+	},
+	"文章表現": {
+		"簡潔で読みやすい文章です。",
+		"文章表現は概ね適切です。",
+		"文の長さや表現に改善の余地があります。",
+	},
+	"課題文の理解": {
+		"課題文の内容を踏まえて論じられています。",
+		"課題文への言及はありますが、理解を示す記述が限られています。",
+		"課題文の内容を踏まえた論述になっていません。",
+	},
+	"自分自身の明確な意見・立場": {
+		"自分の立場が明確に示されています。",
+		"意見は述べられていますが、立場がやや曖昧です。",
+		"自分の意見・立場が読み取れません。",
+	},
+	"論理的思考力": {
+		"根拠と反論への応答を備えた論理的な構成です。",
+		"主張と根拠の対応はありますが、論証に不足があります。",
+		"主張を支える論理的な構成が不十分です。",
+	},
+	"独創性": {
+		"具体例を用いた独自の視点が示されています。",
+		"一般的な論点にとどまっており、独自の視点がやや不足しています。",
+		"独自の視点や具体的な検討が見られません。",
+	},
+	"適合性": {
+		"課題の指示と字数に適合した答案です。",
+		"概ね課題に沿っていますが、字数や指示への対応に不足があります。",
+		"課題の指示や字数への対応が不十分です。",
+	},
+}
+
+// summaryKeywords returns the terms of the test's main thesis and key points, which an answer that grasps the
+// source text is expected to mention
+func summaryKeywords(test *entities.EssayTest) []string {
+	var keywords []string
+	seen := make(map[string]bool)
+	texts := append([]string{test.ScoringCriteria.MainThesis}, test.ScoringCriteria.KeyPoints...)
+	for _, text := range texts {
+		for _, term := range textfeatures.Terms(text) {
+			if !seen[term] {
+				seen[term] = true
+				keywords = append(keywords, term)
+			}
+		}
+	}
+	return keywords
+}
+
+// criterionFeedback fills the evidence and suggestions of a criteria score from the detected text features;
+// keywords are the terms of the test's main points, see summaryKeywords
+func criterionFeedback(cs *entities.CriteriaScore, features textfeatures.Features, keywords []string) {
+	cs.Evidence = []string{}
+	cs.Suggestions = []string{}
+
+	addEvidence := func(e *textfeatures.Excerpt) {
+		if e != nil {
+			cs.Evidence = append(cs.Evidence, textfeatures.Quote(*e, excerptMaxRunes))
+		}
+	}
+	suggest := func(s string) {
+		cs.Suggestions = append(cs.Suggestions, s)
+	}
+
+	switch cs.CriteriaName {
+	case "要点把握", "課題文の理解":
+		found := features.SentencesContaining(keywords)
+		for i := 0; i < len(found) && i < 2; i++ {
+			addEvidence(&found[i])
+		}
+		if len(found) == 0 {
+			suggest("課題文の中心となる論点を、課題文の言葉を用いて明示しましょう。")
+		}
+	case "要点の整理・取捨選択":
+		switch {
+		case features.Length > 250:
+			suggest(fmt.Sprintf("%d字と長めです。重要度の低い内容を削り、250字以内にまとめましょう。", features.Length))
+		case features.Length < 150:
+			suggest(fmt.Sprintf("%d字と短めです。抜けている論点を補い、150字以上でまとめましょう。", features.Length))
+		}
+	case "文章表現":
+		if s := features.LongestSentence; s != nil && len([]rune(s.Text)) >= longSentenceRunes {
+			addEvidence(s)
+			suggest(fmt.Sprintf("一文が%d字と長い箇所があります。二つ以上の文に分けると読みやすくなります。", len([]rune(s.Text))))
+		}
+	case "自分自身の明確な意見・立場":
+		addEvidence(features.Opinion)
+		if features.Opinion == nil {
+			suggest("「私は〜と考える」のように、自分の立場を冒頭で明示しましょう。")
+		}
+	case "論理的思考力":
+		addEvidence(features.Reason)
+		addEvidence(features.Counterargument)
+		addEvidence(features.Conclusion)
+		if features.Reason == nil {
+			suggest("「なぜなら」などを用いて、主張の根拠をはっきり示しましょう。")
+		}
+		if features.Counterargument == nil {
+			suggest("反対の立場にも触れ、それに反論すると主張の説得力が増します。")
+		}
+		if features.WeakConclusion() {
+			suggest("最終段落で「以上のことから」などを用いて、結論を明確にまとめましょう。")
+		}
+	case "独創性":
+		addEvidence(features.Example)
+		if features.Example == nil {
+			suggest("身近な体験や社会の事例など、具体例を挙げて主張を裏付けましょう。")
+		}
+	case "適合性":
+		switch {
+		case features.Length > 800:
+			suggest(fmt.Sprintf("%d字と指定字数を超えています。600-800字に収めましょう。", features.Length))
+		case features.Length < 600:
+			suggest(fmt.Sprintf("%d字と指定字数に足りません。600-800字を目安に論述を深めましょう。", features.Length))
+		}
+	}
+}
+
+// refreshFeedback rebuilds the score-dependent feedback, so it must run again whenever scores change
+func refreshFeedback(result *entities.ScoringResult) {
+	for i := range result.Details {
+		for j := range result.Details[i].CriteriaScores {
+			cs := &result.Details[i].CriteriaScores[j]
+			if comment := bandComment(cs.CriteriaName, cs.Score, cs.MaxScore); comment != "" {
+				cs.Comment = comment
+			}
+		}
+	}
+
+	result.Strengths, result.Improvements = summarizeFeedback(result.Details)
+	result.OverallAssessment = overallAssessment(result.Percentage)
+	result.Feedback = formatFeedback(result)
+}
+
+type feedbackGenerator struct{}
+
+// NewFeedbackGenerator returns the generator of the template feedback also used by the fallback scorer
+func NewFeedbackGenerator() services.FeedbackGenerator {
+	return feedbackGenerator{}
+}
+
+// RefreshFeedback rewrites the band comments of the rescored criteria the teacher left uncommented, the overall
+// assessment when any score changed, and the strengths, improvements and plain-text feedback
+func (feedbackGenerator) RefreshFeedback(result *entities.ScoringResult, refresh services.FeedbackRefresh) {
+	rescored := make(map[string]bool)
+	for _, id := range refresh.Rescored {
+		rescored[id] = true
+	}
+	for _, id := range refresh.KeepComments {
+		delete(rescored, id)
+	}
+	for i := range result.Details {
+		for j := range result.Details[i].CriteriaScores {
+			cs := &result.Details[i].CriteriaScores[j]
+			if !rescored[cs.ID] {
+				continue
+			}
+			if comment := bandComment(cs.CriteriaName, cs.Score, cs.MaxScore); comment != "" {
+				cs.Comment = comment
+			}
+		}
+	}
+
+	result.Strengths, result.Improvements = summarizeFeedback(result.Details)
+	if len(refresh.Rescored) > 0 {
+		result.OverallAssessment = overallAssessment(result.Percentage)
+	}
+	if !refresh.KeepFeedback {
+		result.Feedback = formatFeedback(result)
+	}
+}
+
+func overallAssessment(percentage float64) string {
+	switch {
+	case percentage >= 80:
+		return "優秀な答案です。論理的構成と内容の両面で高い水準に達しています。"
+	case percentage >= 60:
+		return "良好な答案です。基本的な論点は押さえられていますが、さらなる向上の余地があります。"
+	case percentage >= 40:
+		return "標準的な答案です。基本的な理解は示されていますが、論述の深化が必要です。"
+	default:
+		return "改善が必要な答案です。課題文の理解と論述の構成を見直してください。"
+	}
+}
+
+// formatFeedback renders the structured feedback as the plain-text feedback shown by older clients
+func formatFeedback(result *entities.ScoringResult) string {
+	var feedback strings.Builder
+
+	feedback.WriteString("【総合評価】\n")
+	feedback.WriteString(result.OverallAssessment + "\n\n")
+
+	for _, detail := range result.Details {
+		feedback.WriteString(fmt.Sprintf("【問%dについて】\n", detail.QuestionNum))
+		feedback.WriteString(detail.Reasoning + "\n")
+		feedback.WriteString(detail.Comment + "\n\n")
+	}
+
+	if len(result.Strengths) > 0 {
+		feedback.WriteString("【良かった点】\n")
+		for _, s := range result.Strengths {
+			feedback.WriteString("・" + s + "\n")
+		}
+		feedback.WriteString("\n")
+	}
+
+	if len(result.Improvements) > 0 {
+		feedback.WriteString("【改善のポイント】\n")
+		for _, s := range result.Improvements {
+			feedback.WriteString("・" + s + "\n")
+		}
+	}
+
+	return feedback.String()
+}
+
+// bandComment picks the comment template of the score band
+func bandComment(criteriaName string, score, maxScore int) string {
+	comments, ok := criterionComments[criteriaName]
+	if !ok || maxScore <= 0 {
+		return ""
+	}
+
+	ratio := float64(score) / float64(maxScore)
+	switch {
+	case ratio >= 0.8:
+		return comments[0]
+	case ratio >= 0.5:
+		return comments[1]
+	default:
+		return comments[2]
+	}
+}
+
+// summarizeFeedback collects the strengths and improvements of every criteria in rubric order
+func summarizeFeedback(details []entities.QuestionScore) (strengths, improvements []string) {
+	strengths, improvements = []string{}, []string{}
+	seen := make(map[string]bool)
+	for _, detail := range details {
+		for _, cs := range detail.CriteriaScores {
+			if cs.MaxScore > 0 && float64(cs.Score)/float64(cs.MaxScore) >= 0.7 {
+				strength := fmt.Sprintf("問%d「%s」: %s", detail.QuestionNum, cs.CriteriaName, cs.Comment)
+				if len(cs.Evidence) > 0 {
+					strength += " 例: " + cs.Evidence[0]
+				}
+				strengths = append(strengths, strength)
+			}
+			for _, s := range cs.Suggestions {
+				if !seen[s] {
+					seen[s] = true
+					improvements = append(improvements, fmt.Sprintf("問%d: %s", detail.QuestionNum, s))
+				}
+			}
+		}
+	}
+	return strengths, improvements
+}
@@ -0,0 +1,62 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"essay-test-backend/internal/domain/entities"
+	"essay-test-backend/internal/domain/services"
+	"essay-test-backend/pkg/metrics"
+	"essay-test-backend/pkg/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = tracing.Tracer("essay-test-backend/internal/infrastructure/services")
+
+// instrumentedScoringService records a span, the duration and the failures of the wrapped scorer under its name
+type instrumentedScoringService struct {
+	next services.ScoringService
+	name string
+}
+
+func NewInstrumentedScoringService(next services.ScoringService, name string) services.ScoringService {
+	return &instrumentedScoringService{next: next, name: name}
+}
+
+func (s *instrumentedScoringService) ScoreSubmission(ctx context.Context, submission *entities.Submission, test *entities.EssayTest) (result *entities.ScoringResult, err error) {
+	ctx, span := tracer.Start(ctx, "ScoringService.ScoreSubmission", trace.WithAttributes(
+		attribute.String("scorer", s.name),
+		attribute.String("scorer_version", s.next.Version()),
+		attribute.String("submission_id", submission.ID)))
+	defer func() { tracing.End(span, err) }()
+
+	start := time.Now()
+	result, err = s.next.ScoreSubmission(ctx, submission, test)
+	if err != nil {
+		metrics.ScoringFailures.WithLabelValues(s.name).Inc()
+		return nil, err
+	}
+	metrics.ScoringDuration.WithLabelValues(s.name, result.ScoredBy).Observe(time.Since(start).Seconds())
+	span.SetAttributes(
+		attribute.String("scored_by", result.ScoredBy),
+		attribute.Int("total_score", result.TotalScore))
+	return result, nil
+}
+
+func (s *instrumentedScoringService) Version() string {
+	return s.next.Version()
+}
+
+func (s *instrumentedScoringService) CheckHealth(ctx context.Context) error {
+	return checkScorerHealth(ctx, s.next)
+}
+
+// checkScorerHealth asks a wrapped scorer for its health; scorers without remote dependencies are always ready
+func checkScorerHealth(ctx context.Context, scorer services.ScoringService) error {
+	if checker, ok := scorer.(services.HealthChecker); ok {
+		return checker.CheckHealth(ctx)
+	}
+	return nil
+}
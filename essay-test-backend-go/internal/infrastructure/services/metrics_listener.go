@@ -0,0 +1,20 @@
+package services
+
+import (
+	"context"
+
+	"essay-test-backend/internal/domain/entities"
+	"essay-test-backend/internal/domain/services"
+	"essay-test-backend/pkg/metrics"
+)
+
+// metricsListener counts the scored submissions of each test
+type metricsListener struct{}
+
+func NewMetricsListener() services.SubmissionListener {
+	return metricsListener{}
+}
+
+func (metricsListener) OnSubmissionScored(ctx context.Context, submission *entities.Submission, test *entities.EssayTest, result *entities.ScoringResult) {
+	metrics.SubmissionsScored.WithLabelValues(submission.TestID).Inc()
+}
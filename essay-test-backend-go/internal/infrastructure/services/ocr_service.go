@@ -0,0 +1,104 @@
+package services
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"essay-test-backend/internal/domain/services"
+	"essay-test-backend/pkg/config"
+
+	"go.uber.org/zap"
+)
+
+// NewOCRService returns the OCR engine selected by the configuration; unknown engines fall back to the stub
+func NewOCRService(cfg config.OCRConfig, logger *zap.Logger) services.OCRService {
+	switch cfg.Engine {
+	case "tesseract":
+		return &tesseractOCRService{command: cfg.Command, language: cfg.Language}
+	case "stub", "":
+	default:
+		logger.Warn("不明なOCRエンジンのためスタブを使用", zap.String("engine", cfg.Engine))
+	}
+	return stubOCRService{}
+}
+
+// stubOCRService reads nothing, so the student types the answer while looking at their photo
+type stubOCRService struct{}
+
+func (stubOCRService) Transcribe(ctx context.Context, image []byte, contentType string) (*services.OCRResult, error) {
+	return &services.OCRResult{}, nil
+}
+
+func (stubOCRService) Engine() string {
+	return "stub"
+}
+
+// tesseractOCRService runs the tesseract command installed on the server
+type tesseractOCRService struct {
+	command  string
+	language string
+}
+
+func (s *tesseractOCRService) Transcribe(ctx context.Context, image []byte, contentType string) (*services.OCRResult, error) {
+	cmd := exec.CommandContext(ctx, s.command, "stdin", "stdout", "-l", s.language, "tsv")
+	cmd.Stdin = bytes.NewReader(image)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("tesseract failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return parseTesseractTSV(out, !strings.HasPrefix(s.language, "jpn")), nil
+}
+
+func (s *tesseractOCRService) Engine() string {
+	return "tesseract"
+}
+
+// parseTesseractTSV joins the recognized words line by line and averages their confidence
+func parseTesseractTSV(out []byte, spaced bool) *services.OCRResult {
+	var text strings.Builder
+	var confidenceSum float64
+	words := 0
+	lastLine := ""
+
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		// level page_num block_num par_num line_num word_num left top width height conf text
+		fields := strings.Split(scanner.Text(), "\t")
+		if len(fields) < 12 || fields[0] != "5" {
+			continue
+		}
+		conf, err := strconv.ParseFloat(fields[10], 64)
+		word := strings.TrimSpace(fields[11])
+		if err != nil || conf < 0 || word == "" {
+			continue
+		}
+
+		line := strings.Join(fields[2:5], "-")
+		switch {
+		case words == 0:
+		case line != lastLine:
+			text.WriteString("\n")
+		case spaced:
+			text.WriteString(" ")
+		}
+		text.WriteString(word)
+		lastLine = line
+
+		confidenceSum += conf
+		words++
+	}
+
+	result := &services.OCRResult{Text: text.String()}
+	if words > 0 {
+		result.Confidence = confidenceSum / float64(words) / 100
+	}
+	return result
+}
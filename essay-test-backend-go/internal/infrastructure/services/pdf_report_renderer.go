@@ -0,0 +1,221 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"essay-test-backend/internal/domain/entities"
+	"essay-test-backend/internal/domain/services"
+	"essay-test-backend/pkg/config"
+
+	"github.com/go-pdf/fpdf"
+	"go.uber.org/zap"
+)
+
+const (
+	reportFont       = "jp"
+	reportMargin     = 15.0
+	reportLineHeight = 6.0
+)
+
+// pdfReportRenderer lays out score reports on A4 with an embedded Japanese font
+type pdfReportRenderer struct {
+	font   []byte
+	logger *zap.Logger
+}
+
+// NewPDFReportRenderer loads the font once and fails when reports are enabled but the font cannot be used,
+// so a missing font is noticed at startup rather than on the first download
+func NewPDFReportRenderer(cfg config.ReportConfig, logger *zap.Logger) (services.ReportRenderer, error) {
+	if !cfg.Enabled {
+		logger.Info("PDFレポートは無効です")
+		return &pdfReportRenderer{logger: logger}, nil
+	}
+
+	font, err := os.ReadFile(cfg.FontPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read report font: %w", err)
+	}
+	// OpenTypeのCFF形式やフォントコレクション（.ttc）は埋め込めないため、起動時に読み込めるか確かめる
+	if !isTrueTypeFont(font) {
+		return nil, fmt.Errorf("report font %s is not a TrueType font", cfg.FontPath)
+	}
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.AddUTF8FontFromBytes(reportFont, "", font)
+	pdf.SetFont(reportFont, "", 12)
+	if err := pdf.Error(); err != nil {
+		return nil, fmt.Errorf("failed to load report font %s: %w", cfg.FontPath, err)
+	}
+	return &pdfReportRenderer{font: font, logger: logger}, nil
+}
+
+func isTrueTypeFont(font []byte) bool {
+	return len(font) >= 4 && (bytes.Equal(font[:4], []byte{0, 1, 0, 0}) || string(font[:4]) == "true")
+}
+
+func (r *pdfReportRenderer) RenderResultReport(ctx context.Context, report *services.ResultReport) ([]byte, error) {
+	if len(r.font) == 0 {
+		return nil, fmt.Errorf("report disabled")
+	}
+
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(reportMargin, reportMargin, reportMargin)
+	pdf.SetAutoPageBreak(true, reportMargin)
+	pdf.AddUTF8FontFromBytes(reportFont, "", r.font)
+	pdf.SetTitle(report.Test.Title+" 採点レポート", true)
+	pdf.AliasNbPages("")
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-reportMargin + 3)
+		pdf.SetFont(reportFont, "", 8)
+		pdf.SetTextColor(128, 128, 128)
+		pdf.CellFormat(0, 5, fmt.Sprintf("%d / {nb}", pdf.PageNo()), "", 0, "C", false, 0, "")
+	})
+	pdf.AddPage()
+
+	writeReportSummary(pdf, report)
+	for _, detail := range report.Result.Details {
+		writeQuestionScores(pdf, detail)
+	}
+	writeReportAnswers(pdf, report)
+	writeReportFeedback(pdf, report.Result)
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, fmt.Errorf("failed to render report: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func writeReportSummary(pdf *fpdf.Fpdf, report *services.ResultReport) {
+	result := report.Result
+
+	pdf.SetFont(reportFont, "", 18)
+	pdf.CellFormat(0, 10, "小論文 採点レポート", "", 1, "L", false, 0, "")
+	pdf.SetFont(reportFont, "", 12)
+	pdf.MultiCell(0, reportLineHeight, report.Test.Title, "", "L", false)
+
+	pdf.SetFont(reportFont, "", 9)
+	pdf.SetTextColor(96, 96, 96)
+	if report.Submission != nil {
+		pdf.CellFormat(0, 5, "提出日時: "+report.Submission.CreatedAt.Format("2006年1月2日 15:04"), "", 1, "L", false, 0, "")
+	}
+	pdf.CellFormat(0, 5, "結果ID: "+result.ID, "", 1, "L", false, 0, "")
+	pdf.SetTextColor(0, 0, 0)
+	pdf.Ln(3)
+
+	pdf.SetFillColor(240, 244, 250)
+	pdf.SetFont(reportFont, "", 16)
+	pdf.CellFormat(0, 12, fmt.Sprintf("得点 %d / %d（%.1f%%）", result.TotalScore, result.MaxScore, result.Percentage), "", 1, "C", true, 0, "")
+	pdf.SetFont(reportFont, "", 10)
+	if report.Takers > 0 {
+		pdf.CellFormat(0, 7, fmt.Sprintf("順位 %d位 / %d件中（パーセンタイル %.1f）", report.Rank, report.Takers, report.Percentile), "", 1, "C", true, 0, "")
+	}
+	if result.LatePenalty > 0 {
+		pdf.CellFormat(0, 7, fmt.Sprintf("遅延提出による減点 -%d点（%.0f%%）", result.LatePenalty, result.LatePenaltyPercent), "", 1, "C", true, 0, "")
+	}
+	pdf.Ln(4)
+
+	if result.OverallAssessment != "" {
+		writeReportHeading(pdf, "総合評価")
+		pdf.SetFont(reportFont, "", 10)
+		pdf.MultiCell(0, reportLineHeight, result.OverallAssessment, "", "L", false)
+		pdf.Ln(2)
+	}
+}
+
+// writeQuestionScores prints the criteria table of a question, keeping each row on one page
+func writeQuestionScores(pdf *fpdf.Fpdf, detail entities.QuestionScore) {
+	writeReportHeading(pdf, fmt.Sprintf("問%d（%d / %d点）", detail.QuestionNum, detail.Score, detail.MaxScore))
+
+	const nameWidth, scoreWidth = 50.0, 22.0
+	commentWidth := 210 - 2*reportMargin - nameWidth - scoreWidth
+
+	pdf.SetFont(reportFont, "", 9)
+	pdf.SetFillColor(230, 230, 230)
+	pdf.CellFormat(nameWidth, 7, "採点基準", "1", 0, "C", true, 0, "")
+	pdf.CellFormat(scoreWidth, 7, "得点", "1", 0, "C", true, 0, "")
+	pdf.CellFormat(commentWidth, 7, "コメント", "1", 1, "C", true, 0, "")
+
+	_, pageHeight := pdf.GetPageSize()
+	for _, cs := range detail.CriteriaScores {
+		comment := cs.Comment
+		for _, s := range cs.Suggestions {
+			comment += "\n・" + s
+		}
+
+		lines := 0
+		for _, paragraph := range strings.Split(comment, "\n") {
+			lines += max(1, len(pdf.SplitText(paragraph, commentWidth-2)))
+		}
+		nameLines := max(1, len(pdf.SplitText(cs.CriteriaName, nameWidth-2)))
+		height := float64(max(lines, nameLines)) * 5
+
+		if pdf.GetY()+height > pageHeight-reportMargin {
+			pdf.AddPage()
+		}
+		x, y := pdf.GetXY()
+		pdf.Rect(x, y, nameWidth, height, "D")
+		pdf.MultiCell(nameWidth, 5, cs.CriteriaName, "", "L", false)
+		pdf.SetXY(x+nameWidth, y)
+		pdf.CellFormat(scoreWidth, height, fmt.Sprintf("%d / %d", cs.Score, cs.MaxScore), "1", 0, "C", false, 0, "")
+		pdf.Rect(x+nameWidth+scoreWidth, y, commentWidth, height, "D")
+		pdf.MultiCell(commentWidth, 5, comment, "", "L", false)
+		pdf.SetXY(x, y+height)
+	}
+
+	if detail.Comment != "" {
+		pdf.Ln(1)
+		pdf.MultiCell(0, 5, detail.Comment, "", "L", false)
+	}
+	pdf.Ln(3)
+}
+
+func writeReportAnswers(pdf *fpdf.Fpdf, report *services.ResultReport) {
+	if report.Submission == nil {
+		return
+	}
+
+	for _, q := range report.Test.Questions {
+		for _, answer := range report.Submission.Answers {
+			if answer.QuestionID != q.ID {
+				continue
+			}
+			writeReportHeading(pdf, fmt.Sprintf("問%d の解答（%d字）", q.Number, answer.WordCount))
+			pdf.SetFont(reportFont, "", 10)
+			pdf.MultiCell(0, reportLineHeight, answer.Content, "", "L", false)
+			pdf.Ln(3)
+		}
+	}
+}
+
+func writeReportFeedback(pdf *fpdf.Fpdf, result *entities.ScoringResult) {
+	sections := []struct {
+		title string
+		items []string
+	}{
+		{"良かった点", result.Strengths},
+		{"改善のポイント", result.Improvements},
+	}
+	for _, section := range sections {
+		if len(section.items) == 0 {
+			continue
+		}
+		writeReportHeading(pdf, section.title)
+		pdf.SetFont(reportFont, "", 10)
+		for _, item := range section.items {
+			pdf.MultiCell(0, reportLineHeight, "・"+item, "", "L", false)
+		}
+		pdf.Ln(3)
+	}
+}
+
+func writeReportHeading(pdf *fpdf.Fpdf, title string) {
+	pdf.SetFont(reportFont, "", 12)
+	pdf.SetDrawColor(60, 90, 150)
+	pdf.CellFormat(0, 8, title, "B", 1, "L", false, 0, "")
+	pdf.SetDrawColor(0, 0, 0)
+	pdf.Ln(2)
+}
@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"essay-test-backend/internal/application/dto"
+	"essay-test-backend/internal/application/usecases"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type AnalyticsHandler struct {
+	usecase *usecases.AnalyticsUsecase
+	logger  *zap.Logger
+}
+
+func NewAnalyticsHandler(usecase *usecases.AnalyticsUsecase, logger *zap.Logger) *AnalyticsHandler {
+	return &AnalyticsHandler{
+		usecase: usecase,
+		logger:  logger,
+	}
+}
+
+func (h *AnalyticsHandler) GetAnalytics(c *gin.Context) {
+	testID := c.Param("id")
+
+	bins, ok := h.bins(c)
+	if !ok {
+		return
+	}
+
+	analytics, err := h.usecase.GetAnalytics(c.Request.Context(), testID, bins)
+	if err != nil {
+		requestLogger(c, h.logger).Error("分析データの取得に失敗", zap.Error(err), zap.String("test_id", testID))
+		h.respondError(c, err, "分析データの取得に失敗しました")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    analytics,
+	})
+}
+
+func (h *AnalyticsHandler) Rebuild(c *gin.Context) {
+	testID := c.Param("id")
+
+	bins, ok := h.bins(c)
+	if !ok {
+		return
+	}
+
+	analytics, err := h.usecase.Rebuild(c.Request.Context(), testID, bins)
+	if err != nil {
+		requestLogger(c, h.logger).Error("分析データの再集計に失敗", zap.Error(err), zap.String("test_id", testID))
+		h.respondError(c, err, "分析データの再集計に失敗しました")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    analytics,
+		Message: "分析データを再集計しました",
+	})
+}
+
+func (h *AnalyticsHandler) bins(c *gin.Context) (int, bool) {
+	bins := 0
+	if v := c.Query("bins"); v != "" {
+		parsed, err := strconv.Atoi(v)
+		if err != nil {
+			parsed = -1
+		}
+		bins = parsed
+	}
+
+	bins, err := usecases.ValidateHistogramBins(bins)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Error:   "binsが無効です",
+		})
+		return 0, false
+	}
+	return bins, true
+}
+
+func (h *AnalyticsHandler) respondError(c *gin.Context, err error, message string) {
+	switch err.Error() {
+	case "test not found":
+		c.JSON(http.StatusNotFound, dto.APIResponse{Success: false, Error: "指定されたテストが見つかりません"})
+	default:
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{Success: false, Error: message})
+	}
+}
@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"net/http"
+
+	"essay-test-backend/internal/application/dto"
+	"essay-test-backend/internal/application/usecases"
+	"essay-test-backend/internal/presentation/middleware"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type AnnotationHandler struct {
+	usecase *usecases.AnnotationUsecase
+	logger  *zap.Logger
+}
+
+func NewAnnotationHandler(usecase *usecases.AnnotationUsecase, logger *zap.Logger) *AnnotationHandler {
+	return &AnnotationHandler{
+		usecase: usecase,
+		logger:  logger,
+	}
+}
+
+func (h *AnnotationHandler) GetAnnotations(c *gin.Context) {
+	submissionID := c.Param("id")
+
+	answers, err := h.usecase.GetAnnotations(c.Request.Context(), submissionID)
+	if err != nil {
+		requestLogger(c, h.logger).Error("添削の取得に失敗", zap.Error(err), zap.String("submission_id", submissionID))
+		h.respondError(c, err, "添削の取得に失敗しました")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    answers,
+	})
+}
+
+func (h *AnnotationHandler) CreateAnnotation(c *gin.Context) {
+	submissionID := c.Param("id")
+
+	var req dto.AnnotationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		requestLogger(c, h.logger).Error("リクエストの解析に失敗", zap.Error(err))
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Error:   "リクエストが無効です",
+		})
+		return
+	}
+
+	annotation, err := h.usecase.CreateAnnotation(c.Request.Context(), submissionID, middleware.UserID(c), req)
+	if err != nil {
+		requestLogger(c, h.logger).Error("添削の追加に失敗", zap.Error(err), zap.String("submission_id", submissionID))
+		h.respondError(c, err, "添削の追加に失敗しました")
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.APIResponse{
+		Success: true,
+		Data:    annotation,
+		Message: "添削を追加しました",
+	})
+}
+
+func (h *AnnotationHandler) UpdateAnnotation(c *gin.Context) {
+	annotationID := c.Param("id")
+
+	var req dto.AnnotationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		requestLogger(c, h.logger).Error("リクエストの解析に失敗", zap.Error(err))
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Error:   "リクエストが無効です",
+		})
+		return
+	}
+
+	annotation, err := h.usecase.UpdateAnnotation(c.Request.Context(), annotationID, middleware.UserID(c), req)
+	if err != nil {
+		requestLogger(c, h.logger).Error("添削の更新に失敗", zap.Error(err), zap.String("annotation_id", annotationID))
+		h.respondError(c, err, "添削の更新に失敗しました")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    annotation,
+		Message: "添削を更新しました",
+	})
+}
+
+func (h *AnnotationHandler) DeleteAnnotation(c *gin.Context) {
+	annotationID := c.Param("id")
+
+	if err := h.usecase.DeleteAnnotation(c.Request.Context(), annotationID); err != nil {
+		requestLogger(c, h.logger).Error("添削の削除に失敗", zap.Error(err), zap.String("annotation_id", annotationID))
+		h.respondError(c, err, "添削の削除に失敗しました")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Message: "添削を削除しました",
+	})
+}
+
+func (h *AnnotationHandler) respondError(c *gin.Context, err error, message string) {
+	switch err.Error() {
+	case "submission not found":
+		c.JSON(http.StatusNotFound, dto.APIResponse{Success: false, Error: "提出物が見つかりません"})
+	case "answer not found":
+		c.JSON(http.StatusNotFound, dto.APIResponse{Success: false, Error: "回答が見つかりません"})
+	case "annotation not found":
+		c.JSON(http.StatusNotFound, dto.APIResponse{Success: false, Error: "添削が見つかりません"})
+	case "invalid range":
+		c.JSON(http.StatusBadRequest, dto.APIResponse{Success: false, Error: "添削の範囲が回答の本文外です"})
+	case "invalid annotation":
+		c.JSON(http.StatusBadRequest, dto.APIResponse{Success: false, Error: "添削の分類または重要度が不正です"})
+	default:
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{Success: false, Error: message})
+	}
+}
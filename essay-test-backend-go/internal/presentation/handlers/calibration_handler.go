@@ -0,0 +1,198 @@
+package handlers
+
+import (
+	"net/http"
+
+	"essay-test-backend/internal/application/dto"
+	"essay-test-backend/internal/application/usecases"
+	"essay-test-backend/internal/presentation/middleware"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type CalibrationHandler struct {
+	usecase *usecases.CalibrationUsecase
+	logger  *zap.Logger
+}
+
+func NewCalibrationHandler(usecase *usecases.CalibrationUsecase, logger *zap.Logger) *CalibrationHandler {
+	return &CalibrationHandler{
+		usecase: usecase,
+		logger:  logger,
+	}
+}
+
+func (h *CalibrationHandler) CreateAnchor(c *gin.Context) {
+	testID := c.Param("id")
+
+	var req dto.AnchorEssayRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		requestLogger(c, h.logger).Error("リクエストの解析に失敗", zap.Error(err))
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Error:   "リクエストが無効です",
+		})
+		return
+	}
+
+	anchor, err := h.usecase.CreateAnchor(c.Request.Context(), testID, middleware.UserID(c), req)
+	if err != nil {
+		requestLogger(c, h.logger).Error("アンカー答案の登録に失敗", zap.Error(err), zap.String("test_id", testID))
+		h.respondError(c, err, "アンカー答案の登録に失敗しました")
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.APIResponse{
+		Success: true,
+		Data:    anchor,
+		Message: "アンカー答案を登録しました",
+	})
+}
+
+func (h *CalibrationHandler) ListAnchors(c *gin.Context) {
+	testID := c.Param("id")
+
+	anchors, err := h.usecase.ListAnchors(c.Request.Context(), testID)
+	if err != nil {
+		requestLogger(c, h.logger).Error("アンカー答案の取得に失敗", zap.Error(err), zap.String("test_id", testID))
+		h.respondError(c, err, "アンカー答案の取得に失敗しました")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    anchors,
+	})
+}
+
+func (h *CalibrationHandler) DeleteAnchor(c *gin.Context) {
+	testID := c.Param("id")
+	anchorID := c.Param("anchorId")
+
+	if err := h.usecase.DeleteAnchor(c.Request.Context(), testID, anchorID); err != nil {
+		requestLogger(c, h.logger).Error("アンカー答案の削除に失敗", zap.Error(err), zap.String("anchor_id", anchorID))
+		h.respondError(c, err, "アンカー答案の削除に失敗しました")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Message: "アンカー答案を削除しました",
+	})
+}
+
+func (h *CalibrationHandler) Run(c *gin.Context) {
+	testID := c.Param("id")
+
+	run, err := h.usecase.Run(c.Request.Context(), testID, middleware.UserID(c))
+	if err != nil {
+		requestLogger(c, h.logger).Error("採点較正の実行に失敗", zap.Error(err), zap.String("test_id", testID))
+		h.respondError(c, err, "採点較正の実行に失敗しました")
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.APIResponse{
+		Success: true,
+		Data:    run,
+	})
+}
+
+func (h *CalibrationHandler) ListRuns(c *gin.Context) {
+	testID := c.Param("id")
+
+	runs, err := h.usecase.ListRuns(c.Request.Context(), testID)
+	if err != nil {
+		requestLogger(c, h.logger).Error("採点較正結果の取得に失敗", zap.Error(err), zap.String("test_id", testID))
+		h.respondError(c, err, "採点較正結果の取得に失敗しました")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    runs,
+	})
+}
+
+func (h *CalibrationHandler) GetCalibration(c *gin.Context) {
+	testID := c.Param("id")
+
+	calibration, err := h.usecase.GetCalibration(c.Request.Context(), testID)
+	if err != nil {
+		requestLogger(c, h.logger).Error("採点較正の取得に失敗", zap.Error(err), zap.String("test_id", testID))
+		h.respondError(c, err, "採点較正の取得に失敗しました")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    calibration,
+	})
+}
+
+func (h *CalibrationHandler) ApplyCalibration(c *gin.Context) {
+	testID := c.Param("id")
+
+	var req dto.ApplyCalibrationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		requestLogger(c, h.logger).Error("リクエストの解析に失敗", zap.Error(err))
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Error:   "リクエストが無効です",
+		})
+		return
+	}
+
+	calibration, err := h.usecase.Apply(c.Request.Context(), testID, middleware.UserID(c), req)
+	if err != nil {
+		requestLogger(c, h.logger).Error("採点較正の適用に失敗", zap.Error(err), zap.String("test_id", testID))
+		h.respondError(c, err, "採点較正の適用に失敗しました")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    calibration,
+		Message: "以降の自動採点に較正を適用します",
+	})
+}
+
+func (h *CalibrationHandler) RemoveCalibration(c *gin.Context) {
+	testID := c.Param("id")
+
+	if err := h.usecase.RemoveCalibration(c.Request.Context(), testID); err != nil {
+		requestLogger(c, h.logger).Error("採点較正の解除に失敗", zap.Error(err), zap.String("test_id", testID))
+		h.respondError(c, err, "採点較正の解除に失敗しました")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Message: "採点較正を解除しました",
+	})
+}
+
+func (h *CalibrationHandler) respondError(c *gin.Context, err error, message string) {
+	switch err.Error() {
+	case "test not found":
+		c.JSON(http.StatusNotFound, dto.APIResponse{Success: false, Error: "指定されたテストが見つかりません"})
+	case "anchor not found":
+		c.JSON(http.StatusNotFound, dto.APIResponse{Success: false, Error: "アンカー答案が見つかりません"})
+	case "run not found":
+		c.JSON(http.StatusNotFound, dto.APIResponse{Success: false, Error: "採点較正結果が見つかりません"})
+	case "calibration not found":
+		c.JSON(http.StatusNotFound, dto.APIResponse{Success: false, Error: "このテストには採点較正が設定されていません"})
+	case "invalid anchor":
+		c.JSON(http.StatusBadRequest, dto.APIResponse{Success: false, Error: "アンカー答案の回答がテストの問題と一致しません"})
+	case "invalid criteria score":
+		c.JSON(http.StatusBadRequest, dto.APIResponse{Success: false, Error: "基準点の問題番号・採点基準がテストの採点基準と一致しないか、重複しています"})
+	case "score out of range":
+		c.JSON(http.StatusBadRequest, dto.APIResponse{Success: false, Error: "基準点が配点の範囲外です"})
+	case "no anchors":
+		c.JSON(http.StatusBadRequest, dto.APIResponse{Success: false, Error: "アンカー答案が登録されていません"})
+	case "not enough anchors":
+		c.JSON(http.StatusBadRequest, dto.APIResponse{Success: false, Error: "較正に必要なアンカー答案が不足しています"})
+	default:
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{Success: false, Error: message})
+	}
+}
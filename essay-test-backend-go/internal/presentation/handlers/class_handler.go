@@ -0,0 +1,314 @@
+package handlers
+
+import (
+	"net/http"
+
+	"essay-test-backend/internal/application/dto"
+	"essay-test-backend/internal/application/usecases"
+	"essay-test-backend/internal/presentation/middleware"
+	"essay-test-backend/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type ClassHandler struct {
+	usecase *usecases.ClassUsecase
+	logger  *zap.Logger
+}
+
+func NewClassHandler(usecase *usecases.ClassUsecase, logger *zap.Logger) *ClassHandler {
+	return &ClassHandler{
+		usecase: usecase,
+		logger:  logger,
+	}
+}
+
+func (h *ClassHandler) CreateOrganization(c *gin.Context) {
+	var req dto.OrganizationRequest
+	if !h.bind(c, &req) {
+		return
+	}
+
+	organization, err := h.usecase.CreateOrganization(c.Request.Context(), middleware.UserID(c), req)
+	if err != nil {
+		requestLogger(c, h.logger).Error("組織の作成に失敗", zap.Error(err))
+		h.respondError(c, err, "組織の作成に失敗しました")
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.APIResponse{
+		Success: true,
+		Data:    organization,
+		Message: "組織を作成しました",
+	})
+}
+
+func (h *ClassHandler) ListOrganizations(c *gin.Context) {
+	organizations, err := h.usecase.ListOrganizations(c.Request.Context())
+	if err != nil {
+		requestLogger(c, h.logger).Error("組織一覧の取得に失敗", zap.Error(err))
+		h.respondError(c, err, "組織一覧の取得に失敗しました")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    organizations,
+	})
+}
+
+func (h *ClassHandler) CreateClass(c *gin.Context) {
+	var req dto.ClassRequest
+	if !h.bind(c, &req) {
+		return
+	}
+
+	class, err := h.usecase.CreateClass(c.Request.Context(), middleware.UserID(c), req)
+	if err != nil {
+		requestLogger(c, h.logger).Error("クラスの作成に失敗", zap.Error(err))
+		h.respondError(c, err, "クラスの作成に失敗しました")
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.APIResponse{
+		Success: true,
+		Data:    class,
+		Message: "クラスを作成しました",
+	})
+}
+
+func (h *ClassHandler) ListTeacherClasses(c *gin.Context) {
+	classes, err := h.usecase.ListTeacherClasses(c.Request.Context(), middleware.UserID(c))
+	if err != nil {
+		requestLogger(c, h.logger).Error("クラス一覧の取得に失敗", zap.Error(err))
+		h.respondError(c, err, "クラス一覧の取得に失敗しました")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    classes,
+	})
+}
+
+func (h *ClassHandler) GetClass(c *gin.Context) {
+	classID := c.Param("id")
+
+	class, err := h.usecase.GetClass(c.Request.Context(), classID, middleware.UserID(c), isAdmin(c))
+	if err != nil {
+		requestLogger(c, h.logger).Error("クラスの取得に失敗", zap.Error(err), zap.String("class_id", classID))
+		h.respondError(c, err, "クラスの取得に失敗しました")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    class,
+	})
+}
+
+func (h *ClassHandler) RegenerateInviteCode(c *gin.Context) {
+	classID := c.Param("id")
+
+	class, err := h.usecase.RegenerateInviteCode(c.Request.Context(), classID, middleware.UserID(c), isAdmin(c))
+	if err != nil {
+		requestLogger(c, h.logger).Error("招待コードの再発行に失敗", zap.Error(err), zap.String("class_id", classID))
+		h.respondError(c, err, "招待コードの再発行に失敗しました")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    class,
+		Message: "招待コードを再発行しました",
+	})
+}
+
+func (h *ClassHandler) AddTeacher(c *gin.Context) {
+	classID := c.Param("id")
+
+	var req dto.ClassTeacherRequest
+	if !h.bind(c, &req) {
+		return
+	}
+
+	member, err := h.usecase.AddTeacher(c.Request.Context(), classID, middleware.UserID(c), isAdmin(c), req)
+	if err != nil {
+		requestLogger(c, h.logger).Error("教員の追加に失敗", zap.Error(err), zap.String("class_id", classID))
+		h.respondError(c, err, "教員の追加に失敗しました")
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.APIResponse{
+		Success: true,
+		Data:    member,
+		Message: "教員を追加しました",
+	})
+}
+
+func (h *ClassHandler) JoinClass(c *gin.Context) {
+	var req dto.JoinClassRequest
+	if !h.bind(c, &req) {
+		return
+	}
+
+	class, err := h.usecase.JoinClass(c.Request.Context(), middleware.UserID(c), req)
+	if err != nil {
+		requestLogger(c, h.logger).Warn("クラスへの参加に失敗", zap.Error(err))
+		h.respondError(c, err, "クラスへの参加に失敗しました")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    class,
+		Message: "クラスに参加しました",
+	})
+}
+
+func (h *ClassHandler) ListMyClasses(c *gin.Context) {
+	classes, err := h.usecase.ListMyClasses(c.Request.Context(), middleware.UserID(c))
+	if err != nil {
+		requestLogger(c, h.logger).Error("クラス一覧の取得に失敗", zap.Error(err))
+		h.respondError(c, err, "クラス一覧の取得に失敗しました")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    classes,
+	})
+}
+
+func (h *ClassHandler) ListMyAssignments(c *gin.Context) {
+	assignments, err := h.usecase.ListMyAssignments(c.Request.Context(), middleware.UserID(c))
+	if err != nil {
+		requestLogger(c, h.logger).Error("課題一覧の取得に失敗", zap.Error(err))
+		h.respondError(c, err, "課題一覧の取得に失敗しました")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    assignments,
+	})
+}
+
+func (h *ClassHandler) CreateAssignment(c *gin.Context) {
+	classID := c.Param("id")
+
+	var req dto.AssignmentRequest
+	if !h.bind(c, &req) {
+		return
+	}
+
+	assignment, err := h.usecase.CreateAssignment(c.Request.Context(), classID, middleware.UserID(c), isAdmin(c), req)
+	if err != nil {
+		requestLogger(c, h.logger).Error("課題の作成に失敗", zap.Error(err), zap.String("class_id", classID))
+		h.respondError(c, err, "課題の作成に失敗しました")
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.APIResponse{
+		Success: true,
+		Data:    assignment,
+		Message: "課題を作成しました",
+	})
+}
+
+func (h *ClassHandler) ListAssignments(c *gin.Context) {
+	classID := c.Param("id")
+
+	assignments, err := h.usecase.ListAssignments(c.Request.Context(), classID, middleware.UserID(c), isAdmin(c))
+	if err != nil {
+		requestLogger(c, h.logger).Error("課題一覧の取得に失敗", zap.Error(err), zap.String("class_id", classID))
+		h.respondError(c, err, "課題一覧の取得に失敗しました")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    assignments,
+	})
+}
+
+func (h *ClassHandler) GetAssignmentProgress(c *gin.Context) {
+	assignmentID := c.Param("id")
+
+	progress, err := h.usecase.GetAssignmentProgress(c.Request.Context(), assignmentID, middleware.UserID(c), isAdmin(c))
+	if err != nil {
+		requestLogger(c, h.logger).Error("課題の提出状況の取得に失敗", zap.Error(err), zap.String("assignment_id", assignmentID))
+		h.respondError(c, err, "課題の提出状況の取得に失敗しました")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    progress,
+	})
+}
+
+func (h *ClassHandler) GetClassScores(c *gin.Context) {
+	classID := c.Param("id")
+
+	scores, err := h.usecase.GetClassScores(c.Request.Context(), classID, middleware.UserID(c), isAdmin(c))
+	if err != nil {
+		requestLogger(c, h.logger).Error("クラスの成績の取得に失敗", zap.Error(err), zap.String("class_id", classID))
+		h.respondError(c, err, "クラスの成績の取得に失敗しました")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    scores,
+	})
+}
+
+func (h *ClassHandler) bind(c *gin.Context, req interface{}) bool {
+	if err := c.ShouldBindJSON(req); err != nil {
+		requestLogger(c, h.logger).Error("リクエストの解析に失敗", zap.Error(err))
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Error:   "リクエストが無効です",
+		})
+		return false
+	}
+	return true
+}
+
+func (h *ClassHandler) respondError(c *gin.Context, err error, message string) {
+	switch err.Error() {
+	case "organization not found":
+		c.JSON(http.StatusNotFound, dto.APIResponse{Success: false, Error: "組織が見つかりません"})
+	case "class not found":
+		c.JSON(http.StatusNotFound, dto.APIResponse{Success: false, Error: "クラスが見つかりません"})
+	case "assignment not found":
+		c.JSON(http.StatusNotFound, dto.APIResponse{Success: false, Error: "課題が見つかりません"})
+	case "test not found":
+		c.JSON(http.StatusNotFound, dto.APIResponse{Success: false, Error: "指定されたテストが見つかりません"})
+	case "invalid assignment":
+		c.JSON(http.StatusBadRequest, dto.APIResponse{Success: false, Error: "課題の設定が正しくありません"})
+	case "invalid invite code":
+		c.JSON(http.StatusNotFound, dto.APIResponse{Success: false, Error: "招待コードが正しくありません"})
+	case "already a member":
+		c.JSON(http.StatusConflict, dto.APIResponse{Success: false, Error: "既にクラスのメンバーです"})
+	default:
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{Success: false, Error: message})
+	}
+}
+
+// isAdmin lets administrators manage every class without being a member
+func isAdmin(c *gin.Context) bool {
+	return middleware.UserRole(c) == middleware.RoleAdmin
+}
+
+func isTeacher(c *gin.Context) bool {
+	role := middleware.UserRole(c)
+	return role == middleware.RoleTeacher || role == middleware.RoleAdmin
+}
+
+// requestLogger returns the logger the access log middleware attached to the request
+func requestLogger(c *gin.Context, fallback *zap.Logger) *zap.Logger {
+	return logger.FromContext(c.Request.Context(), fallback)
+}
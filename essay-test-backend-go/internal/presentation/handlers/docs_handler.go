@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"essay-test-backend/pkg/openapi"
+
+	"github.com/gin-gonic/gin"
+	swaggerFiles "github.com/swaggo/files/v2"
+)
+
+// swaggerInitializer replaces the initializer bundled with swagger-ui, which points at the petstore example
+const swaggerInitializer = `window.onload = function() {
+  window.ui = SwaggerUIBundle({
+    url: "/api/v1/openapi.json",
+    dom_id: "#swagger-ui",
+    deepLinking: true,
+    presets: [SwaggerUIBundle.presets.apis, SwaggerUIStandalonePreset],
+    plugins: [SwaggerUIBundle.plugins.DownloadUrl],
+    layout: "StandaloneLayout"
+  });
+};
+`
+
+type DocsHandler struct {
+	spec   []byte
+	assets http.Handler
+}
+
+func NewDocsHandler() *DocsHandler {
+	return &DocsHandler{
+		assets: http.FileServer(http.FS(swaggerFiles.FS)),
+	}
+}
+
+// SetSpec sets the document served by OpenAPI; the spec describes the registered routes,
+// so it is set once they are all registered and before the server starts
+func (h *DocsHandler) SetSpec(spec *openapi.Document) error {
+	body, err := json.Marshal(spec)
+	if err != nil {
+		return err
+	}
+	h.spec = body
+	return nil
+}
+
+// OpenAPI serves the OpenAPI document of the API
+func (h *DocsHandler) OpenAPI(c *gin.Context) {
+	c.Data(http.StatusOK, "application/json; charset=utf-8", h.spec)
+}
+
+// Viewer serves swagger-ui, bundled into the binary, showing the document served by OpenAPI
+func (h *DocsHandler) Viewer(c *gin.Context) {
+	file := strings.TrimPrefix(c.Param("filepath"), "/")
+	switch file {
+	case "swagger-initializer.js":
+		c.Data(http.StatusOK, "application/javascript; charset=utf-8", []byte(swaggerInitializer))
+		return
+	case "":
+		file = "index.html"
+	}
+
+	// FileServerはindex.htmlへのリクエストを「./」にリダイレクトするため、ファイル名を除いたパスで渡す
+	req := c.Request.Clone(c.Request.Context())
+	req.URL.Path = "/" + file
+	if file == "index.html" {
+		req.URL.Path = "/"
+	}
+	h.assets.ServeHTTP(c.Writer, req)
+}
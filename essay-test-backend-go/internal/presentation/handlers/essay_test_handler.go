@@ -1,10 +1,12 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 
 	"essay-test-backend/internal/application/dto"
 	"essay-test-backend/internal/application/usecases"
+	"essay-test-backend/internal/presentation/middleware"
 
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
@@ -23,11 +25,24 @@ func NewEssayTestHandler(usecase *usecases.EssayTestUsecase, logger *zap.Logger)
 }
 
 func (h *EssayTestHandler) GetAllTests(c *gin.Context) {
-	h.logger.Info("すべてのテスト取得リクエスト")
-	
-	tests, err := h.usecase.GetAllTests(c.Request.Context())
+	requestLogger(c, h.logger).Info("すべてのテスト取得リクエスト")
+
+	page, ok := h.listTests(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    page,
+	})
+}
+
+// GetAllTestsLegacy returns every test as a bare array, as the old API did
+func (h *EssayTestHandler) GetAllTestsLegacy(c *gin.Context) {
+	tests, err := h.usecase.ListAllTests(c.Request.Context())
 	if err != nil {
-		h.logger.Error("テストの取得に失敗", zap.Error(err))
+		requestLogger(c, h.logger).Error("テストの取得に失敗", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, dto.APIResponse{
 			Success: false,
 			Error:   "テストの取得に失敗しました",
@@ -35,20 +50,54 @@ func (h *EssayTestHandler) GetAllTests(c *gin.Context) {
 		return
 	}
 
-	h.logger.Info("テスト取得成功", zap.Int("count", len(tests)))
+	requestLogger(c, h.logger).Info("テスト取得成功", zap.Int("count", len(tests)))
 	c.JSON(http.StatusOK, dto.APIResponse{
 		Success: true,
 		Data:    tests,
 	})
 }
 
+func (h *EssayTestHandler) listTests(c *gin.Context) (*dto.TestPageResponse, bool) {
+	var req dto.TestListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		requestLogger(c, h.logger).Error("リクエストの解析に失敗", zap.Error(err))
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Error:   "リクエストが無効です",
+		})
+		return nil, false
+	}
+
+	page, err := h.usecase.GetAllTests(c.Request.Context(), req)
+	if err != nil {
+		requestLogger(c, h.logger).Error("テストの取得に失敗", zap.Error(err))
+		switch err.Error() {
+		case "invalid sort":
+			c.JSON(http.StatusBadRequest, dto.APIResponse{Success: false, Error: "sortはnewestまたはpopularを指定してください"})
+		case "invalid limit":
+			c.JSON(http.StatusBadRequest, dto.APIResponse{Success: false, Error: "limitが無効です"})
+		case "invalid cursor":
+			c.JSON(http.StatusBadRequest, dto.APIResponse{Success: false, Error: "cursorが無効です"})
+		default:
+			c.JSON(http.StatusInternalServerError, dto.APIResponse{
+				Success: false,
+				Error:   "テストの取得に失敗しました",
+			})
+		}
+		return nil, false
+	}
+
+	requestLogger(c, h.logger).Info("テスト取得成功", zap.Int("count", len(page.Tests)))
+	return page, true
+}
+
 func (h *EssayTestHandler) GetTestByID(c *gin.Context) {
 	id := c.Param("id")
-	h.logger.Info("テスト取得リクエスト", zap.String("test_id", id))
+	requestLogger(c, h.logger).Info("テスト取得リクエスト", zap.String("test_id", id))
 	
 	test, err := h.usecase.GetTestByID(c.Request.Context(), id)
 	if err != nil {
-		h.logger.Error("テストの取得に失敗", zap.Error(err), zap.String("test_id", id))
+		requestLogger(c, h.logger).Error("テストの取得に失敗", zap.Error(err), zap.String("test_id", id))
 		
 		if err.Error() == "test not found" {
 			c.JSON(http.StatusNotFound, dto.APIResponse{
@@ -64,7 +113,7 @@ func (h *EssayTestHandler) GetTestByID(c *gin.Context) {
 		return
 	}
 
-	h.logger.Info("テスト取得成功", zap.String("test_id", id), zap.String("title", test.Title))
+	requestLogger(c, h.logger).Info("テスト取得成功", zap.String("test_id", id), zap.String("title", test.Title))
 	c.JSON(http.StatusOK, dto.APIResponse{
 		Success: true,
 		Data:    test,
@@ -74,7 +123,15 @@ func (h *EssayTestHandler) GetTestByID(c *gin.Context) {
 func (h *EssayTestHandler) SubmitEssay(c *gin.Context) {
 	var req dto.SubmissionRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		h.logger.Error("リクエストの解析に失敗", zap.Error(err))
+		requestLogger(c, h.logger).Error("リクエストの解析に失敗", zap.Error(err))
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			c.JSON(http.StatusRequestEntityTooLarge, dto.APIResponse{
+				Success: false,
+				Error:   "リクエストが大きすぎます",
+			})
+			return
+		}
 		c.JSON(http.StatusBadRequest, dto.APIResponse{
 			Success: false,
 			Error:   "リクエストが無効です",
@@ -82,21 +139,62 @@ func (h *EssayTestHandler) SubmitEssay(c *gin.Context) {
 		return
 	}
 
-	h.logger.Info("小論文提出リクエスト", 
+	requestLogger(c, h.logger).Info("小論文提出リクエスト", 
 		zap.String("test_id", req.TestID),
-		zap.String("user_id", req.UserID),
+		zap.String("user_id", middleware.UserID(c)),
 		zap.Int("answers_count", len(req.Answers)))
 
-	result, err := h.usecase.SubmitEssay(c.Request.Context(), req)
+	result, err := h.usecase.SubmitEssay(c.Request.Context(), middleware.UserID(c), req)
 	if err != nil {
-		h.logger.Error("小論文の提出に失敗", zap.Error(err))
+		requestLogger(c, h.logger).Error("小論文の提出に失敗", zap.Error(err))
 		
-		if err.Error() == "test not found" {
+		switch err.Error() {
+		case "test not found":
 			c.JSON(http.StatusNotFound, dto.APIResponse{
 				Success: false,
 				Error:   "指定されたテストが見つかりません",
 			})
-		} else {
+		case "authentication required":
+			c.JSON(http.StatusUnauthorized, dto.APIResponse{
+				Success: false,
+				Error:   "課題・手書き答案の提出には認証が必要です",
+			})
+		case "assignment not found":
+			c.JSON(http.StatusNotFound, dto.APIResponse{
+				Success: false,
+				Error:   "課題が見つかりません",
+			})
+		case "not a class member":
+			c.JSON(http.StatusForbidden, dto.APIResponse{
+				Success: false,
+				Error:   "この課題のクラスに参加していません",
+			})
+		case "assignment not open":
+			c.JSON(http.StatusForbidden, dto.APIResponse{
+				Success: false,
+				Error:   "課題の受付期間外です",
+			})
+		case "attempt limit reached":
+			c.JSON(http.StatusConflict, dto.APIResponse{
+				Success: false,
+				Error:   "提出回数の上限に達しています",
+			})
+		case "invalid transcription":
+			c.JSON(http.StatusBadRequest, dto.APIResponse{
+				Success: false,
+				Error:   "手書き答案の読み取り結果が見つからないか、この問題には使用できません",
+			})
+		case "answer too long":
+			c.JSON(http.StatusBadRequest, dto.APIResponse{
+				Success: false,
+				Error:   "回答が長すぎます",
+			})
+		case "transcription not confirmed":
+			c.JSON(http.StatusBadRequest, dto.APIResponse{
+				Success: false,
+				Error:   "手書き答案の読み取り結果を確認してから提出してください",
+			})
+		default:
 			c.JSON(http.StatusInternalServerError, dto.APIResponse{
 				Success: false,
 				Error:   "小論文の提出に失敗しました",
@@ -105,7 +203,7 @@ func (h *EssayTestHandler) SubmitEssay(c *gin.Context) {
 		return
 	}
 
-	h.logger.Info("小論文提出成功", 
+	requestLogger(c, h.logger).Info("小論文提出成功", 
 		zap.String("result_id", result.ResultID),
 		zap.Int("total_score", result.TotalScore))
 	
@@ -118,17 +216,22 @@ func (h *EssayTestHandler) SubmitEssay(c *gin.Context) {
 
 func (h *EssayTestHandler) GetResult(c *gin.Context) {
 	resultID := c.Param("id")
-	h.logger.Info("結果取得リクエスト", zap.String("result_id", resultID))
+	requestLogger(c, h.logger).Info("結果取得リクエスト", zap.String("result_id", resultID))
 	
 	result, err := h.usecase.GetResult(c.Request.Context(), resultID)
 	if err != nil {
-		h.logger.Error("結果の取得に失敗", zap.Error(err), zap.String("result_id", resultID))
+		requestLogger(c, h.logger).Error("結果の取得に失敗", zap.Error(err), zap.String("result_id", resultID))
 		
 		if err.Error() == "result not found" {
 			c.JSON(http.StatusNotFound, dto.APIResponse{
 				Success: false,
 				Error:   "結果が見つかりません",
 			})
+		} else if err.Error() == "rating in progress" {
+			c.JSON(http.StatusConflict, dto.APIResponse{
+				Success: false,
+				Error:   ratingInProgressMessage,
+			})
 		} else {
 			c.JSON(http.StatusInternalServerError, dto.APIResponse{
 				Success: false,
@@ -138,7 +241,7 @@ func (h *EssayTestHandler) GetResult(c *gin.Context) {
 		return
 	}
 
-	h.logger.Info("結果取得成功", 
+	requestLogger(c, h.logger).Info("結果取得成功", 
 		zap.String("result_id", resultID),
 		zap.String("test_title", result.TestTitle))
 	
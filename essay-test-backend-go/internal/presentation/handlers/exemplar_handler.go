@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"net/http"
+
+	"essay-test-backend/internal/application/dto"
+	"essay-test-backend/internal/application/usecases"
+	"essay-test-backend/internal/presentation/middleware"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type ExemplarHandler struct {
+	usecase *usecases.ExemplarUsecase
+	logger  *zap.Logger
+}
+
+func NewExemplarHandler(usecase *usecases.ExemplarUsecase, logger *zap.Logger) *ExemplarHandler {
+	return &ExemplarHandler{
+		usecase: usecase,
+		logger:  logger,
+	}
+}
+
+func (h *ExemplarHandler) CreateExemplar(c *gin.Context) {
+	testID := c.Param("id")
+
+	var req dto.ModelAnswerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		requestLogger(c, h.logger).Error("リクエストの解析に失敗", zap.Error(err))
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Error:   "リクエストが無効です",
+		})
+		return
+	}
+
+	exemplar, err := h.usecase.CreateExemplar(c.Request.Context(), testID, middleware.UserID(c), req)
+	if err != nil {
+		requestLogger(c, h.logger).Error("模範解答の登録に失敗", zap.Error(err), zap.String("test_id", testID))
+		h.respondError(c, err, "模範解答の登録に失敗しました")
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.APIResponse{
+		Success: true,
+		Data:    exemplar,
+		Message: "模範解答を登録しました",
+	})
+}
+
+func (h *ExemplarHandler) ListExemplars(c *gin.Context) {
+	testID := c.Param("id")
+
+	exemplars, err := h.usecase.ListExemplars(c.Request.Context(), testID)
+	if err != nil {
+		requestLogger(c, h.logger).Error("模範解答の取得に失敗", zap.Error(err), zap.String("test_id", testID))
+		h.respondError(c, err, "模範解答の取得に失敗しました")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    exemplars,
+	})
+}
+
+func (h *ExemplarHandler) UpdateExemplar(c *gin.Context) {
+	exemplarID := c.Param("id")
+
+	var req dto.ModelAnswerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		requestLogger(c, h.logger).Error("リクエストの解析に失敗", zap.Error(err))
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Error:   "リクエストが無効です",
+		})
+		return
+	}
+
+	exemplar, err := h.usecase.UpdateExemplar(c.Request.Context(), exemplarID, req)
+	if err != nil {
+		requestLogger(c, h.logger).Error("模範解答の更新に失敗", zap.Error(err), zap.String("exemplar_id", exemplarID))
+		h.respondError(c, err, "模範解答の更新に失敗しました")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    exemplar,
+		Message: "模範解答を更新しました",
+	})
+}
+
+func (h *ExemplarHandler) DeleteExemplar(c *gin.Context) {
+	exemplarID := c.Param("id")
+
+	if err := h.usecase.DeleteExemplar(c.Request.Context(), exemplarID); err != nil {
+		requestLogger(c, h.logger).Error("模範解答の削除に失敗", zap.Error(err), zap.String("exemplar_id", exemplarID))
+		h.respondError(c, err, "模範解答の削除に失敗しました")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Message: "模範解答を削除しました",
+	})
+}
+
+func (h *ExemplarHandler) ListReleasedExemplars(c *gin.Context) {
+	testID := c.Param("id")
+
+	exemplars, err := h.usecase.ListReleasedExemplars(c.Request.Context(), testID, middleware.UserID(c))
+	if err != nil {
+		requestLogger(c, h.logger).Warn("模範解答の取得に失敗", zap.Error(err), zap.String("test_id", testID))
+		h.respondError(c, err, "模範解答の取得に失敗しました")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    exemplars,
+	})
+}
+
+func (h *ExemplarHandler) respondError(c *gin.Context, err error, message string) {
+	switch err.Error() {
+	case "test not found":
+		c.JSON(http.StatusNotFound, dto.APIResponse{Success: false, Error: "指定されたテストが見つかりません"})
+	case "exemplar not found":
+		c.JSON(http.StatusNotFound, dto.APIResponse{Success: false, Error: "模範解答が見つかりません"})
+	case "invalid question":
+		c.JSON(http.StatusBadRequest, dto.APIResponse{Success: false, Error: "問題がテストに含まれていません"})
+	case "invalid score":
+		c.JSON(http.StatusBadRequest, dto.APIResponse{Success: false, Error: "点数が問題の配点の範囲外です"})
+	case "exemplars not released":
+		c.JSON(http.StatusForbidden, dto.APIResponse{Success: false, Error: "模範解答は答案の提出後に公開されます"})
+	default:
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{Success: false, Error: message})
+	}
+}
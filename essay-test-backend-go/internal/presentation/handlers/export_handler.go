@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"essay-test-backend/internal/application/dto"
+	"essay-test-backend/internal/application/usecases"
+	"essay-test-backend/internal/presentation/middleware"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type ExportHandler struct {
+	usecase *usecases.ExportUsecase
+	logger  *zap.Logger
+}
+
+func NewExportHandler(usecase *usecases.ExportUsecase, logger *zap.Logger) *ExportHandler {
+	return &ExportHandler{
+		usecase: usecase,
+		logger:  logger,
+	}
+}
+
+func (h *ExportHandler) ExportResults(c *gin.Context) {
+	var req dto.ResultExportRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		requestLogger(c, h.logger).Error("リクエストの解析に失敗", zap.Error(err))
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Error:   "リクエストが無効です",
+		})
+		return
+	}
+
+	export, err := h.usecase.PrepareResultExport(c.Request.Context(), middleware.UserID(c), isAdmin(c), req)
+	if err != nil {
+		requestLogger(c, h.logger).Error("成績の出力に失敗", zap.Error(err))
+		h.respondError(c, err)
+		return
+	}
+
+	c.Header("Content-Type", export.ContentType)
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, export.Filename))
+	c.Status(http.StatusOK)
+
+	// 出力を始めた後はステータスを変えられないので、途中のエラーは記録のみ行う
+	if err := export.Write(c.Request.Context(), c.Writer); err != nil {
+		requestLogger(c, h.logger).Error("成績の出力が途中で失敗", zap.Error(err), zap.String("filename", export.Filename))
+	}
+}
+
+func (h *ExportHandler) respondError(c *gin.Context, err error) {
+	switch err.Error() {
+	case "invalid export format":
+		c.JSON(http.StatusBadRequest, dto.APIResponse{Success: false, Error: "出力形式はcsvまたはxlsxを指定してください"})
+	case "export scope required":
+		c.JSON(http.StatusBadRequest, dto.APIResponse{Success: false, Error: "テスト、クラス、期間のいずれかを指定してください"})
+	case "invalid date range":
+		c.JSON(http.StatusBadRequest, dto.APIResponse{Success: false, Error: "期間の指定が正しくありません"})
+	case "test not found":
+		c.JSON(http.StatusNotFound, dto.APIResponse{Success: false, Error: "指定されたテストが見つかりません"})
+	case "class not found":
+		c.JSON(http.StatusNotFound, dto.APIResponse{Success: false, Error: "クラスが見つかりません"})
+	default:
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{Success: false, Error: "成績の出力に失敗しました"})
+	}
+}
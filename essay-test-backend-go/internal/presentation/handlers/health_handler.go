@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"net/http"
+
+	"essay-test-backend/internal/application/dto"
+	"essay-test-backend/internal/application/usecases"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type HealthHandler struct {
+	usecase *usecases.HealthUsecase
+	logger  *zap.Logger
+}
+
+func NewHealthHandler(usecase *usecases.HealthUsecase, logger *zap.Logger) *HealthHandler {
+	return &HealthHandler{
+		usecase: usecase,
+		logger:  logger,
+	}
+}
+
+// Livez reports that the process is serving requests; it does not check dependencies,
+// so an unavailable database does not get the process restarted
+func (h *HealthHandler) Livez(c *gin.Context) {
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    gin.H{"status": "alive"},
+	})
+}
+
+// Readyz reports each dependency and answers 503 while any of them is unavailable
+func (h *HealthHandler) Readyz(c *gin.Context) {
+	readiness := h.usecase.Readiness(c.Request.Context())
+	if readiness.Status != "ready" {
+		c.JSON(http.StatusServiceUnavailable, dto.APIResponse{
+			Success: false,
+			Data:    readiness,
+			Error:   "依存サービスが利用できません",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    readiness,
+	})
+}
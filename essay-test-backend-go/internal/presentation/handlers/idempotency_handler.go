@@ -0,0 +1,154 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+
+	"essay-test-backend/internal/application/dto"
+	"essay-test-backend/internal/application/usecases"
+	"essay-test-backend/internal/presentation/middleware"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+const (
+	HeaderIdempotencyKey    = "Idempotency-Key"
+	HeaderIdempotentReplay  = "Idempotent-Replayed"
+	maxIdempotencyKeyLength = 191
+)
+
+type IdempotencyHandler struct {
+	usecase *usecases.IdempotencyUsecase
+	logger  *zap.Logger
+}
+
+func NewIdempotencyHandler(usecase *usecases.IdempotencyUsecase, logger *zap.Logger) *IdempotencyHandler {
+	return &IdempotencyHandler{
+		usecase: usecase,
+		logger:  logger,
+	}
+}
+
+// Guard runs the route once per Idempotency-Key: a retry of the same request gets the stored response again,
+// and a different request with the same key is rejected with 409. Requests without the header pass through.
+func (h *IdempotencyHandler) Guard(c *gin.Context) {
+	key := c.GetHeader(HeaderIdempotencyKey)
+	if key == "" {
+		c.Next()
+		return
+	}
+	if len(key) > maxIdempotencyKeyLength {
+		c.AbortWithStatusJSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Error:   "Idempotency-Keyが長すぎます",
+		})
+		return
+	}
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, dto.APIResponse{Success: false, Error: "リクエストが大きすぎます"})
+			return
+		}
+		c.AbortWithStatusJSON(http.StatusBadRequest, dto.APIResponse{Success: false, Error: "リクエストが無効です"})
+		return
+	}
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+	ctx := c.Request.Context()
+	record, replay, err := h.usecase.Begin(ctx, idempotencyOwner(c), key, requestFingerprint(c, body))
+	if err != nil {
+		requestLogger(c, h.logger).Warn("Idempotency-Keyの確認に失敗", zap.Error(err), zap.String("idempotency_key", key))
+		h.respondError(c, err)
+		return
+	}
+	if replay {
+		c.Header(HeaderIdempotentReplay, "true")
+		c.Data(record.StatusCode, record.ContentType, []byte(record.Response))
+		c.Abort()
+		return
+	}
+
+	recorder := &responseRecorder{ResponseWriter: c.Writer}
+	c.Writer = recorder
+
+	// 処理がパニックで終わった場合も受付を解放し、再送で処理し直せるようにする
+	completed := false
+	defer func() {
+		if completed {
+			return
+		}
+		if err := h.usecase.Release(context.WithoutCancel(ctx), record); err != nil {
+			requestLogger(c, h.logger).Error("Idempotency-Keyの解放に失敗", zap.Error(err), zap.String("idempotency_key", key))
+		}
+	}()
+
+	c.Next()
+
+	completed = true
+	if err := h.usecase.Complete(context.WithoutCancel(ctx), record, recorder.Status(), recorder.Header().Get("Content-Type"), recorder.body.Bytes()); err != nil {
+		requestLogger(c, h.logger).Error("応答の保存に失敗", zap.Error(err), zap.String("idempotency_key", key))
+	}
+}
+
+func (h *IdempotencyHandler) respondError(c *gin.Context, err error) {
+	switch err.Error() {
+	case "idempotency key reused":
+		c.AbortWithStatusJSON(http.StatusConflict, dto.APIResponse{
+			Success: false,
+			Error:   "このIdempotency-Keyは別の内容のリクエストで使用されています",
+		})
+	case "request in progress":
+		c.Header("Retry-After", "1")
+		c.AbortWithStatusJSON(http.StatusConflict, dto.APIResponse{
+			Success: false,
+			Error:   "同じIdempotency-Keyのリクエストを処理中です",
+		})
+	default:
+		c.AbortWithStatusJSON(http.StatusInternalServerError, dto.APIResponse{
+			Success: false,
+			Error:   "リクエストの処理に失敗しました",
+		})
+	}
+}
+
+// idempotencyOwner scopes keys to the authenticated user, or to the client IP for anonymous requests
+// so that anonymous clients do not share each other's keys
+func idempotencyOwner(c *gin.Context) string {
+	if userID := middleware.UserID(c); userID != "" {
+		return userID
+	}
+	return "ip:" + c.ClientIP()
+}
+
+// requestFingerprint identifies a request by its method, path and body
+func requestFingerprint(c *gin.Context, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(c.Request.Method + " " + c.Request.URL.Path + "\n"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// responseRecorder keeps a copy of the response body while writing it to the client
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"essay-test-backend/internal/application/dto"
+	"essay-test-backend/internal/application/usecases"
+	"essay-test-backend/internal/presentation/middleware"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type IngestHandler struct {
+	usecase *usecases.IngestUsecase
+	logger  *zap.Logger
+}
+
+func NewIngestHandler(usecase *usecases.IngestUsecase, logger *zap.Logger) *IngestHandler {
+	return &IngestHandler{
+		usecase: usecase,
+		logger:  logger,
+	}
+}
+
+func (h *IngestHandler) IngestSubmissions(c *gin.Context) {
+	header, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Error:   "ファイル（file）を指定してください",
+		})
+		return
+	}
+
+	// 形式の指定がなければ拡張子から判断する
+	format := c.Query("format")
+	if format == "" {
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(header.Filename)), ".")
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		requestLogger(c, h.logger).Error("アップロードファイルを開けません", zap.Error(err))
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Error:   "ファイルを読み取れません",
+		})
+		return
+	}
+	defer file.Close()
+
+	report, err := h.usecase.IngestSubmissions(c.Request.Context(), middleware.UserID(c), format, file)
+	if err != nil {
+		requestLogger(c, h.logger).Error("一括登録に失敗", zap.Error(err), zap.String("filename", header.Filename))
+		h.respondError(c, err)
+		return
+	}
+
+	requestLogger(c, h.logger).Info("一括登録を受付",
+		zap.String("filename", header.Filename),
+		zap.String("user_id", middleware.UserID(c)))
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    report,
+		Message: fmt.Sprintf("%d件を登録し、採点待ちに追加しました", report.Created),
+	})
+}
+
+func (h *IngestHandler) respondError(c *gin.Context, err error) {
+	switch err.Error() {
+	case "invalid ingest format":
+		c.JSON(http.StatusBadRequest, dto.APIResponse{Success: false, Error: "形式はcsvまたはjsonlを指定してください"})
+	case "invalid ingest file":
+		c.JSON(http.StatusBadRequest, dto.APIResponse{Success: false, Error: "ファイルを読み取れません。ヘッダー（user_id, test_id, answer_1...）と形式を確認してください"})
+	case "ingest file empty":
+		c.JSON(http.StatusBadRequest, dto.APIResponse{Success: false, Error: "登録する行がありません"})
+	case "too many rows":
+		c.JSON(http.StatusRequestEntityTooLarge, dto.APIResponse{Success: false, Error: "行数が多すぎます。ファイルを分割してください"})
+	default:
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{Success: false, Error: "一括登録に失敗しました"})
+	}
+}
@@ -0,0 +1,206 @@
+package handlers
+
+import (
+	"net/http"
+
+	"essay-test-backend/internal/application/dto"
+	"essay-test-backend/internal/application/usecases"
+	"essay-test-backend/internal/presentation/middleware"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// 複数採点者による採点の確定前は、途中の点数を採点者にも生徒にも見せない
+const ratingInProgressMessage = "複数採点者による採点が確定するまで結果は公開されません"
+
+// 再採点で新しい版ができた結果には、レビューや採点を反映しない
+const resultSupersededMessage = "再採点により新しい結果が作成されています。最新の結果で操作してください"
+
+type RatingHandler struct {
+	usecase *usecases.RatingUsecase
+	logger  *zap.Logger
+}
+
+func NewRatingHandler(usecase *usecases.RatingUsecase, logger *zap.Logger) *RatingHandler {
+	return &RatingHandler{
+		usecase: usecase,
+		logger:  logger,
+	}
+}
+
+func (h *RatingHandler) AssignRaters(c *gin.Context) {
+	submissionID := c.Param("id")
+
+	var req dto.AssignRatersRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		requestLogger(c, h.logger).Error("リクエストの解析に失敗", zap.Error(err))
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Error:   "リクエストが無効です",
+		})
+		return
+	}
+
+	session, err := h.usecase.AssignRaters(c.Request.Context(), submissionID, middleware.UserID(c), req)
+	if err != nil {
+		requestLogger(c, h.logger).Error("採点者の割り当てに失敗", zap.Error(err), zap.String("submission_id", submissionID))
+		h.respondError(c, err, "採点者の割り当てに失敗しました")
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.APIResponse{
+		Success: true,
+		Data:    session,
+		Message: "採点者を割り当てました",
+	})
+}
+
+func (h *RatingHandler) AssignAdjudicator(c *gin.Context) {
+	submissionID := c.Param("id")
+
+	var req dto.AssignAdjudicatorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		requestLogger(c, h.logger).Error("リクエストの解析に失敗", zap.Error(err))
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Error:   "リクエストが無効です",
+		})
+		return
+	}
+
+	session, err := h.usecase.AssignAdjudicator(c.Request.Context(), submissionID, req)
+	if err != nil {
+		requestLogger(c, h.logger).Error("裁定者の割り当てに失敗", zap.Error(err), zap.String("submission_id", submissionID))
+		h.respondError(c, err, "裁定者の割り当てに失敗しました")
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.APIResponse{
+		Success: true,
+		Data:    session,
+		Message: "裁定者を割り当てました",
+	})
+}
+
+func (h *RatingHandler) GetSession(c *gin.Context) {
+	submissionID := c.Param("id")
+
+	session, err := h.usecase.GetSession(c.Request.Context(), submissionID)
+	if err != nil {
+		requestLogger(c, h.logger).Error("採点セッションの取得に失敗", zap.Error(err), zap.String("submission_id", submissionID))
+		h.respondError(c, err, "採点セッションの取得に失敗しました")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    session,
+	})
+}
+
+func (h *RatingHandler) ListAssignments(c *gin.Context) {
+	assignments, err := h.usecase.ListAssignments(c.Request.Context(), middleware.UserID(c))
+	if err != nil {
+		requestLogger(c, h.logger).Error("採点割り当ての取得に失敗", zap.Error(err))
+		h.respondError(c, err, "採点割り当ての取得に失敗しました")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    assignments,
+	})
+}
+
+func (h *RatingHandler) GetTask(c *gin.Context) {
+	assignmentID := c.Param("id")
+
+	task, err := h.usecase.GetTask(c.Request.Context(), assignmentID, middleware.UserID(c))
+	if err != nil {
+		requestLogger(c, h.logger).Error("採点課題の取得に失敗", zap.Error(err), zap.String("assignment_id", assignmentID))
+		h.respondError(c, err, "採点課題の取得に失敗しました")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    task,
+	})
+}
+
+func (h *RatingHandler) SubmitScores(c *gin.Context) {
+	assignmentID := c.Param("id")
+
+	var req dto.RaterScoresRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		requestLogger(c, h.logger).Error("リクエストの解析に失敗", zap.Error(err))
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Error:   "リクエストが無効です",
+		})
+		return
+	}
+
+	assignment, err := h.usecase.SubmitScores(c.Request.Context(), assignmentID, middleware.UserID(c), req)
+	if err != nil {
+		requestLogger(c, h.logger).Error("採点の提出に失敗", zap.Error(err), zap.String("assignment_id", assignmentID))
+		h.respondError(c, err, "採点の提出に失敗しました")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    assignment,
+		Message: "採点を提出しました",
+	})
+}
+
+func (h *RatingHandler) GetAgreement(c *gin.Context) {
+	testID := c.Param("id")
+
+	agreement, err := h.usecase.GetAgreement(c.Request.Context(), testID)
+	if err != nil {
+		requestLogger(c, h.logger).Error("採点一致度の取得に失敗", zap.Error(err), zap.String("test_id", testID))
+		h.respondError(c, err, "採点一致度の取得に失敗しました")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    agreement,
+	})
+}
+
+func (h *RatingHandler) respondError(c *gin.Context, err error, message string) {
+	switch err.Error() {
+	case "submission not found":
+		c.JSON(http.StatusNotFound, dto.APIResponse{Success: false, Error: "提出データが見つかりません"})
+	case "result not found":
+		c.JSON(http.StatusNotFound, dto.APIResponse{Success: false, Error: "結果が見つかりません"})
+	case "rating not found":
+		c.JSON(http.StatusNotFound, dto.APIResponse{Success: false, Error: "採点セッションが見つかりません"})
+	case "assignment not found":
+		c.JSON(http.StatusNotFound, dto.APIResponse{Success: false, Error: "採点割り当てが見つかりません"})
+	case "at least two raters required":
+		c.JSON(http.StatusBadRequest, dto.APIResponse{Success: false, Error: "採点者は2名以上指定してください"})
+	case "adjudicator must differ from raters":
+		c.JSON(http.StatusBadRequest, dto.APIResponse{Success: false, Error: "裁定者は採点者と別の教員を指定してください"})
+	case "invalid criteria score":
+		c.JSON(http.StatusBadRequest, dto.APIResponse{Success: false, Error: "採点項目が結果に含まれていません"})
+	case "score out of range":
+		c.JSON(http.StatusBadRequest, dto.APIResponse{Success: false, Error: "点数が配点の範囲外です"})
+	case "missing criteria scores":
+		c.JSON(http.StatusBadRequest, dto.APIResponse{Success: false, Error: "未採点の項目があります"})
+	case "rating already exists":
+		c.JSON(http.StatusConflict, dto.APIResponse{Success: false, Error: "この提出物には既に採点者が割り当てられています"})
+	case "adjudication not required", "adjudicator already assigned":
+		c.JSON(http.StatusConflict, dto.APIResponse{Success: false, Error: "裁定者を割り当てられる状態ではありません"})
+	case "assignment already submitted", "rating already finalized":
+		c.JSON(http.StatusConflict, dto.APIResponse{Success: false, Error: "採点は既に提出されています"})
+	case "result superseded":
+		c.JSON(http.StatusConflict, dto.APIResponse{Success: false, Error: resultSupersededMessage})
+	default:
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{Success: false, Error: message})
+	}
+}
@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"essay-test-backend/internal/application/dto"
+	"essay-test-backend/internal/application/usecases"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type ReportHandler struct {
+	usecase *usecases.ReportUsecase
+	logger  *zap.Logger
+}
+
+func NewReportHandler(usecase *usecases.ReportUsecase, logger *zap.Logger) *ReportHandler {
+	return &ReportHandler{
+		usecase: usecase,
+		logger:  logger,
+	}
+}
+
+func (h *ReportHandler) DownloadResultReport(c *gin.Context) {
+	resultID := c.Param("id")
+
+	pdf, filename, err := h.usecase.RenderResultReport(c.Request.Context(), resultID)
+	if err != nil {
+		requestLogger(c, h.logger).Error("採点レポートの生成に失敗", zap.Error(err), zap.String("result_id", resultID))
+		h.respondError(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	c.Data(http.StatusOK, "application/pdf", pdf)
+}
+
+func (h *ReportHandler) respondError(c *gin.Context, err error) {
+	switch err.Error() {
+	case "result not found", "test not found":
+		c.JSON(http.StatusNotFound, dto.APIResponse{Success: false, Error: "指定された結果が見つかりません"})
+	case "rating in progress":
+		c.JSON(http.StatusConflict, dto.APIResponse{Success: false, Error: ratingInProgressMessage})
+	case "report disabled":
+		c.JSON(http.StatusServiceUnavailable, dto.APIResponse{Success: false, Error: "PDFレポートは無効に設定されています"})
+	default:
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{Success: false, Error: "採点レポートの生成に失敗しました"})
+	}
+}
@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"net/http"
+
+	"essay-test-backend/internal/application/dto"
+	"essay-test-backend/internal/application/usecases"
+	"essay-test-backend/internal/presentation/middleware"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type RescoreHandler struct {
+	usecase *usecases.RescoreUsecase
+	logger  *zap.Logger
+}
+
+func NewRescoreHandler(usecase *usecases.RescoreUsecase, logger *zap.Logger) *RescoreHandler {
+	return &RescoreHandler{
+		usecase: usecase,
+		logger:  logger,
+	}
+}
+
+func (h *RescoreHandler) StartJob(c *gin.Context) {
+	var req dto.RescoreJobRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		requestLogger(c, h.logger).Error("リクエストの解析に失敗", zap.Error(err))
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Error:   "リクエストが無効です",
+		})
+		return
+	}
+
+	job, err := h.usecase.StartJob(c.Request.Context(), middleware.UserID(c), req)
+	if err != nil {
+		requestLogger(c, h.logger).Error("再採点ジョブの登録に失敗", zap.Error(err))
+		h.respondError(c, err, "再採点ジョブの登録に失敗しました")
+		return
+	}
+
+	c.JSON(http.StatusAccepted, dto.APIResponse{
+		Success: true,
+		Data:    job,
+		Message: "再採点ジョブを開始しました",
+	})
+}
+
+func (h *RescoreHandler) ListJobs(c *gin.Context) {
+	jobs, err := h.usecase.ListJobs(c.Request.Context())
+	if err != nil {
+		requestLogger(c, h.logger).Error("再採点ジョブ一覧の取得に失敗", zap.Error(err))
+		h.respondError(c, err, "再採点ジョブ一覧の取得に失敗しました")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    jobs,
+	})
+}
+
+func (h *RescoreHandler) GetJob(c *gin.Context) {
+	jobID := c.Param("id")
+
+	job, err := h.usecase.GetJob(c.Request.Context(), jobID)
+	if err != nil {
+		requestLogger(c, h.logger).Error("再採点ジョブの取得に失敗", zap.Error(err), zap.String("job_id", jobID))
+		h.respondError(c, err, "再採点ジョブの取得に失敗しました")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    job,
+	})
+}
+
+func (h *RescoreHandler) GetReport(c *gin.Context) {
+	jobID := c.Param("id")
+
+	report, err := h.usecase.GetReport(c.Request.Context(), jobID)
+	if err != nil {
+		requestLogger(c, h.logger).Error("再採点結果の取得に失敗", zap.Error(err), zap.String("job_id", jobID))
+		h.respondError(c, err, "再採点結果の取得に失敗しました")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    report,
+	})
+}
+
+func (h *RescoreHandler) GetResultVersions(c *gin.Context) {
+	submissionID := c.Param("id")
+
+	results, err := h.usecase.GetResultVersions(c.Request.Context(), submissionID)
+	if err != nil {
+		requestLogger(c, h.logger).Error("採点履歴の取得に失敗", zap.Error(err), zap.String("submission_id", submissionID))
+		h.respondError(c, err, "採点履歴の取得に失敗しました")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    results,
+	})
+}
+
+func (h *RescoreHandler) respondError(c *gin.Context, err error, message string) {
+	switch err.Error() {
+	case "test not found":
+		c.JSON(http.StatusNotFound, dto.APIResponse{Success: false, Error: "指定されたテストが見つかりません"})
+	case "submission not found":
+		c.JSON(http.StatusNotFound, dto.APIResponse{Success: false, Error: "提出物が見つかりません"})
+	case "job not found":
+		c.JSON(http.StatusNotFound, dto.APIResponse{Success: false, Error: "再採点ジョブが見つかりません"})
+	case "rating in progress":
+		c.JSON(http.StatusConflict, dto.APIResponse{Success: false, Error: ratingInProgressMessage})
+	case "rescore scope required":
+		c.JSON(http.StatusBadRequest, dto.APIResponse{Success: false, Error: "テストIDまたは期間を指定してください"})
+	case "invalid date range":
+		c.JSON(http.StatusBadRequest, dto.APIResponse{Success: false, Error: "期間の指定が不正です"})
+	default:
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{Success: false, Error: message})
+	}
+}
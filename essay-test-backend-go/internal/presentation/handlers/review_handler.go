@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"net/http"
+
+	"essay-test-backend/internal/application/dto"
+	"essay-test-backend/internal/application/usecases"
+	"essay-test-backend/internal/presentation/middleware"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type ReviewHandler struct {
+	usecase *usecases.ReviewUsecase
+	logger  *zap.Logger
+}
+
+func NewReviewHandler(usecase *usecases.ReviewUsecase, logger *zap.Logger) *ReviewHandler {
+	return &ReviewHandler{
+		usecase: usecase,
+		logger:  logger,
+	}
+}
+
+func (h *ReviewHandler) GetReview(c *gin.Context) {
+	submissionID := c.Param("id")
+	requestLogger(c, h.logger).Info("レビュー取得リクエスト", zap.String("submission_id", submissionID))
+
+	review, err := h.usecase.GetReview(c.Request.Context(), submissionID)
+	if err != nil {
+		requestLogger(c, h.logger).Error("レビューの取得に失敗", zap.Error(err), zap.String("submission_id", submissionID))
+		h.respondError(c, err, "レビューの取得に失敗しました")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    review,
+	})
+}
+
+func (h *ReviewHandler) SaveDraft(c *gin.Context) {
+	resultID := c.Param("id")
+
+	var req dto.ReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		requestLogger(c, h.logger).Error("リクエストの解析に失敗", zap.Error(err))
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Error:   "リクエストが無効です",
+		})
+		return
+	}
+
+	review, err := h.usecase.SaveDraft(c.Request.Context(), resultID, middleware.UserID(c), req)
+	if err != nil {
+		requestLogger(c, h.logger).Error("レビューの保存に失敗", zap.Error(err), zap.String("result_id", resultID))
+		h.respondError(c, err, "レビューの保存に失敗しました")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    review,
+		Message: "下書きを保存しました",
+	})
+}
+
+func (h *ReviewHandler) Publish(c *gin.Context) {
+	resultID := c.Param("id")
+
+	result, err := h.usecase.Publish(c.Request.Context(), resultID, middleware.UserID(c))
+	if err != nil {
+		requestLogger(c, h.logger).Error("レビューの公開に失敗", zap.Error(err), zap.String("result_id", resultID))
+		h.respondError(c, err, "レビューの公開に失敗しました")
+		return
+	}
+
+	requestLogger(c, h.logger).Info("レビュー公開成功",
+		zap.String("result_id", resultID),
+		zap.Int("total_score", result.TotalScore))
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    result,
+		Message: "採点結果を公開しました",
+	})
+}
+
+func (h *ReviewHandler) GetAuditLogs(c *gin.Context) {
+	resultID := c.Param("id")
+
+	logs, err := h.usecase.GetAuditLogs(c.Request.Context(), resultID)
+	if err != nil {
+		requestLogger(c, h.logger).Error("監査ログの取得に失敗", zap.Error(err), zap.String("result_id", resultID))
+		h.respondError(c, err, "監査ログの取得に失敗しました")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    logs,
+	})
+}
+
+func (h *ReviewHandler) respondError(c *gin.Context, err error, message string) {
+	switch err.Error() {
+	case "submission not found":
+		c.JSON(http.StatusNotFound, dto.APIResponse{Success: false, Error: "提出データが見つかりません"})
+	case "result not found":
+		c.JSON(http.StatusNotFound, dto.APIResponse{Success: false, Error: "結果が見つかりません"})
+	case "invalid criteria score", "invalid question score":
+		c.JSON(http.StatusBadRequest, dto.APIResponse{Success: false, Error: "修正対象の採点項目が結果に含まれていません"})
+	case "score out of range":
+		c.JSON(http.StatusBadRequest, dto.APIResponse{Success: false, Error: "点数が配点の範囲外です"})
+	case "no draft review":
+		c.JSON(http.StatusConflict, dto.APIResponse{Success: false, Error: "公開する下書きがありません"})
+	case "rating in progress":
+		c.JSON(http.StatusConflict, dto.APIResponse{Success: false, Error: ratingInProgressMessage})
+	case "result superseded":
+		c.JSON(http.StatusConflict, dto.APIResponse{Success: false, Error: resultSupersededMessage})
+	default:
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{Success: false, Error: message})
+	}
+}
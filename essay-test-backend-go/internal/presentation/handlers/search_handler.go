@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"net/http"
+
+	"essay-test-backend/internal/application/dto"
+	"essay-test-backend/internal/application/usecases"
+	"essay-test-backend/internal/presentation/middleware"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type SearchHandler struct {
+	usecase *usecases.SearchUsecase
+	logger  *zap.Logger
+}
+
+func NewSearchHandler(usecase *usecases.SearchUsecase, logger *zap.Logger) *SearchHandler {
+	return &SearchHandler{
+		usecase: usecase,
+		logger:  logger,
+	}
+}
+
+func (h *SearchHandler) SearchEssays(c *gin.Context) {
+	var req dto.EssaySearchRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		requestLogger(c, h.logger).Error("リクエストの解析に失敗", zap.Error(err))
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Error:   "リクエストが無効です",
+		})
+		return
+	}
+
+	result, err := h.usecase.SearchEssays(c.Request.Context(), middleware.UserID(c), isAdmin(c), req)
+	if err != nil {
+		requestLogger(c, h.logger).Error("回答の検索に失敗", zap.Error(err))
+		h.respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    result,
+	})
+}
+
+func (h *SearchHandler) respondError(c *gin.Context, err error) {
+	switch err.Error() {
+	case "search query required":
+		c.JSON(http.StatusBadRequest, dto.APIResponse{Success: false, Error: "検索語を指定してください"})
+	case "invalid score range":
+		c.JSON(http.StatusBadRequest, dto.APIResponse{Success: false, Error: "点数の範囲が正しくありません"})
+	case "invalid date range":
+		c.JSON(http.StatusBadRequest, dto.APIResponse{Success: false, Error: "期間の指定が正しくありません"})
+	case "invalid limit":
+		c.JSON(http.StatusBadRequest, dto.APIResponse{Success: false, Error: "limitが無効です"})
+	case "invalid cursor":
+		c.JSON(http.StatusBadRequest, dto.APIResponse{Success: false, Error: "cursorが無効です"})
+	case "test not found":
+		c.JSON(http.StatusNotFound, dto.APIResponse{Success: false, Error: "指定されたテストが見つかりません"})
+	case "class not found":
+		c.JSON(http.StatusNotFound, dto.APIResponse{Success: false, Error: "クラスが見つかりません"})
+	default:
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{Success: false, Error: "回答の検索に失敗しました"})
+	}
+}
@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"essay-test-backend/internal/application/dto"
+	"essay-test-backend/internal/application/usecases"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type SimilarityHandler struct {
+	usecase *usecases.SimilarityUsecase
+	logger  *zap.Logger
+}
+
+func NewSimilarityHandler(usecase *usecases.SimilarityUsecase, logger *zap.Logger) *SimilarityHandler {
+	return &SimilarityHandler{
+		usecase: usecase,
+		logger:  logger,
+	}
+}
+
+func (h *SimilarityHandler) GetTestSimilarities(c *gin.Context) {
+	testID := c.Param("id")
+
+	minSimilarity := 0.0
+	if v := c.Query("min_similarity"); v != "" {
+		parsed, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.APIResponse{
+				Success: false,
+				Error:   "min_similarityが無効です",
+			})
+			return
+		}
+		minSimilarity = parsed
+	}
+
+	matches, err := h.usecase.GetMatchesByTest(c.Request.Context(), testID, minSimilarity)
+	if err != nil {
+		requestLogger(c, h.logger).Error("類似回答の取得に失敗", zap.Error(err), zap.String("test_id", testID))
+
+		if err.Error() == "test not found" {
+			c.JSON(http.StatusNotFound, dto.APIResponse{
+				Success: false,
+				Error:   "指定されたテストが見つかりません",
+			})
+		} else {
+			c.JSON(http.StatusInternalServerError, dto.APIResponse{
+				Success: false,
+				Error:   "類似回答の取得に失敗しました",
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    matches,
+	})
+}
+
+func (h *SimilarityHandler) GetSubmissionSimilarities(c *gin.Context) {
+	submissionID := c.Param("id")
+
+	matches, err := h.usecase.GetMatchesBySubmission(c.Request.Context(), submissionID)
+	if err != nil {
+		requestLogger(c, h.logger).Error("類似回答の取得に失敗", zap.Error(err), zap.String("submission_id", submissionID))
+
+		if err.Error() == "submission not found" {
+			c.JSON(http.StatusNotFound, dto.APIResponse{
+				Success: false,
+				Error:   "提出データが見つかりません",
+			})
+		} else {
+			c.JSON(http.StatusInternalServerError, dto.APIResponse{
+				Success: false,
+				Error:   "類似回答の取得に失敗しました",
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    matches,
+	})
+}
+
+func (h *SimilarityHandler) ReindexTest(c *gin.Context) {
+	testID := c.Param("id")
+
+	result, err := h.usecase.Reindex(c.Request.Context(), testID)
+	if err != nil {
+		requestLogger(c, h.logger).Error("類似度インデックスの再構築に失敗", zap.Error(err), zap.String("test_id", testID))
+
+		if err.Error() == "test not found" {
+			c.JSON(http.StatusNotFound, dto.APIResponse{
+				Success: false,
+				Error:   "指定されたテストが見つかりません",
+			})
+		} else {
+			c.JSON(http.StatusInternalServerError, dto.APIResponse{
+				Success: false,
+				Error:   "類似度インデックスの再構築に失敗しました",
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    result,
+		Message: "類似度インデックスを再構築しました",
+	})
+}
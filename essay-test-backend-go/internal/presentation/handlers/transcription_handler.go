@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"io"
+	"net/http"
+
+	"essay-test-backend/internal/application/dto"
+	"essay-test-backend/internal/application/usecases"
+	"essay-test-backend/internal/presentation/middleware"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type TranscriptionHandler struct {
+	usecase *usecases.TranscriptionUsecase
+	logger  *zap.Logger
+}
+
+func NewTranscriptionHandler(usecase *usecases.TranscriptionUsecase, logger *zap.Logger) *TranscriptionHandler {
+	return &TranscriptionHandler{
+		usecase: usecase,
+		logger:  logger,
+	}
+}
+
+func (h *TranscriptionHandler) Upload(c *gin.Context) {
+	testID := c.Param("id")
+	questionID := c.Param("questionId")
+
+	header, err := c.FormFile("image")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Error:   "画像（image）を指定してください",
+		})
+		return
+	}
+	image, err := header.Open()
+	if err != nil {
+		requestLogger(c, h.logger).Error("アップロードファイルを開けません", zap.Error(err))
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Error:   "画像を読み取れません",
+		})
+		return
+	}
+	defer image.Close()
+
+	transcription, err := h.usecase.Upload(c.Request.Context(), middleware.UserID(c), testID, questionID, image)
+	if err != nil {
+		requestLogger(c, h.logger).Error("手書き答案のアップロードに失敗", zap.Error(err), zap.String("test_id", testID))
+		h.respondError(c, err, "手書き答案のアップロードに失敗しました")
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.APIResponse{
+		Success: true,
+		Data:    transcription,
+		Message: "読み取り結果を確認・修正してから提出してください",
+	})
+}
+
+func (h *TranscriptionHandler) GetTranscription(c *gin.Context) {
+	transcriptionID := c.Param("id")
+
+	transcription, err := h.usecase.GetTranscription(c.Request.Context(), transcriptionID, middleware.UserID(c), isTeacher(c))
+	if err != nil {
+		requestLogger(c, h.logger).Error("読み取り結果の取得に失敗", zap.Error(err), zap.String("transcription_id", transcriptionID))
+		h.respondError(c, err, "読み取り結果の取得に失敗しました")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    transcription,
+	})
+}
+
+func (h *TranscriptionHandler) GetImage(c *gin.Context) {
+	transcriptionID := c.Param("id")
+
+	image, contentType, err := h.usecase.OpenImage(c.Request.Context(), transcriptionID, middleware.UserID(c), isTeacher(c))
+	if err != nil {
+		requestLogger(c, h.logger).Error("画像の取得に失敗", zap.Error(err), zap.String("transcription_id", transcriptionID))
+		h.respondError(c, err, "画像の取得に失敗しました")
+		return
+	}
+	defer image.Close()
+
+	c.Header("Content-Type", contentType)
+	c.Header("Cache-Control", "private, max-age=3600")
+	c.Status(http.StatusOK)
+	if _, err := io.Copy(c.Writer, image); err != nil {
+		requestLogger(c, h.logger).Error("画像の送信に失敗", zap.Error(err), zap.String("transcription_id", transcriptionID))
+	}
+}
+
+func (h *TranscriptionHandler) Confirm(c *gin.Context) {
+	transcriptionID := c.Param("id")
+
+	var req dto.TranscriptionConfirmRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		requestLogger(c, h.logger).Error("リクエストの解析に失敗", zap.Error(err))
+		c.JSON(http.StatusBadRequest, dto.APIResponse{
+			Success: false,
+			Error:   "リクエストが無効です",
+		})
+		return
+	}
+
+	transcription, err := h.usecase.Confirm(c.Request.Context(), transcriptionID, middleware.UserID(c), req)
+	if err != nil {
+		requestLogger(c, h.logger).Error("読み取り結果の確認に失敗", zap.Error(err), zap.String("transcription_id", transcriptionID))
+		h.respondError(c, err, "読み取り結果の確認に失敗しました")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.APIResponse{
+		Success: true,
+		Data:    transcription,
+		Message: "読み取り結果を確定しました",
+	})
+}
+
+func (h *TranscriptionHandler) respondError(c *gin.Context, err error, message string) {
+	switch err.Error() {
+	case "test not found":
+		c.JSON(http.StatusNotFound, dto.APIResponse{Success: false, Error: "指定されたテストが見つかりません"})
+	case "question not found":
+		c.JSON(http.StatusNotFound, dto.APIResponse{Success: false, Error: "指定された問題が見つかりません"})
+	case "transcription not found":
+		c.JSON(http.StatusNotFound, dto.APIResponse{Success: false, Error: "読み取り結果が見つかりません"})
+	case "image too large":
+		c.JSON(http.StatusRequestEntityTooLarge, dto.APIResponse{Success: false, Error: "画像のサイズが大きすぎます"})
+	case "invalid image":
+		c.JSON(http.StatusUnsupportedMediaType, dto.APIResponse{Success: false, Error: "JPEG、PNG、WebP形式の画像を指定してください"})
+	case "empty transcription":
+		c.JSON(http.StatusBadRequest, dto.APIResponse{Success: false, Error: "回答の本文を入力してください"})
+	case "answer too long":
+		c.JSON(http.StatusBadRequest, dto.APIResponse{Success: false, Error: "回答が長すぎます"})
+	case "transcription already submitted":
+		c.JSON(http.StatusConflict, dto.APIResponse{Success: false, Error: "提出済みの回答は変更できません"})
+	default:
+		c.JSON(http.StatusInternalServerError, dto.APIResponse{Success: false, Error: message})
+	}
+}
@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+
+	"essay-test-backend/pkg/logger"
+	"essay-test-backend/pkg/tracing"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// AccessLog puts a logger carrying the request ID, user ID, route and trace IDs into the request context
+// and writes one access log line per request. It must run after RequestID, tracing and Identity.
+func AccessLog(base *zap.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		fields := []zap.Field{
+			zap.String("request_id", RequestIDOf(c)),
+			zap.String("method", c.Request.Method),
+			zap.String("route", route),
+		}
+		if userID := UserID(c); userID != "" {
+			fields = append(fields, zap.String("user_id", userID))
+		}
+		fields = append(fields, tracing.Fields(c.Request.Context())...)
+		log := base.With(fields...)
+		c.Request = c.Request.WithContext(logger.WithContext(c.Request.Context(), log))
+
+		c.Next()
+
+		// クエリ文字列やボディには検索語や回答が含まれ得るため、パスのみ記録する
+		status := c.Writer.Status()
+		entry := []zap.Field{
+			zap.String("path", c.Request.URL.Path),
+			zap.Int("status", status),
+			zap.Duration("latency", time.Since(start)),
+			zap.Int("bytes", c.Writer.Size()),
+			zap.String("client_ip", c.ClientIP()),
+		}
+		if len(c.Errors) > 0 {
+			entry = append(entry, zap.String("errors", c.Errors.String()))
+		}
+		switch {
+		case status >= http.StatusInternalServerError:
+			log.Error("リクエスト処理完了", entry...)
+		case status >= http.StatusBadRequest:
+			log.Warn("リクエスト処理完了", entry...)
+		default:
+			log.Info("リクエスト処理完了", entry...)
+		}
+	}
+}
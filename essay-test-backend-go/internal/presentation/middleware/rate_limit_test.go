@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"essay-test-backend/pkg/ratelimit"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestRateLimitKeepsPoliciesPerCaller(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(Identity("secret"))
+	r.POST("/submit",
+		RateLimit(ratelimit.NewMemoryStore(), "submit",
+			ratelimit.Policy{PerMinute: 1, Burst: 2},
+			ratelimit.Policy{PerMinute: 1, Burst: 1}),
+		func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	send := func(userID string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodPost, "/submit", nil)
+		req.RemoteAddr = "192.0.2.1:1234"
+		if userID != "" {
+			req.Header.Set(HeaderGatewaySecret, "secret")
+			req.Header.Set(HeaderUserID, userID)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	if w := send(""); w.Code != http.StatusOK {
+		t.Fatalf("first anonymous request: status %d", w.Code)
+	}
+	w := send("")
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("anonymous request over the burst: status %d, want 429", w.Code)
+	}
+	if got := w.Header().Get("Retry-After"); got != "60" {
+		t.Fatalf("Retry-After = %q, want 60", got)
+	}
+
+	// 同じIPからでも認証済みの利用者は利用者ごとのポリシーで制限する
+	for i := 0; i < 2; i++ {
+		if w := send("student001"); w.Code != http.StatusOK {
+			t.Fatalf("authenticated request %d: status %d", i+1, w.Code)
+		}
+	}
+	if w := send("student001"); w.Code != http.StatusTooManyRequests {
+		t.Fatalf("authenticated request over the burst: status %d, want 429", w.Code)
+	}
+	if w := send("student002"); w.Code != http.StatusOK {
+		t.Fatalf("another user: status %d", w.Code)
+	}
+}
@@ -0,0 +1,195 @@
+package routes
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"essay-test-backend/internal/application/dto"
+	"essay-test-backend/internal/presentation/handlers"
+	"essay-test-backend/internal/presentation/middleware"
+	"essay-test-backend/pkg/openapi"
+
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	members  = []string{middleware.RoleStudent, middleware.RoleTeacher, middleware.RoleAdmin}
+	teachers = []string{middleware.RoleTeacher, middleware.RoleAdmin}
+	admins   = []string{middleware.RoleAdmin}
+
+	idempotencyKey = openapi.Parameter{
+		Name:        handlers.HeaderIdempotencyKey,
+		In:          "header",
+		Description: "同じキーでの再送には最初の応答をそのまま返す",
+		Schema:      &openapi.Schema{Type: "string"},
+	}
+)
+
+// OpenAPISpec describes the routes registered on r; the request and response types come from apiRoutes,
+// and a route missing there is still listed, with only its handler name
+func OpenAPISpec(r *gin.Engine) *openapi.Document {
+	b := openapi.NewBuilder(openapi.Info{
+		Title:       "Essay Test API",
+		Version:     "1.0.0",
+		Description: "小論文テストの出題・提出・自動採点と、教員・管理者向けの採点管理API",
+	}, dto.APIResponse{})
+
+	described := describedRoutes()
+	for _, route := range r.Routes() {
+		op, ok := described[route.Method+" "+route.Path]
+		if !ok {
+			op = openapi.Route{Method: route.Method, Path: route.Path, Summary: route.Handler}
+		}
+		b.Add(op)
+	}
+	return b.Document()
+}
+
+// VerifyOpenAPI reports the routes registered on r that apiRoutes does not describe, and the descriptions of
+// routes that are no longer registered
+func VerifyOpenAPI(r *gin.Engine, spec *openapi.Document) error {
+	described := describedRoutes()
+	registered := make(map[string]bool)
+	var missing []string
+	for _, route := range r.Routes() {
+		key := route.Method + " " + route.Path
+		registered[key] = true
+		if _, ok := described[key]; !ok || !spec.Has(route.Method, route.Path) {
+			missing = append(missing, key)
+		}
+	}
+
+	var stale []string
+	for key := range described {
+		if !registered[key] {
+			stale = append(stale, key)
+		}
+	}
+
+	if len(missing) == 0 && len(stale) == 0 {
+		return nil
+	}
+	sort.Strings(missing)
+	sort.Strings(stale)
+	return fmt.Errorf("openapi spec out of date: undocumented routes [%s], documented but not registered [%s]",
+		strings.Join(missing, ", "), strings.Join(stale, ", "))
+}
+
+func describedRoutes() map[string]openapi.Route {
+	described := make(map[string]openapi.Route)
+	for _, route := range apiRoutes() {
+		described[route.Method+" "+route.Path] = route
+	}
+	return described
+}
+
+// apiRoutes describes the routes of SetupRoutes with their request and response types; keep it in the same order
+func apiRoutes() []openapi.Route {
+	return []openapi.Route{
+		// ヘルスチェック
+		{Method: http.MethodGet, Path: "/health", Tag: "health", Summary: "ヘルスチェック", Response: map[string]string{}},
+		{Method: http.MethodGet, Path: "/livez", Tag: "health", Summary: "プロセスの生存確認", Response: map[string]string{}},
+		{Method: http.MethodGet, Path: "/readyz", Tag: "health", Summary: "依存サービスを含めた受付可否（受付不可なら503）", Response: dto.ReadinessResponse{}},
+		{Method: http.MethodGet, Path: "/metrics", Tag: "health", Summary: "Prometheusのメトリクス", Produces: []string{"text/plain"}},
+
+		// APIの仕様
+		{Method: http.MethodGet, Path: "/api/v1/openapi.json", Tag: "docs", Summary: "OpenAPI仕様", Produces: []string{"application/json"}},
+		{Method: http.MethodGet, Path: "/api/v1/docs/*filepath", Tag: "docs", Summary: "API仕様のビューア", Produces: []string{"text/html"}},
+
+		// テスト
+		{Method: http.MethodGet, Path: "/api/v1/tests", Tag: "tests", Summary: "テスト一覧（絞り込み・検索・並び替え・ページング）", Query: dto.TestListRequest{}, Response: dto.TestPageResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/tests/:id", Tag: "tests", Summary: "特定のテスト取得", Response: dto.EssayTestResponse{}},
+		{Method: http.MethodPost, Path: "/api/v1/tests/:id/submit", Tag: "tests", Summary: "小論文提出", Headers: []openapi.Parameter{idempotencyKey}, Body: dto.SubmissionRequest{}, Response: dto.SubmissionResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/tests/:id/exemplars", Tag: "tests", Summary: "模範解答（提出後のみ）", Response: []dto.ModelAnswerResponse{}},
+
+		// 結果
+		{Method: http.MethodGet, Path: "/api/v1/results/:id", Tag: "results", Summary: "結果取得", Response: dto.ScoringResultResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/results/:id/report.pdf", Tag: "results", Summary: "採点レポート（PDF）", Produces: []string{"application/pdf"}},
+
+		// ログイン済みの利用者
+		{Method: http.MethodPost, Path: "/api/v1/classes/join", Tag: "classes", Summary: "招待コードでクラスに参加", Roles: members, Body: dto.JoinClassRequest{}, Response: dto.ClassResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/classes", Tag: "classes", Summary: "所属クラス一覧", Roles: members, Response: []dto.ClassResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/assignments", Tag: "classes", Summary: "自分の課題と提出状況", Roles: members, Response: []dto.StudentAssignmentResponse{}},
+		{Method: http.MethodPost, Path: "/api/v1/tests/:id/questions/:questionId/transcriptions", Tag: "transcriptions", Summary: "写真のアップロードと読み取り", Roles: members, Files: []string{"image"}, Status: http.StatusCreated, Response: dto.TranscriptionResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/transcriptions/:id", Tag: "transcriptions", Summary: "読み取り結果", Roles: members, Response: dto.TranscriptionResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/transcriptions/:id/image", Tag: "transcriptions", Summary: "アップロードした写真", Roles: members, Produces: []string{"image/jpeg", "image/png"}},
+		{Method: http.MethodPost, Path: "/api/v1/transcriptions/:id/confirm", Tag: "transcriptions", Summary: "読み取り結果の確認・修正", Roles: members, Body: dto.TranscriptionConfirmRequest{}, Response: dto.TranscriptionResponse{}},
+
+		// 教員
+		{Method: http.MethodGet, Path: "/api/v1/teacher/tests/:id/similarities", Tag: "similarities", Summary: "テスト内の類似回答一覧", Roles: teachers, QueryParams: []openapi.Parameter{
+			{Name: "min_similarity", In: "query", Description: "この値以上の類似度のみ返す（0〜1）", Schema: &openapi.Schema{Type: "number"}},
+		}, Response: []dto.SimilarityMatchResponse{}},
+		{Method: http.MethodPost, Path: "/api/v1/teacher/tests/:id/similarities/reindex", Tag: "similarities", Summary: "類似度インデックス再構築", Roles: teachers, Response: dto.ReindexResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/teacher/submissions/:id/similarities", Tag: "similarities", Summary: "提出物の類似回答", Roles: teachers, Response: []dto.SimilarityMatchResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/teacher/submissions/:id/review", Tag: "review", Summary: "採点レビュー画面", Roles: teachers, Response: dto.ReviewResponse{}},
+		{Method: http.MethodPut, Path: "/api/v1/teacher/results/:id/review", Tag: "review", Summary: "採点修正の下書き保存", Roles: teachers, Body: dto.ReviewRequest{}, Response: dto.ScoreReviewResponse{}},
+		{Method: http.MethodPost, Path: "/api/v1/teacher/results/:id/review/publish", Tag: "review", Summary: "採点修正の公開", Roles: teachers, Response: dto.ScoringResultResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/teacher/results/:id/audit", Tag: "review", Summary: "採点修正の監査ログ", Roles: teachers, Response: []dto.AuditLogResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/teacher/ratings", Tag: "ratings", Summary: "自分の採点割り当て一覧", Roles: teachers, Response: []dto.RaterAssignmentResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/teacher/ratings/:id", Tag: "ratings", Summary: "採点課題（他の採点者の点数は非表示）", Roles: teachers, Response: dto.RatingTaskResponse{}},
+		{Method: http.MethodPost, Path: "/api/v1/teacher/ratings/:id/submit", Tag: "ratings", Summary: "採点の提出", Roles: teachers, Body: dto.RaterScoresRequest{}, Response: dto.RaterAssignmentResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/teacher/submissions/:id/results", Tag: "rescore", Summary: "採点結果の版履歴", Roles: teachers, Response: []dto.ScoringResultResponse{}},
+		{Method: http.MethodPost, Path: "/api/v1/teacher/tests/:id/exemplars", Tag: "exemplars", Summary: "模範解答の登録", Roles: teachers, Body: dto.ModelAnswerRequest{}, Status: http.StatusCreated, Response: dto.ModelAnswerResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/teacher/tests/:id/exemplars", Tag: "exemplars", Summary: "模範解答一覧", Roles: teachers, Response: []dto.ModelAnswerResponse{}},
+		{Method: http.MethodPut, Path: "/api/v1/teacher/exemplars/:id", Tag: "exemplars", Summary: "模範解答の更新", Roles: teachers, Body: dto.ModelAnswerRequest{}, Response: dto.ModelAnswerResponse{}},
+		{Method: http.MethodDelete, Path: "/api/v1/teacher/exemplars/:id", Tag: "exemplars", Summary: "模範解答の削除", Roles: teachers},
+		{Method: http.MethodGet, Path: "/api/v1/teacher/submissions/:id/annotations", Tag: "annotations", Summary: "回答と添削一覧", Roles: teachers, Response: []dto.AnswerResponse{}},
+		{Method: http.MethodPost, Path: "/api/v1/teacher/submissions/:id/annotations", Tag: "annotations", Summary: "添削の追加", Roles: teachers, Body: dto.AnnotationRequest{}, Status: http.StatusCreated, Response: dto.AnnotationResponse{}},
+		{Method: http.MethodPut, Path: "/api/v1/teacher/annotations/:id", Tag: "annotations", Summary: "添削の更新", Roles: teachers, Body: dto.AnnotationRequest{}, Response: dto.AnnotationResponse{}},
+		{Method: http.MethodDelete, Path: "/api/v1/teacher/annotations/:id", Tag: "annotations", Summary: "添削の削除", Roles: teachers},
+		{Method: http.MethodGet, Path: "/api/v1/teacher/organizations", Tag: "classes", Summary: "組織一覧", Roles: teachers, Response: []dto.OrganizationResponse{}},
+		{Method: http.MethodPost, Path: "/api/v1/teacher/classes", Tag: "classes", Summary: "クラスの作成", Roles: teachers, Body: dto.ClassRequest{}, Status: http.StatusCreated, Response: dto.ClassResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/teacher/classes", Tag: "classes", Summary: "担当クラス一覧", Roles: teachers, Response: []dto.ClassResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/teacher/classes/:id", Tag: "classes", Summary: "クラスとメンバー", Roles: teachers, Response: dto.ClassResponse{}},
+		{Method: http.MethodPost, Path: "/api/v1/teacher/classes/:id/invite-code", Tag: "classes", Summary: "招待コードの再発行", Roles: teachers, Response: dto.ClassResponse{}},
+		{Method: http.MethodPost, Path: "/api/v1/teacher/classes/:id/teachers", Tag: "classes", Summary: "担当教員の追加", Roles: teachers, Body: dto.ClassTeacherRequest{}, Status: http.StatusCreated, Response: dto.ClassMemberResponse{}},
+		{Method: http.MethodPost, Path: "/api/v1/teacher/classes/:id/assignments", Tag: "classes", Summary: "テストを課題として配布", Roles: teachers, Body: dto.AssignmentRequest{}, Status: http.StatusCreated, Response: dto.AssignmentResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/teacher/classes/:id/assignments", Tag: "classes", Summary: "クラスの課題一覧", Roles: teachers, Response: []dto.AssignmentResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/teacher/classes/:id/scores", Tag: "classes", Summary: "生徒ごとの成績一覧", Roles: teachers, Response: dto.ClassScoresResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/teacher/assignments/:id/progress", Tag: "classes", Summary: "課題の提出状況と点数", Roles: teachers, Response: dto.AssignmentProgressResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/teacher/exports/results", Tag: "exports", Summary: "CSV・Excel形式の成績一覧", Roles: teachers, Query: dto.ResultExportRequest{}, Produces: []string{
+			"text/csv",
+			"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+		}},
+		{Method: http.MethodGet, Path: "/api/v1/teacher/search/essays", Tag: "search", Summary: "語を含む回答・講評とスニペット", Roles: teachers, Query: dto.EssaySearchRequest{}, Response: dto.EssaySearchResponse{}},
+		{Method: http.MethodPost, Path: "/api/v1/teacher/ingest/submissions", Tag: "ingest", Summary: "CSV・JSONLからの提出物登録", Roles: teachers, Files: []string{"file"}, QueryParams: []openapi.Parameter{
+			{Name: "format", In: "query", Description: "csv, jsonl（省略時はファイル名から判定）", Schema: &openapi.Schema{Type: "string"}},
+		}, Response: dto.IngestReportResponse{}},
+
+		// 管理者
+		{Method: http.MethodPost, Path: "/api/v1/admin/organizations", Tag: "classes", Summary: "組織（学校・塾）の作成", Roles: admins, Body: dto.OrganizationRequest{}, Status: http.StatusCreated, Response: dto.OrganizationResponse{}},
+		{Method: http.MethodPost, Path: "/api/v1/admin/submissions/:id/raters", Tag: "ratings", Summary: "複数採点者の割り当て", Roles: admins, Body: dto.AssignRatersRequest{}, Status: http.StatusCreated, Response: dto.RatingSessionResponse{}},
+		{Method: http.MethodPost, Path: "/api/v1/admin/submissions/:id/adjudicator", Tag: "ratings", Summary: "裁定者の割り当て", Roles: admins, Body: dto.AssignAdjudicatorRequest{}, Status: http.StatusCreated, Response: dto.RatingSessionResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/admin/submissions/:id/rating", Tag: "ratings", Summary: "採点セッションの状況", Roles: admins, Response: dto.RatingSessionResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/admin/tests/:id/agreement", Tag: "ratings", Summary: "採点者間一致度", Roles: admins, Response: dto.AgreementResponse{}},
+		{Method: http.MethodPost, Path: "/api/v1/admin/tests/:id/anchors", Tag: "calibration", Summary: "アンカー答案の登録", Roles: admins, Body: dto.AnchorEssayRequest{}, Status: http.StatusCreated, Response: dto.AnchorEssayResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/admin/tests/:id/anchors", Tag: "calibration", Summary: "アンカー答案一覧", Roles: admins, Response: []dto.AnchorEssayResponse{}},
+		{Method: http.MethodDelete, Path: "/api/v1/admin/tests/:id/anchors/:anchorId", Tag: "calibration", Summary: "アンカー答案の削除", Roles: admins},
+		{Method: http.MethodPost, Path: "/api/v1/admin/tests/:id/calibration/runs", Tag: "calibration", Summary: "自動採点とアンカーの比較", Roles: admins, Status: http.StatusCreated, Response: dto.CalibrationRunResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/admin/tests/:id/calibration/runs", Tag: "calibration", Summary: "比較結果一覧", Roles: admins, Response: []dto.CalibrationRunResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/admin/tests/:id/calibration", Tag: "calibration", Summary: "適用中の較正", Roles: admins, Response: dto.TestCalibrationResponse{}},
+		{Method: http.MethodPut, Path: "/api/v1/admin/tests/:id/calibration", Tag: "calibration", Summary: "較正の適用", Roles: admins, Body: dto.ApplyCalibrationRequest{}, Response: dto.TestCalibrationResponse{}},
+		{Method: http.MethodDelete, Path: "/api/v1/admin/tests/:id/calibration", Tag: "calibration", Summary: "較正の解除", Roles: admins},
+		{Method: http.MethodGet, Path: "/api/v1/admin/tests/:id/analytics", Tag: "analytics", Summary: "得点分布・基準ごとの難易度", Roles: admins, QueryParams: []openapi.Parameter{binsParam}, Response: dto.TestAnalyticsResponse{}},
+		{Method: http.MethodPost, Path: "/api/v1/admin/tests/:id/analytics/rebuild", Tag: "analytics", Summary: "現在の結果からの再集計", Roles: admins, QueryParams: []openapi.Parameter{binsParam}, Response: dto.TestAnalyticsResponse{}},
+		{Method: http.MethodPost, Path: "/api/v1/admin/rescore-jobs", Tag: "rescore", Summary: "再採点ジョブの開始", Roles: admins, Body: dto.RescoreJobRequest{}, Status: http.StatusAccepted, Response: dto.RescoreJobResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/admin/rescore-jobs", Tag: "rescore", Summary: "再採点ジョブ一覧", Roles: admins, Response: []dto.RescoreJobResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/admin/rescore-jobs/:id", Tag: "rescore", Summary: "再採点ジョブの進捗", Roles: admins, Response: dto.RescoreJobResponse{}},
+		{Method: http.MethodGet, Path: "/api/v1/admin/rescore-jobs/:id/diff", Tag: "rescore", Summary: "点数変化のレポート", Roles: admins, Response: dto.RescoreReportResponse{}},
+
+		// 既存のAPIとの互換性のためのルート
+		{Method: http.MethodGet, Path: "/api/essay-test", Tag: "legacy", Summary: "すべてのテスト取得（旧API）", Response: []dto.EssayTestResponse{}},
+		{Method: http.MethodGet, Path: "/api/essay-test/:id", Tag: "legacy", Summary: "特定のテスト取得（旧API）", Response: dto.EssayTestResponse{}},
+		{Method: http.MethodPost, Path: "/api/essay-test/submit", Tag: "legacy", Summary: "小論文提出（旧API）", Headers: []openapi.Parameter{idempotencyKey}, Body: dto.SubmissionRequest{}, Response: dto.SubmissionResponse{}},
+		{Method: http.MethodGet, Path: "/api/results/:id", Tag: "legacy", Summary: "結果取得（旧API）", Response: dto.ScoringResultResponse{}},
+	}
+}
+
+var binsParam = openapi.Parameter{
+	Name:        "bins",
+	In:          "query",
+	Description: "得点分布の階級数",
+	Schema:      &openapi.Schema{Type: "integer"},
+}
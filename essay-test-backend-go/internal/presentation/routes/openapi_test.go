@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"testing"
+
+	"essay-test-backend/internal/presentation/handlers"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestOpenAPIDescribesEveryRoute(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	SetupRoutes(r, Handlers{Docs: handlers.NewDocsHandler()}, RateLimits{})
+
+	if err := VerifyOpenAPI(r, OpenAPISpec(r)); err != nil {
+		t.Fatal(err)
+	}
+}
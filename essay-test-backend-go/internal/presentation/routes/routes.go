@@ -2,29 +2,162 @@ package routes
 
 import (
 	"essay-test-backend/internal/presentation/handlers"
+	"essay-test-backend/internal/presentation/middleware"
+	"essay-test-backend/pkg/metrics"
 
 	"github.com/gin-gonic/gin"
 )
 
-func SetupRoutes(r *gin.Engine, testHandler *handlers.EssayTestHandler) {
+// Handlers groups the HTTP handlers registered by SetupRoutes
+type Handlers struct {
+	EssayTest  *handlers.EssayTestHandler
+	Similarity *handlers.SimilarityHandler
+	Review     *handlers.ReviewHandler
+	Rating      *handlers.RatingHandler
+	Calibration *handlers.CalibrationHandler
+	Rescore     *handlers.RescoreHandler
+	Exemplar    *handlers.ExemplarHandler
+	Annotation  *handlers.AnnotationHandler
+	Class       *handlers.ClassHandler
+	Analytics   *handlers.AnalyticsHandler
+	Export      *handlers.ExportHandler
+	Search      *handlers.SearchHandler
+	Report      *handlers.ReportHandler
+	Ingest      *handlers.IngestHandler
+	Transcription *handlers.TranscriptionHandler
+	Health        *handlers.HealthHandler
+	Idempotency   *handlers.IdempotencyHandler
+	Docs          *handlers.DocsHandler
+}
+
+// RateLimits are the rate-limiting middlewares of the routes that trigger scoring
+type RateLimits struct {
+	Submit  gin.HandlerFunc // 小論文の提出
+	Upload  gin.HandlerFunc // 手書き答案の読み取り
+	Scoring gin.HandlerFunc // 教員・管理者が起動する採点処理
+}
+
+func SetupRoutes(r *gin.Engine, h Handlers, limits RateLimits) {
+	testHandler := h.EssayTest
+
 	// ヘルスチェック
 	r.GET("/health", testHandler.HealthCheck)
+	r.GET("/livez", h.Health.Livez)   // プロセスの生存確認
+	r.GET("/readyz", h.Health.Readyz) // 依存サービスを含めた受付可否
+
+	// Prometheusのメトリクス
+	r.GET("/metrics", gin.WrapH(metrics.Handler()))
 
 	// API v1 グループ
 	v1 := r.Group("/api/v1")
 	{
+		// APIの仕様（ルートを追加したらopenapi.goのapiRoutesにも記載する）
+		v1.GET("/openapi.json", h.Docs.OpenAPI) // OpenAPI仕様
+		v1.GET("/docs/*filepath", h.Docs.Viewer) // API仕様のビューア
+
 		// テスト関連のルート
 		tests := v1.Group("/tests")
 		{
-			tests.GET("", testHandler.GetAllTests)           // すべてのテスト取得
+			tests.GET("", testHandler.GetAllTests)           // テスト一覧（絞り込み・検索・並び替え・ページング）
 			tests.GET("/:id", testHandler.GetTestByID)       // 特定のテスト取得
-			tests.POST("/:id/submit", testHandler.SubmitEssay) // 小論文提出
+			tests.POST("/:id/submit", limits.Submit, h.Idempotency.Guard, testHandler.SubmitEssay) // 小論文提出
+			tests.GET("/:id/exemplars", h.Exemplar.ListReleasedExemplars) // 模範解答（提出後のみ）
 		}
 
 		// 結果関連のルート
 		results := v1.Group("/results")
 		{
 			results.GET("/:id", testHandler.GetResult) // 結果取得
+			results.GET("/:id/report.pdf", h.Report.DownloadResultReport) // 採点レポート（PDF）
+		}
+
+		// ログイン済みの利用者向けのルート
+		member := v1.Group("", middleware.RequireRole(middleware.RoleStudent, middleware.RoleTeacher, middleware.RoleAdmin))
+		{
+			member.POST("/classes/join", h.Class.JoinClass)      // 招待コードでクラスに参加
+			member.GET("/classes", h.Class.ListMyClasses)         // 所属クラス一覧
+			member.GET("/assignments", h.Class.ListMyAssignments) // 自分の課題と提出状況
+
+			// 手書き答案の写真からの入力
+			member.POST("/tests/:id/questions/:questionId/transcriptions", limits.Upload, h.Transcription.Upload) // 写真のアップロードと読み取り
+			member.GET("/transcriptions/:id", h.Transcription.GetTranscription)                    // 読み取り結果
+			member.GET("/transcriptions/:id/image", h.Transcription.GetImage)                      // アップロードした写真
+			member.POST("/transcriptions/:id/confirm", h.Transcription.Confirm)                    // 読み取り結果の確認・修正
+		}
+
+		// 教員向けのルート
+		teacher := v1.Group("/teacher", middleware.RequireRole(middleware.RoleTeacher, middleware.RoleAdmin))
+		{
+			teacher.GET("/tests/:id/similarities", h.Similarity.GetTestSimilarities)         // テスト内の類似回答一覧
+			teacher.POST("/tests/:id/similarities/reindex", limits.Scoring, h.Similarity.ReindexTest)       // 類似度インデックス再構築
+			teacher.GET("/submissions/:id/similarities", h.Similarity.GetSubmissionSimilarities) // 提出物の類似回答
+			teacher.GET("/submissions/:id/review", h.Review.GetReview)                            // 採点レビュー画面
+			teacher.PUT("/results/:id/review", h.Review.SaveDraft)                                // 採点修正の下書き保存
+			teacher.POST("/results/:id/review/publish", h.Review.Publish)                         // 採点修正の公開
+			teacher.GET("/results/:id/audit", h.Review.GetAuditLogs)                              // 採点修正の監査ログ
+			teacher.GET("/ratings", h.Rating.ListAssignments)                                     // 自分の採点割り当て一覧
+			teacher.GET("/ratings/:id", h.Rating.GetTask)                                         // 採点課題（他の採点者の点数は非表示）
+			teacher.POST("/ratings/:id/submit", h.Rating.SubmitScores)                            // 採点の提出
+			teacher.GET("/submissions/:id/results", h.Rescore.GetResultVersions)                  // 採点結果の版履歴
+			teacher.POST("/tests/:id/exemplars", h.Exemplar.CreateExemplar)                       // 模範解答の登録
+			teacher.GET("/tests/:id/exemplars", h.Exemplar.ListExemplars)                         // 模範解答一覧
+			teacher.PUT("/exemplars/:id", h.Exemplar.UpdateExemplar)                              // 模範解答の更新
+			teacher.DELETE("/exemplars/:id", h.Exemplar.DeleteExemplar)                           // 模範解答の削除
+			teacher.GET("/submissions/:id/annotations", h.Annotation.GetAnnotations)              // 回答と添削一覧
+			teacher.POST("/submissions/:id/annotations", h.Annotation.CreateAnnotation)           // 添削の追加
+			teacher.PUT("/annotations/:id", h.Annotation.UpdateAnnotation)                        // 添削の更新
+			teacher.DELETE("/annotations/:id", h.Annotation.DeleteAnnotation)                     // 添削の削除
+
+			// クラス管理
+			teacher.GET("/organizations", h.Class.ListOrganizations)                   // 組織一覧
+			teacher.POST("/classes", h.Class.CreateClass)                              // クラスの作成
+			teacher.GET("/classes", h.Class.ListTeacherClasses)                        // 担当クラス一覧
+			teacher.GET("/classes/:id", h.Class.GetClass)                              // クラスとメンバー
+			teacher.POST("/classes/:id/invite-code", h.Class.RegenerateInviteCode)     // 招待コードの再発行
+			teacher.POST("/classes/:id/teachers", h.Class.AddTeacher)                  // 担当教員の追加
+			teacher.POST("/classes/:id/assignments", h.Class.CreateAssignment)         // テストを課題として配布
+			teacher.GET("/classes/:id/assignments", h.Class.ListAssignments)           // クラスの課題一覧
+			teacher.GET("/classes/:id/scores", h.Class.GetClassScores)                 // 生徒ごとの成績一覧
+			teacher.GET("/assignments/:id/progress", h.Class.GetAssignmentProgress)    // 課題の提出状況と点数
+
+			// 成績の出力
+			teacher.GET("/exports/results", h.Export.ExportResults) // CSV・Excel形式の成績一覧
+
+			// 回答・講評の全文検索
+			teacher.GET("/search/essays", h.Search.SearchEssays) // 語を含む回答・講評とスニペット
+
+			// 紙の答案の一括登録
+			teacher.POST("/ingest/submissions", limits.Scoring, h.Ingest.IngestSubmissions) // CSV・JSONLからの提出物登録
+		}
+
+		// 管理者向けのルート
+		admin := v1.Group("/admin", middleware.RequireRole(middleware.RoleAdmin))
+		{
+			admin.POST("/organizations", h.Class.CreateOrganization)               // 組織（学校・塾）の作成
+			admin.POST("/submissions/:id/raters", h.Rating.AssignRaters)           // 複数採点者の割り当て
+			admin.POST("/submissions/:id/adjudicator", h.Rating.AssignAdjudicator) // 裁定者の割り当て
+			admin.GET("/submissions/:id/rating", h.Rating.GetSession)              // 採点セッションの状況
+			admin.GET("/tests/:id/agreement", h.Rating.GetAgreement)               // 採点者間一致度
+
+			// 採点較正
+			admin.POST("/tests/:id/anchors", h.Calibration.CreateAnchor)                // アンカー答案の登録
+			admin.GET("/tests/:id/anchors", h.Calibration.ListAnchors)                  // アンカー答案一覧
+			admin.DELETE("/tests/:id/anchors/:anchorId", h.Calibration.DeleteAnchor)    // アンカー答案の削除
+			admin.POST("/tests/:id/calibration/runs", limits.Scoring, h.Calibration.Run)                // 自動採点とアンカーの比較
+			admin.GET("/tests/:id/calibration/runs", h.Calibration.ListRuns)            // 比較結果一覧
+			admin.GET("/tests/:id/calibration", h.Calibration.GetCalibration)           // 適用中の較正
+			admin.PUT("/tests/:id/calibration", h.Calibration.ApplyCalibration)         // 較正の適用
+			admin.DELETE("/tests/:id/calibration", h.Calibration.RemoveCalibration)     // 較正の解除
+
+			// テストの分析
+			admin.GET("/tests/:id/analytics", h.Analytics.GetAnalytics)         // 得点分布・基準ごとの難易度
+			admin.POST("/tests/:id/analytics/rebuild", limits.Scoring, h.Analytics.Rebuild)     // 現在の結果からの再集計
+
+			// 一括再採点
+			admin.POST("/rescore-jobs", limits.Scoring, h.Rescore.StartJob)          // 再採点ジョブの開始
+			admin.GET("/rescore-jobs", h.Rescore.ListJobs)           // 再採点ジョブ一覧
+			admin.GET("/rescore-jobs/:id", h.Rescore.GetJob)         // 再採点ジョブの進捗
+			admin.GET("/rescore-jobs/:id/diff", h.Rescore.GetReport) // 点数変化のレポート
 		}
 	}
 
@@ -33,9 +166,9 @@ func SetupRoutes(r *gin.Engine, testHandler *handlers.EssayTestHandler) {
 	{
 		essayTest := legacy.Group("/essay-test")
 		{
-			essayTest.GET("", testHandler.GetAllTests)
+			essayTest.GET("", testHandler.GetAllTestsLegacy)
 			essayTest.GET("/:id", testHandler.GetTestByID)
-			essayTest.POST("/submit", testHandler.SubmitEssay)
+			essayTest.POST("/submit", limits.Submit, h.Idempotency.Guard, testHandler.SubmitEssay)
 		}
 		
 		legacy.GET("/results/:id", testHandler.GetResult)
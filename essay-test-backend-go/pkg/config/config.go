@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/spf13/viper"
@@ -13,12 +14,24 @@ type Config struct {
 	Server      ServerConfig      `mapstructure:"server"`
 	Database    DatabaseConfig    `mapstructure:"database"`
 	CORS        CORSConfig        `mapstructure:"cors"`
+	Auth        AuthConfig        `mapstructure:"auth"`
+	Similarity  SimilarityConfig  `mapstructure:"similarity"`
+	Report      ReportConfig      `mapstructure:"report"`
+	Ingest      IngestConfig      `mapstructure:"ingest"`
+	Upload      UploadConfig      `mapstructure:"upload"`
+	OCR         OCRConfig         `mapstructure:"ocr"`
+	Tracing     TracingConfig     `mapstructure:"tracing"`
+	Health      HealthConfig      `mapstructure:"health"`
+	Limits      LimitsConfig      `mapstructure:"limits"`
+	RateLimit   RateLimitConfig   `mapstructure:"rate_limit"`
 	LogLevel    string            `mapstructure:"log_level"`
 	Environment string            `mapstructure:"environment"`
 }
 
 type ServerConfig struct {
-	Port string `mapstructure:"port"`
+	Port            string        `mapstructure:"port"`
+	ShutdownTimeout time.Duration `mapstructure:"shutdown_timeout"` // 停止時に処理中のリクエストと採点を待つ時間
+	TrustedProxies  []string      `mapstructure:"trusted_proxies"`  // X-Forwarded-Forを信頼するプロキシのIP・CIDR。未設定なら接続元をクライアントIPとする
 }
 
 type DatabaseConfig struct {
@@ -33,6 +46,74 @@ type CORSConfig struct {
 	AllowedOrigins []string `mapstructure:"allowed_origins"`
 }
 
+// AuthConfig configures the trust in the gateway that authenticates callers
+type AuthConfig struct {
+	GatewaySecret string `mapstructure:"gateway_secret"` // ゲートウェイが付ける共有シークレット。未設定なら利用者ヘッダーを信頼しない
+}
+
+type SimilarityConfig struct {
+	ShingleSize     int     `mapstructure:"shingle_size"`
+	NumHashes       int     `mapstructure:"num_hashes"`
+	Threshold       float64 `mapstructure:"threshold"`
+	SourceThreshold float64 `mapstructure:"source_threshold"`
+}
+
+type ReportConfig struct {
+	Enabled bool `mapstructure:"enabled"` // 無効にするとフォントなしで起動し、PDFレポートは503を返す
+	// 日本語を含むTrueTypeフォント（IPAexゴシックなど）のパス
+	FontPath string `mapstructure:"font_path"`
+}
+
+type IngestConfig struct {
+	MaxRows   int `mapstructure:"max_rows"`   // 1回の一括登録で受け付ける最大行数
+	QueueSize int `mapstructure:"queue_size"` // 採点待ちキューの長さ
+	Workers   int `mapstructure:"workers"`    // バックグラウンドで採点するワーカー数
+	// 採点中の提出物を、採点したプロセスが停止したとみなして別のプロセスが引き継ぐまでの時間。最も長い採点より長くする
+	ClaimTimeout time.Duration `mapstructure:"claim_timeout"`
+}
+
+type UploadConfig struct {
+	Dir           string `mapstructure:"dir"` // アップロードされたファイルの保存先
+	MaxImageBytes int64  `mapstructure:"max_image_bytes"`
+}
+
+type OCRConfig struct {
+	Engine   string `mapstructure:"engine"`   // stub, tesseract
+	Command  string `mapstructure:"command"`  // tesseractの実行ファイル
+	Language string `mapstructure:"language"` // tesseractの言語データ
+}
+
+type TracingConfig struct {
+	Exporter    string  `mapstructure:"exporter"` // none, otlp, stdout
+	Endpoint    string  `mapstructure:"endpoint"` // OTLP/HTTPの送信先URL（例: http://localhost:4318/v1/traces）。未設定ならOTEL_EXPORTER_OTLP_ENDPOINTに従う
+	ServiceName string  `mapstructure:"service_name"`
+	SampleRatio float64 `mapstructure:"sample_ratio"` // 記録するトレースの割合（0〜1）
+}
+
+type HealthConfig struct {
+	CheckTimeout time.Duration `mapstructure:"check_timeout"` // /readyzの各依存チェックの制限時間
+}
+
+type LimitsConfig struct {
+	MaxBodyBytes   int64 `mapstructure:"max_body_bytes"`   // JSONなどのリクエストボディの上限
+	MaxUploadBytes int64 `mapstructure:"max_upload_bytes"` // multipart（写真・一括登録ファイル）のリクエストボディの上限
+	MaxAnswerChars int   `mapstructure:"max_answer_chars"` // 1問の回答の最大文字数
+}
+
+// RateLimitConfig holds the per-route policies; callers are limited per user, or per IP when not logged in
+type RateLimitConfig struct {
+	Enabled         bool            `mapstructure:"enabled"`
+	Submit          RateLimitPolicy `mapstructure:"submit"`           // 小論文の提出（採点を伴う）
+	SubmitAnonymous RateLimitPolicy `mapstructure:"submit_anonymous"` // 未ログインの提出。教室などで1つのIPを共有するため、IPごとに大きめに取る
+	Upload          RateLimitPolicy `mapstructure:"upload"`           // 手書き答案の写真の読み取り
+	Scoring         RateLimitPolicy `mapstructure:"scoring"`          // 一括登録・再採点・較正など、教員・管理者が起動する採点処理
+}
+
+type RateLimitPolicy struct {
+	PerMinute float64 `mapstructure:"per_minute"` // 1分あたりに補充されるリクエスト数
+	Burst     int     `mapstructure:"burst"`      // 連続して受け付けるリクエスト数
+}
+
 func Load() (*Config, error) {
 	// Load .env file if it exists
 	if err := godotenv.Load(); err != nil {
@@ -68,23 +149,82 @@ func Load() (*Config, error) {
 
 func setDefaults() {
 	viper.SetDefault("server.port", "5000")
+	viper.SetDefault("server.shutdown_timeout", "30s")
 	viper.SetDefault("database.host", "localhost")
 	viper.SetDefault("database.port", "3306")
 	viper.SetDefault("database.user", "essay_user")
 	viper.SetDefault("database.password", "essay_password")
 	viper.SetDefault("database.name", "essay_test_db")
 	viper.SetDefault("cors.allowed_origins", []string{"http://localhost:3000", "http://localhost:3001", "http://localhost:3002"})
+	viper.SetDefault("similarity.shingle_size", 5)
+	viper.SetDefault("similarity.num_hashes", 128)
+	viper.SetDefault("similarity.threshold", 0.6)
+	viper.SetDefault("similarity.source_threshold", 0.5)
+	viper.SetDefault("report.enabled", true)
+	viper.SetDefault("report.font_path", "/usr/share/fonts/opentype/ipaexfont-gothic/ipaexg.ttf")
+	viper.SetDefault("ingest.max_rows", 5000)
+	viper.SetDefault("ingest.queue_size", 10000)
+	viper.SetDefault("ingest.workers", 2)
+	viper.SetDefault("ingest.claim_timeout", "15m")
+	viper.SetDefault("upload.dir", "uploads")
+	viper.SetDefault("upload.max_image_bytes", 10<<20)
+	viper.SetDefault("ocr.engine", "stub")
+	viper.SetDefault("ocr.command", "tesseract")
+	viper.SetDefault("ocr.language", "jpn")
+	viper.SetDefault("tracing.exporter", "none")
+	viper.SetDefault("tracing.service_name", "essay-test-backend")
+	viper.SetDefault("tracing.sample_ratio", 1.0)
+	viper.SetDefault("health.check_timeout", "2s")
+	viper.SetDefault("limits.max_body_bytes", 1<<20)
+	viper.SetDefault("limits.max_upload_bytes", 32<<20)
+	viper.SetDefault("limits.max_answer_chars", 10000)
+	viper.SetDefault("rate_limit.enabled", true)
+	viper.SetDefault("rate_limit.submit.per_minute", 6)
+	viper.SetDefault("rate_limit.submit.burst", 3)
+	viper.SetDefault("rate_limit.submit_anonymous.per_minute", 60)
+	viper.SetDefault("rate_limit.submit_anonymous.burst", 40)
+	viper.SetDefault("rate_limit.upload.per_minute", 20)
+	viper.SetDefault("rate_limit.upload.burst", 10)
+	viper.SetDefault("rate_limit.scoring.per_minute", 10)
+	viper.SetDefault("rate_limit.scoring.burst", 5)
 	viper.SetDefault("log_level", "info")
 	viper.SetDefault("environment", "development")
 }
 
 func bindEnvVars() {
 	viper.BindEnv("server.port", "SERVER_PORT")
+	viper.BindEnv("server.shutdown_timeout", "SHUTDOWN_TIMEOUT")
 	viper.BindEnv("database.host", "DB_HOST")
 	viper.BindEnv("database.port", "DB_PORT")
 	viper.BindEnv("database.user", "DB_USER")
 	viper.BindEnv("database.password", "DB_PASSWORD")
 	viper.BindEnv("database.name", "DB_NAME")
+	viper.BindEnv("auth.gateway_secret", "GATEWAY_SECRET")
+	viper.BindEnv("similarity.threshold", "SIMILARITY_THRESHOLD")
+	viper.BindEnv("similarity.source_threshold", "SIMILARITY_SOURCE_THRESHOLD")
+	viper.BindEnv("report.enabled", "REPORT_ENABLED")
+	viper.BindEnv("report.font_path", "REPORT_FONT_PATH")
+	viper.BindEnv("ingest.max_rows", "INGEST_MAX_ROWS")
+	viper.BindEnv("ingest.workers", "SCORING_WORKERS")
+	viper.BindEnv("ingest.claim_timeout", "SCORING_CLAIM_TIMEOUT")
+	viper.BindEnv("upload.dir", "UPLOAD_DIR")
+	viper.BindEnv("upload.max_image_bytes", "UPLOAD_MAX_IMAGE_BYTES")
+	viper.BindEnv("ocr.engine", "OCR_ENGINE")
+	viper.BindEnv("ocr.command", "OCR_COMMAND")
+	viper.BindEnv("ocr.language", "OCR_LANGUAGE")
+	viper.BindEnv("tracing.exporter", "TRACING_EXPORTER")
+	viper.BindEnv("tracing.endpoint", "TRACING_ENDPOINT")
+	viper.BindEnv("tracing.service_name", "OTEL_SERVICE_NAME")
+	viper.BindEnv("tracing.sample_ratio", "TRACING_SAMPLE_RATIO")
+	viper.BindEnv("health.check_timeout", "HEALTH_CHECK_TIMEOUT")
+	viper.BindEnv("limits.max_body_bytes", "MAX_BODY_BYTES")
+	viper.BindEnv("limits.max_upload_bytes", "MAX_UPLOAD_BYTES")
+	viper.BindEnv("limits.max_answer_chars", "MAX_ANSWER_CHARS")
+	viper.BindEnv("rate_limit.enabled", "RATE_LIMIT_ENABLED")
+	viper.BindEnv("rate_limit.submit.per_minute", "RATE_LIMIT_SUBMIT_PER_MINUTE")
+	viper.BindEnv("rate_limit.submit.burst", "RATE_LIMIT_SUBMIT_BURST")
+	viper.BindEnv("rate_limit.submit_anonymous.per_minute", "RATE_LIMIT_SUBMIT_ANONYMOUS_PER_MINUTE")
+	viper.BindEnv("rate_limit.submit_anonymous.burst", "RATE_LIMIT_SUBMIT_ANONYMOUS_BURST")
 	viper.BindEnv("log_level", "LOG_LEVEL")
 	viper.BindEnv("environment", "ENVIRONMENT")
 	
@@ -96,6 +236,15 @@ func bindEnvVars() {
 		}
 		viper.Set("cors.allowed_origins", origins)
 	}
+
+	// 信頼するプロキシの処理
+	if proxies := os.Getenv("TRUSTED_PROXIES"); proxies != "" {
+		list := strings.Split(proxies, ",")
+		for i, proxy := range list {
+			list[i] = strings.TrimSpace(proxy)
+		}
+		viper.Set("server.trusted_proxies", list)
+	}
 }
 
 func (c *DatabaseConfig) GetDSN() string {
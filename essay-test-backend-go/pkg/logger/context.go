@@ -0,0 +1,27 @@
+package logger
+
+import (
+	"context"
+
+	"essay-test-backend/pkg/tracing"
+
+	"go.uber.org/zap"
+)
+
+type contextKey struct{}
+
+// WithContext returns a copy of ctx that carries l, so code further down the call chain logs with its fields
+func WithContext(ctx context.Context, l *zap.Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, l)
+}
+
+// FromContext returns the logger carried by ctx, or fallback with the trace IDs of ctx when there is none
+func FromContext(ctx context.Context, fallback *zap.Logger) *zap.Logger {
+	if l, ok := ctx.Value(contextKey{}).(*zap.Logger); ok {
+		return l
+	}
+	if fields := tracing.Fields(ctx); fields != nil {
+		return fallback.With(fields...)
+	}
+	return fallback
+}
@@ -0,0 +1,84 @@
+// Package metrics holds the Prometheus collectors exposed on /metrics.
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "essay"
+
+// Registry collects every metric of the server; the default registry is not used so tests of other packages stay isolated
+var Registry = prometheus.NewRegistry()
+
+var (
+	// HTTPRequestDuration is labelled by the gin route template, not the raw path, to keep the cardinality bounded
+	HTTPRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request latency by route and status.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	SubmissionsScored = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "submissions_scored_total",
+		Help:      "Submissions scored and stored, by test.",
+	}, []string{"test_id"})
+
+	ScoringDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "scoring_duration_seconds",
+		Help:      "Time spent scoring a submission, by scoring implementation and resulting scored_by.",
+		Buckets:   []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30},
+	}, []string{"scorer", "scored_by"})
+
+	ScoringFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "scoring_failures_total",
+		Help:      "Scoring attempts that returned an error, by scoring implementation.",
+	}, []string{"scorer"})
+
+	RateLimited = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "rate_limited_total",
+		Help:      "Requests rejected with 429, by rate-limit policy.",
+	}, []string{"policy"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		HTTPRequestDuration,
+		SubmissionsScored,
+		ScoringDuration,
+		ScoringFailures,
+		RateLimited,
+	)
+}
+
+// RegisterDBStats exposes the connection pool statistics of the database
+func RegisterDBStats(db *sql.DB) {
+	Registry.MustRegister(collectors.NewDBStatsCollector(db, namespace))
+}
+
+// RegisterQueueDepth exposes the number of submissions waiting in the background scoring queue
+func RegisterQueueDepth(depth func() int) {
+	Registry.MustRegister(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "scoring_queue_depth",
+		Help:      "Submissions waiting in the background scoring queue.",
+	}, func() float64 {
+		return float64(depth())
+	}))
+}
+
+// Handler serves the metrics in the Prometheus text format
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{Registry: Registry})
+}
@@ -0,0 +1,206 @@
+// Package openapi builds an OpenAPI 3 document from route descriptions, deriving the schemas from Go types.
+package openapi
+
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+type Document struct {
+	OpenAPI    string                          `json:"openapi"`
+	Info       Info                            `json:"info"`
+	Paths      map[string]map[string]Operation `json:"paths"`
+	Components Components                      `json:"components"`
+}
+
+type Info struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+type Components struct {
+	Schemas         map[string]*Schema        `json:"schemas"`
+	SecuritySchemes map[string]SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+type SecurityScheme struct {
+	Type        string `json:"type"`
+	In          string `json:"in"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+type Operation struct {
+	Tags        []string              `json:"tags,omitempty"`
+	Summary     string                `json:"summary"`
+	Description string                `json:"description,omitempty"`
+	Parameters  []Parameter           `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]Response   `json:"responses"`
+	Security    []map[string][]string `json:"security,omitempty"`
+}
+
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"` // path, query, header
+	Required    bool    `json:"required,omitempty"`
+	Description string  `json:"description,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Required bool                 `json:"required"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+// Route describes an operation the server registers. Body, Query and Response are zero values of the Go types
+// whose schemas describe the JSON body, the query parameters (from `form` tags) and the `data` of the response envelope.
+type Route struct {
+	Method  string
+	Path    string // ginの書式（/tests/:id）
+	Tag     string
+	Summary string
+	Roles   []string // 必要なロール。空なら誰でも呼び出せる
+
+	Query       interface{}
+	QueryParams []Parameter
+	Headers     []Parameter
+	Body        interface{}
+	Files       []string // multipart/form-dataで送るファイルのフィールド名
+
+	Status   int         // 成功時のステータス（省略時は200）
+	Response interface{} // 応答の封筒（APIResponse）のdata
+	Produces []string    // JSON以外を返す場合のContent-Type
+}
+
+// Builder collects routes into a Document
+type Builder struct {
+	doc      *Document
+	schemas  *schemaRegistry
+	envelope interface{}
+}
+
+// NewBuilder starts a document whose JSON responses are wrapped in envelope, a struct with a `data` field
+func NewBuilder(info Info, envelope interface{}) *Builder {
+	b := &Builder{
+		doc: &Document{
+			OpenAPI: "3.0.3",
+			Info:    info,
+			Paths:   make(map[string]map[string]Operation),
+			Components: Components{
+				SecuritySchemes: map[string]SecurityScheme{
+					"userId":   {Type: "apiKey", In: "header", Name: "X-User-ID", Description: "ゲートウェイが認証した利用者のID"},
+					"userRole": {Type: "apiKey", In: "header", Name: "X-User-Role", Description: "利用者のロール（student, teacher, admin）"},
+				},
+			},
+		},
+		schemas:  newSchemaRegistry(),
+		envelope: envelope,
+	}
+	b.schemas.of(envelope)
+	return b
+}
+
+var pathParam = regexp.MustCompile(`[:*]([A-Za-z0-9_]+)`)
+
+// Add describes a route in the document
+func (b *Builder) Add(r Route) {
+	op := Operation{
+		Summary:   r.Summary,
+		Responses: make(map[string]Response),
+	}
+	if r.Tag != "" {
+		op.Tags = []string{r.Tag}
+	}
+	if len(r.Roles) > 0 {
+		op.Security = []map[string][]string{{"userId": {}, "userRole": {}}}
+		op.Description = "必要なロール: " + strings.Join(r.Roles, ", ")
+	}
+
+	for _, m := range pathParam.FindAllStringSubmatch(r.Path, -1) {
+		op.Parameters = append(op.Parameters, Parameter{Name: m[1], In: "path", Required: true, Schema: &Schema{Type: "string"}})
+	}
+	if r.Query != nil {
+		op.Parameters = append(op.Parameters, b.schemas.queryParams(r.Query)...)
+	}
+	op.Parameters = append(op.Parameters, r.QueryParams...)
+	op.Parameters = append(op.Parameters, r.Headers...)
+
+	switch {
+	case r.Body != nil:
+		op.RequestBody = &RequestBody{
+			Required: true,
+			Content:  map[string]MediaType{"application/json": {Schema: b.schemas.of(r.Body)}},
+		}
+	case len(r.Files) > 0:
+		form := &Schema{Type: "object", Properties: make(map[string]*Schema), Required: r.Files}
+		for _, name := range r.Files {
+			form.Properties[name] = &Schema{Type: "string", Format: "binary"}
+		}
+		op.RequestBody = &RequestBody{
+			Required: true,
+			Content:  map[string]MediaType{"multipart/form-data": {Schema: form}},
+		}
+	}
+
+	status := r.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	success := Response{Description: http.StatusText(status)}
+	if len(r.Produces) > 0 {
+		success.Content = make(map[string]MediaType)
+		for _, contentType := range r.Produces {
+			success.Content[contentType] = MediaType{Schema: &Schema{Type: "string", Format: "binary"}}
+		}
+	} else {
+		success.Content = map[string]MediaType{"application/json": {Schema: b.envelopeOf(r.Response)}}
+	}
+	op.Responses[strconv.Itoa(status)] = success
+	op.Responses["default"] = Response{
+		Description: "エラー",
+		Content:     map[string]MediaType{"application/json": {Schema: b.schemas.of(b.envelope)}},
+	}
+
+	path := pathParam.ReplaceAllString(r.Path, "{$1}")
+	if b.doc.Paths[path] == nil {
+		b.doc.Paths[path] = make(map[string]Operation)
+	}
+	b.doc.Paths[path][strings.ToLower(r.Method)] = op
+}
+
+// envelopeOf is the envelope schema with its data narrowed to the response type
+func (b *Builder) envelopeOf(response interface{}) *Schema {
+	envelope := b.schemas.of(b.envelope)
+	if response == nil {
+		return envelope
+	}
+	return &Schema{AllOf: []*Schema{
+		envelope,
+		{Type: "object", Properties: map[string]*Schema{"data": b.schemas.of(response)}},
+	}}
+}
+
+// Document returns the document with every schema referenced by the routes
+func (b *Builder) Document() *Document {
+	b.doc.Components.Schemas = b.schemas.components
+	return b.doc
+}
+
+// Has reports whether the document describes the operation; path is in gin's format
+func (d *Document) Has(method, path string) bool {
+	_, ok := d.Paths[pathParam.ReplaceAllString(path, "{$1}")][strings.ToLower(method)]
+	return ok
+}
@@ -0,0 +1,66 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMemoryStoreTake(t *testing.T) {
+	now := time.Date(2024, 4, 1, 9, 0, 0, 0, time.UTC)
+	s := NewMemoryStore()
+	s.now = func() time.Time { return now }
+	policy := Policy{PerMinute: 6, Burst: 2} // 10秒に1回補充
+
+	take := func(key string) (bool, time.Duration) {
+		t.Helper()
+		allowed, retryAfter, err := s.Take(context.Background(), key, policy)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return allowed, retryAfter.Round(time.Millisecond)
+	}
+
+	for i := 0; i < 2; i++ {
+		if ok, _ := take("a"); !ok {
+			t.Fatalf("request %d within burst was rejected", i+1)
+		}
+	}
+	if ok, retryAfter := take("a"); ok || retryAfter != 10*time.Second {
+		t.Fatalf("empty bucket: allowed=%v retryAfter=%s, want rejected with 10s", ok, retryAfter)
+	}
+	if ok, _ := take("b"); !ok {
+		t.Fatal("another key shares the bucket")
+	}
+
+	now = now.Add(4 * time.Second)
+	if ok, retryAfter := take("a"); ok || retryAfter != 6*time.Second {
+		t.Fatalf("partly refilled bucket: allowed=%v retryAfter=%s, want rejected with 6s", ok, retryAfter)
+	}
+
+	now = now.Add(6 * time.Second)
+	if ok, _ := take("a"); !ok {
+		t.Fatal("refilled token was not granted")
+	}
+
+	// 満杯を超えて補充しない
+	now = now.Add(time.Hour)
+	for i := 0; i < 2; i++ {
+		if ok, _ := take("a"); !ok {
+			t.Fatalf("request %d after refill was rejected", i+1)
+		}
+	}
+	if ok, _ := take("a"); ok {
+		t.Fatal("bucket refilled beyond its burst")
+	}
+}
+
+func TestMemoryStoreTakeUnlimited(t *testing.T) {
+	s := NewMemoryStore()
+	for i := 0; i < 100; i++ {
+		allowed, _, err := s.Take(context.Background(), "a", Policy{})
+		if err != nil || !allowed {
+			t.Fatalf("unlimited policy rejected request %d: %v", i+1, err)
+		}
+	}
+}
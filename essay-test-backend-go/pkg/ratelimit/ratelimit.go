@@ -0,0 +1,30 @@
+// Package ratelimit limits requests per key with token buckets kept in a pluggable store.
+package ratelimit
+
+import (
+	"context"
+	"time"
+)
+
+// Policy lets a key make Burst requests at once and refills its bucket at PerMinute tokens a minute.
+// A policy without a rate or burst does not limit.
+type Policy struct {
+	PerMinute float64
+	Burst     int
+}
+
+func (p Policy) unlimited() bool {
+	return p.PerMinute <= 0 || p.Burst <= 0
+}
+
+// perSecond is the refill rate of the bucket
+func (p Policy) perSecond() float64 {
+	return p.PerMinute / 60
+}
+
+// Store keeps the buckets. MemoryStore serves a single instance; when several instances share the traffic,
+// implement Store on a shared store such as Redis so that a key's limit holds across them.
+type Store interface {
+	// Take removes a token from the bucket of key; when the bucket is empty it returns how long until a token is available
+	Take(ctx context.Context, key string, policy Policy) (allowed bool, retryAfter time.Duration, err error)
+}
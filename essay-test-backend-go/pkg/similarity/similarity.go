@@ -0,0 +1,190 @@
+package similarity
+
+import (
+	"hash/fnv"
+	"math"
+	"unicode"
+)
+
+// Span represents a copied range in two texts, expressed in rune offsets of the original texts
+type Span struct {
+	Start        int
+	End          int
+	MatchedStart int
+	MatchedEnd   int
+}
+
+// Text is a normalized text ready for shingling
+type Text struct {
+	runes   []rune
+	offsets []int // 正規化後の各文字に対応する元テキストでの位置
+}
+
+// Normalize removes whitespace and punctuation so that formatting differences do not hide copying
+func Normalize(content string) Text {
+	var t Text
+	for i, r := range []rune(content) {
+		if unicode.IsSpace(r) || unicode.IsPunct(r) || unicode.IsSymbol(r) {
+			continue
+		}
+		t.runes = append(t.runes, unicode.ToLower(r))
+		t.offsets = append(t.offsets, i)
+	}
+	return t
+}
+
+// Len returns the number of normalized runes
+func (t Text) Len() int {
+	return len(t.runes)
+}
+
+// Shingles returns the hash of every k-rune shingle in order of appearance
+func (t Text) Shingles(k int) []uint64 {
+	if k <= 0 || len(t.runes) < k {
+		return nil
+	}
+
+	shingles := make([]uint64, 0, len(t.runes)-k+1)
+	buf := make([]byte, 0, k*4)
+	for i := 0; i+k <= len(t.runes); i++ {
+		buf = buf[:0]
+		for _, r := range t.runes[i : i+k] {
+			buf = append(buf, byte(r), byte(r>>8), byte(r>>16), byte(r>>24))
+		}
+		h := fnv.New64a()
+		h.Write(buf)
+		shingles = append(shingles, h.Sum64())
+	}
+	return shingles
+}
+
+// Signature computes a MinHash signature with numHashes hash functions
+func Signature(shingles []uint64, numHashes int) []uint64 {
+	signature := make([]uint64, numHashes)
+	for i := range signature {
+		signature[i] = math.MaxUint64
+	}
+
+	for _, s := range shingles {
+		for i := range signature {
+			if h := mix64(s ^ seed(i)); h < signature[i] {
+				signature[i] = h
+			}
+		}
+	}
+	return signature
+}
+
+// EstimateJaccard estimates the Jaccard similarity of two shingle sets from their signatures
+func EstimateJaccard(a, b []uint64) float64 {
+	if len(a) == 0 || len(a) != len(b) {
+		return 0
+	}
+
+	equal := 0
+	for i := range a {
+		if a[i] == b[i] && a[i] != math.MaxUint64 {
+			equal++
+		}
+	}
+	return float64(equal) / float64(len(a))
+}
+
+// Jaccard returns the exact Jaccard similarity of two shingle lists
+func Jaccard(a, b []uint64) float64 {
+	setA, setB := toSet(a), toSet(b)
+	if len(setA) == 0 && len(setB) == 0 {
+		return 0
+	}
+
+	intersection := 0
+	for s := range setA {
+		if _, ok := setB[s]; ok {
+			intersection++
+		}
+	}
+	return float64(intersection) / float64(len(setA)+len(setB)-intersection)
+}
+
+// Containment returns the share of a's shingles that also appear in b
+func Containment(a, b []uint64) float64 {
+	setA, setB := toSet(a), toSet(b)
+	if len(setA) == 0 {
+		return 0
+	}
+
+	contained := 0
+	for s := range setA {
+		if _, ok := setB[s]; ok {
+			contained++
+		}
+	}
+	return float64(contained) / float64(len(setA))
+}
+
+// OverlapSpans finds the ranges of a that were copied from b
+func OverlapSpans(a, b Text, k int) []Span {
+	shinglesA, shinglesB := a.Shingles(k), b.Shingles(k)
+	if len(shinglesA) == 0 || len(shinglesB) == 0 {
+		return nil
+	}
+
+	positions := make(map[uint64][]int)
+	for j, s := range shinglesB {
+		positions[s] = append(positions[s], j)
+	}
+
+	var spans []Span
+	for i := 0; i < len(shinglesA); {
+		bestStart, bestLen := -1, 0
+		for _, j := range positions[shinglesA[i]] {
+			l := 0
+			for i+l < len(shinglesA) && j+l < len(shinglesB) && shinglesA[i+l] == shinglesB[j+l] {
+				l++
+			}
+			if l > bestLen {
+				bestStart, bestLen = j, l
+			}
+		}
+
+		if bestStart < 0 {
+			i++
+			continue
+		}
+
+		// 連続した一致シングルをひとつの区間にまとめる
+		endA := i + bestLen + k - 1
+		endB := bestStart + bestLen + k - 1
+		spans = append(spans, Span{
+			Start:        a.offsets[i],
+			End:          a.offsets[endA-1] + 1,
+			MatchedStart: b.offsets[bestStart],
+			MatchedEnd:   b.offsets[endB-1] + 1,
+		})
+		i = endA
+	}
+	return spans
+}
+
+func toSet(shingles []uint64) map[uint64]struct{} {
+	set := make(map[uint64]struct{}, len(shingles))
+	for _, s := range shingles {
+		set[s] = struct{}{}
+	}
+	return set
+}
+
+// seed derives a deterministic per-function seed so that stored signatures stay comparable
+func seed(i int) uint64 {
+	return mix64(uint64(i+1) * 0x9e3779b97f4a7c15)
+}
+
+// mix64 is the splitmix64 finalizer
+func mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
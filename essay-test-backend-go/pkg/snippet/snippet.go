@@ -0,0 +1,113 @@
+// Package snippet cuts the part of a text around search terms and locates the terms in it.
+package snippet
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Range is a [Start, End) rune range
+type Range struct {
+	Start int
+	End   int
+}
+
+// Snippet is an excerpt of a text; Start and End locate it in the text and Highlights locate the terms in Text
+type Snippet struct {
+	Text       string
+	Start      int
+	End        int
+	Highlights []Range
+}
+
+// Terms splits a search query into words, dropping the quotes and operators a query language would give meaning to
+func Terms(query string) []string {
+	var terms []string
+	for _, word := range strings.Fields(query) {
+		word = strings.Trim(word, `"+-<>()~*@`)
+		word = strings.ReplaceAll(word, `"`, "")
+		if word != "" {
+			terms = append(terms, word)
+		}
+	}
+	return terms
+}
+
+// Extract cuts about width runes of text starting shortly before the first occurrence of a term;
+// it reports false when no term occurs. Terms match case-insensitively
+func Extract(text string, terms []string, width int) (Snippet, bool) {
+	runes := []rune(text)
+	matches := find(runes, terms)
+	if len(matches) == 0 {
+		return Snippet{}, false
+	}
+
+	// 最初の一致の前に少し文脈を残す
+	start := matches[0].Start - width/5
+	if start < 0 {
+		start = 0
+	}
+	end := start + width
+	if end > len(runes) {
+		end = len(runes)
+		start = end - width
+		if start < 0 {
+			start = 0
+		}
+	}
+
+	s := Snippet{Text: string(runes[start:end]), Start: start, End: end}
+	for _, m := range matches {
+		if m.Start >= start && m.End <= end {
+			s.Highlights = append(s.Highlights, Range{Start: m.Start - start, End: m.End - start})
+		}
+	}
+	return s, true
+}
+
+// find returns the non-overlapping occurrences of the terms in text order, preferring the longest term at a position
+func find(text []rune, terms []string) []Range {
+	lower := make([]rune, len(text))
+	for i, r := range text {
+		lower[i] = unicode.ToLower(r)
+	}
+	patterns := make([][]rune, 0, len(terms))
+	for _, term := range terms {
+		p := []rune(term)
+		for i, r := range p {
+			p[i] = unicode.ToLower(r)
+		}
+		if len(p) > 0 {
+			patterns = append(patterns, p)
+		}
+	}
+
+	var matches []Range
+	for i := 0; i < len(lower); {
+		longest := 0
+		for _, p := range patterns {
+			if len(p) > longest && hasPrefix(lower[i:], p) {
+				longest = len(p)
+			}
+		}
+		if longest == 0 {
+			i++
+			continue
+		}
+		matches = append(matches, Range{Start: i, End: i + longest})
+		i += longest
+	}
+	return matches
+}
+
+func hasPrefix(s, prefix []rune) bool {
+	if len(prefix) > len(s) {
+		return false
+	}
+	for i, r := range prefix {
+		if s[i] != r {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,78 @@
+package stats
+
+// ExactAgreement returns the share of pairs where both ratings are identical
+func ExactAgreement(a, b []int) float64 {
+	return AdjacentAgreement(a, b, 0)
+}
+
+// AdjacentAgreement returns the share of pairs whose ratings differ by at most tolerance
+func AdjacentAgreement(a, b []int, tolerance int) float64 {
+	n := min(len(a), len(b))
+	if n == 0 {
+		return 0
+	}
+
+	agreed := 0
+	for i := 0; i < n; i++ {
+		diff := a[i] - b[i]
+		if diff < 0 {
+			diff = -diff
+		}
+		if diff <= tolerance {
+			agreed++
+		}
+	}
+	return float64(agreed) / float64(n)
+}
+
+// QuadraticWeightedKappa returns Cohen's kappa with quadratic weights for ratings in [minRating, maxRating]
+func QuadraticWeightedKappa(a, b []int, minRating, maxRating int) float64 {
+	n := min(len(a), len(b))
+	k := maxRating - minRating + 1
+	if n == 0 || k <= 1 {
+		return 1
+	}
+
+	observed := make([][]float64, k)
+	for i := range observed {
+		observed[i] = make([]float64, k)
+	}
+	histA := make([]float64, k)
+	histB := make([]float64, k)
+	for i := 0; i < n; i++ {
+		x := clamp(a[i], minRating, maxRating) - minRating
+		y := clamp(b[i], minRating, maxRating) - minRating
+		observed[x][y]++
+		histA[x]++
+		histB[y]++
+	}
+
+	var numerator, denominator float64
+	for i := 0; i < k; i++ {
+		for j := 0; j < k; j++ {
+			weight := float64((i-j)*(i-j)) / float64((k-1)*(k-1))
+			expected := histA[i] * histB[j] / float64(n)
+			numerator += weight * observed[i][j]
+			denominator += weight * expected
+		}
+	}
+
+	// 全員が同じ点数をつけた場合は期待不一致が0になる
+	if denominator == 0 {
+		if numerator == 0 {
+			return 1
+		}
+		return 0
+	}
+	return 1 - numerator/denominator
+}
+
+func clamp(v, lo, hi int) int {
+	if v < lo {
+		return lo
+	}
+	if v > hi {
+		return hi
+	}
+	return v
+}
@@ -0,0 +1,85 @@
+package stats
+
+import (
+	"math"
+	"sort"
+)
+
+// CountsMean returns the mean of integer values given as value -> count
+func CountsMean(counts map[int]int) float64 {
+	n, sum := 0, 0
+	for v, c := range counts {
+		n += c
+		sum += v * c
+	}
+	if n == 0 {
+		return 0
+	}
+	return float64(sum) / float64(n)
+}
+
+// CountsMedian returns the median of integer values given as value -> count
+func CountsMedian(counts map[int]int) float64 {
+	values := make([]int, 0, len(counts))
+	n := 0
+	for v, c := range counts {
+		if c > 0 {
+			values = append(values, v)
+			n += c
+		}
+	}
+	if n == 0 {
+		return 0
+	}
+	sort.Ints(values)
+
+	// 中央の1つ（偶数件なら2つ）の順位にある値を探す
+	lower, upper := (n-1)/2, n/2
+	var lowerValue, upperValue int
+	seen := 0
+	for _, v := range values {
+		next := seen + counts[v]
+		if lower >= seen && lower < next {
+			lowerValue = v
+		}
+		if upper >= seen && upper < next {
+			upperValue = v
+			break
+		}
+		seen = next
+	}
+	return float64(lowerValue+upperValue) / 2
+}
+
+// CountsStdDev returns the population standard deviation of integer values given as value -> count
+func CountsStdDev(counts map[int]int) float64 {
+	n := 0
+	for _, c := range counts {
+		n += c
+	}
+	if n == 0 {
+		return 0
+	}
+
+	mean := CountsMean(counts)
+	sum := 0.0
+	for v, c := range counts {
+		d := float64(v) - mean
+		sum += d * d * float64(c)
+	}
+	return math.Sqrt(sum / float64(n))
+}
+
+// Correlation returns Pearson's r from running sums, or 0 when either variable has no variance
+func Correlation(n, sumX, sumY, sumXX, sumYY, sumXY float64) float64 {
+	if n < 2 {
+		return 0
+	}
+	cov := n*sumXY - sumX*sumY
+	varX := n*sumXX - sumX*sumX
+	varY := n*sumYY - sumY*sumY
+	if varX <= 0 || varY <= 0 {
+		return 0
+	}
+	return cov / math.Sqrt(varX*varY)
+}
@@ -0,0 +1,64 @@
+package stats
+
+import "math"
+
+// Mean returns the arithmetic mean, or 0 for an empty slice
+func Mean(xs []float64) float64 {
+	if len(xs) == 0 {
+		return 0
+	}
+	sum := 0.0
+	for _, x := range xs {
+		sum += x
+	}
+	return sum / float64(len(xs))
+}
+
+// MeanAbsoluteError returns the mean of |predicted - actual|
+func MeanAbsoluteError(predicted, actual []float64) float64 {
+	n := min(len(predicted), len(actual))
+	if n == 0 {
+		return 0
+	}
+	sum := 0.0
+	for i := 0; i < n; i++ {
+		sum += math.Abs(predicted[i] - actual[i])
+	}
+	return sum / float64(n)
+}
+
+// RootMeanSquaredError returns the square root of the mean of (predicted - actual)^2
+func RootMeanSquaredError(predicted, actual []float64) float64 {
+	n := min(len(predicted), len(actual))
+	if n == 0 {
+		return 0
+	}
+	sum := 0.0
+	for i := 0; i < n; i++ {
+		d := predicted[i] - actual[i]
+		sum += d * d
+	}
+	return math.Sqrt(sum / float64(n))
+}
+
+// LinearFit returns the least squares line y = slope*x + intercept.
+// When x has no variance the line only corrects the mean offset.
+func LinearFit(x, y []float64) (slope, intercept float64) {
+	n := min(len(x), len(y))
+	if n == 0 {
+		return 1, 0
+	}
+
+	meanX, meanY := Mean(x[:n]), Mean(y[:n])
+	var sxx, sxy float64
+	for i := 0; i < n; i++ {
+		sxx += (x[i] - meanX) * (x[i] - meanX)
+		sxy += (x[i] - meanX) * (y[i] - meanY)
+	}
+	if sxx == 0 {
+		return 1, meanY - meanX
+	}
+
+	slope = sxy / sxx
+	return slope, meanY - slope*meanX
+}
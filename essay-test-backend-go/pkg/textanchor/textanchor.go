@@ -0,0 +1,48 @@
+package textanchor
+
+import "unicode"
+
+// Normalize converts a [start, end) rune range into offsets that ignore whitespace,
+// so that the range survives whitespace normalization of the text
+func Normalize(content string, start, end int) (int, int) {
+	normalizedStart, normalizedEnd := 0, 0
+	for i, r := range []rune(content) {
+		if unicode.IsSpace(r) {
+			continue
+		}
+		if i < start {
+			normalizedStart++
+		}
+		if i < end {
+			normalizedEnd++
+		}
+	}
+	return normalizedStart, normalizedEnd
+}
+
+// Resolve maps whitespace-insensitive offsets back to a rune range of content
+func Resolve(content string, normalizedStart, normalizedEnd int) (int, int, bool) {
+	if normalizedStart < 0 || normalizedEnd <= normalizedStart {
+		return 0, 0, false
+	}
+
+	start, end := -1, -1
+	n := 0
+	for i, r := range []rune(content) {
+		if unicode.IsSpace(r) {
+			continue
+		}
+		if n == normalizedStart {
+			start = i
+		}
+		n++
+		if n == normalizedEnd {
+			end = i + 1
+			break
+		}
+	}
+	if start < 0 || end < 0 {
+		return 0, 0, false
+	}
+	return start, end, true
+}
@@ -0,0 +1,238 @@
+package textfeatures
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Excerpt is a sentence of the analyzed text, located by rune offsets of the original text
+type Excerpt struct {
+	Text  string
+	Start int
+	End   int
+}
+
+// Features holds the structural signals detected in an essay
+type Features struct {
+	Length          int
+	Paragraphs      int
+	Sentences       []Excerpt
+	Opinion         *Excerpt // 自分の立場を示す最初の文
+	Reason          *Excerpt // 根拠を示す最初の文
+	Example         *Excerpt // 具体例を挙げる最初の文
+	Counterargument *Excerpt // 反対意見に触れる最初の文
+	Conclusion      *Excerpt // 最終段落でまとめを示す文
+	LongestSentence *Excerpt
+	ExampleCount    int
+}
+
+// Sentence styles reported by SentenceStyle
+const (
+	StylePolite = "polite" // です・ます調
+	StylePlain  = "plain"  // だ・である調
+)
+
+// Thesis positions reported by ThesisPosition
+const (
+	PositionOpening = "opening"
+	PositionMiddle  = "middle"
+	PositionClosing = "closing"
+	PositionNone    = "none"
+)
+
+var (
+	opinionMarkers         = []string{"私は", "私の考え", "と考える", "と思う", "べきだ", "べきである"}
+	reasonMarkers          = []string{"なぜなら", "理由", "根拠", "からだ", "からである"}
+	exampleMarkers         = []string{"例えば", "たとえば", "具体的に", "実際に", "例として"}
+	counterargumentMarkers = []string{"確かに", "たしかに", "もちろん", "一方で", "反対に", "という意見もある", "という考えもある", "反論"}
+	politeEndings          = []string{"です", "ます", "でした", "ました", "ません", "でしょう", "ましょう"}
+	plainEndings           = []string{"だ", "である", "であろう", "だろう", "ない", "た", "る", "う"}
+	conclusionMarkers      = []string{"結論", "以上", "このように", "したがって", "よって", "つまり", "以上のことから"}
+)
+
+// Analyze splits content into paragraphs and sentences and detects the argumentative markers
+func Analyze(content string) Features {
+	runes := []rune(content)
+	f := Features{Length: len(runes)}
+
+	lastParagraphStart := 0
+	start := 0
+	inParagraph := false
+	flush := func(end int) {
+		if e, ok := trim(runes, start, end); ok {
+			f.Sentences = append(f.Sentences, e)
+		}
+		start = end
+	}
+	for i, r := range runes {
+		if r == '\n' {
+			flush(i)
+			start = i + 1
+			inParagraph = false
+			continue
+		}
+		if !inParagraph && !unicode.IsSpace(r) {
+			inParagraph = true
+			f.Paragraphs++
+			lastParagraphStart = i
+		}
+		if r == '。' || r == '！' || r == '？' || r == '!' || r == '?' {
+			flush(i + 1)
+		}
+	}
+	flush(len(runes))
+
+	for i := range f.Sentences {
+		s := &f.Sentences[i]
+		if f.Opinion == nil && containsAny(s.Text, opinionMarkers) {
+			f.Opinion = s
+		}
+		if f.Reason == nil && containsAny(s.Text, reasonMarkers) {
+			f.Reason = s
+		}
+		if containsAny(s.Text, exampleMarkers) {
+			f.ExampleCount++
+			if f.Example == nil {
+				f.Example = s
+			}
+		}
+		if f.Counterargument == nil && containsAny(s.Text, counterargumentMarkers) {
+			f.Counterargument = s
+		}
+		if s.Start >= lastParagraphStart && containsAny(s.Text, conclusionMarkers) {
+			f.Conclusion = s
+		}
+		if f.LongestSentence == nil || len([]rune(s.Text)) > len([]rune(f.LongestSentence.Text)) {
+			f.LongestSentence = s
+		}
+	}
+
+	// 段落分けのない文章は末尾の2文にまとめがある場合だけ結論とみなす
+	if f.Conclusion != nil && f.Paragraphs <= 1 && len(f.Sentences) > 2 && f.Conclusion.Start < f.Sentences[len(f.Sentences)-2].Start {
+		f.Conclusion = nil
+	}
+	return f
+}
+
+// WeakConclusion reports whether the essay ends without a concluding statement
+func (f Features) WeakConclusion() bool {
+	return f.Conclusion == nil
+}
+
+// ThesisPosition reports in which third of the text the writer first states their position
+func (f Features) ThesisPosition() string {
+	if f.Opinion == nil || f.Length == 0 {
+		return PositionNone
+	}
+
+	switch ratio := float64(f.Opinion.Start) / float64(f.Length); {
+	case ratio < 1.0/3:
+		return PositionOpening
+	case ratio < 2.0/3:
+		return PositionMiddle
+	default:
+		return PositionClosing
+	}
+}
+
+// AverageSentenceLength returns the mean number of runes per sentence
+func (f Features) AverageSentenceLength() float64 {
+	if len(f.Sentences) == 0 {
+		return 0
+	}
+	total := 0
+	for _, s := range f.Sentences {
+		total += len([]rune(s.Text))
+	}
+	return float64(total) / float64(len(f.Sentences))
+}
+
+// SentencesContaining returns the sentences that mention any of the keywords
+func (f Features) SentencesContaining(keywords []string) []Excerpt {
+	var found []Excerpt
+	for _, s := range f.Sentences {
+		if containsAny(s.Text, keywords) {
+			found = append(found, s)
+		}
+	}
+	return found
+}
+
+// 語句として扱う最小の文字数
+const minTermRunes = 2
+
+// Terms returns the runs of kanji, katakana and alphanumerics in text, which carry the content words of Japanese text
+// while particles and inflections are written in hiragana. Runs shorter than two characters are dropped.
+func Terms(text string) []string {
+	var terms []string
+	var term []rune
+	flush := func() {
+		if len(term) >= minTermRunes {
+			terms = append(terms, string(term))
+		}
+		term = term[:0]
+	}
+	for _, r := range text {
+		if isTermRune(r) {
+			term = append(term, r)
+			continue
+		}
+		flush()
+	}
+	flush()
+	return terms
+}
+
+func isTermRune(r rune) bool {
+	if unicode.In(r, unicode.Han, unicode.Katakana) || r == 'ー' {
+		return true
+	}
+	return (unicode.IsLetter(r) || unicode.IsDigit(r)) && !unicode.Is(unicode.Hiragana, r)
+}
+
+// Quote formats an excerpt as a Japanese quotation, shortened to maxRunes
+func Quote(e Excerpt, maxRunes int) string {
+	text := []rune(e.Text)
+	if maxRunes > 0 && len(text) > maxRunes {
+		return "「" + string(text[:maxRunes]) + "…」"
+	}
+	return "「" + string(text) + "」"
+}
+
+// SentenceStyle classifies a sentence by its ending, or returns "" when the ending is ambiguous
+func SentenceStyle(sentence string) string {
+	text := strings.TrimRight(sentence, "。！？!?」』）) \t")
+	for _, ending := range politeEndings {
+		if strings.HasSuffix(text, ending) {
+			return StylePolite
+		}
+	}
+	for _, ending := range plainEndings {
+		if strings.HasSuffix(text, ending) {
+			return StylePlain
+		}
+	}
+	return ""
+}
+
+func trim(runes []rune, start, end int) (Excerpt, bool) {
+	for start < end && unicode.IsSpace(runes[start]) {
+		start++
+	}
+	for end > start && unicode.IsSpace(runes[end-1]) {
+		end--
+	}
+	if start >= end {
+		return Excerpt{}, false
+	}
+	return Excerpt{Text: string(runes[start:end]), Start: start, End: end}, true
+}
+
+func containsAny(text string, markers []string) bool {
+	for _, m := range markers {
+		if strings.Contains(text, m) {
+			return true
+		}
+	}
+	return false
+}